@@ -0,0 +1,189 @@
+package crud
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// nonNumericTypes are the struct types that map to columns an aggregate cannot be scanned from as a number.
+var nonNumericTypes = map[reflect.Type]bool{
+	reflect.TypeFor[time.Time]():      true,
+	reflect.TypeFor[sql.NullString](): true,
+	reflect.TypeFor[sql.NullTime]():   true,
+	reflect.TypeFor[sql.NullBool]():   true,
+}
+
+// GroupedAggregate runs SELECT groupCols..., selectExprs... FROM table WHERE ... GROUP BY groupCols and scans
+// each row into an R, whose `db` tags must match the group columns and the aliases of the expressions, e.g.
+//
+//	type StatusCount struct {
+//		Status string `db:"status"`
+//		Total  int64  `db:"total"`
+//	}
+//	counts, err := GroupedAggregate[Order, StatusCount](ctx, orderRepo, []string{"status"}, []string{"COUNT(*) AS total"})
+//
+// Group columns must be columns of T. The expressions are inserted verbatim, so they must not contain untrusted
+// input. Joins, WHERE conditions, ordering, limit (including LimitWithTies) and offset from opts are applied;
+// relations are ignored.
+func GroupedAggregate[T any, R any](
+	ctx context.Context, repo RepositoryInterface[T], groupCols []string, selectExprs []string, opts ...Option[T],
+) ([]R, error) {
+	r, err := baseOf(repo)
+	if err != nil {
+		return nil, err
+	}
+	if len(groupCols) == 0 {
+		return nil, fmt.Errorf("GroupedAggregate requires at least one group column")
+	}
+
+	selectCols := make([]string, 0, len(groupCols)+len(selectExprs))
+	groupBy := make([]string, len(groupCols))
+	for i, col := range groupCols {
+		if _, ok := r.scanMap[col]; !ok {
+			return nil, fmt.Errorf("unknown group column '%s' for table %s", col, r.tableName)
+		}
+		groupBy[i] = r.qualify(col)
+		selectCols = append(selectCols, fmt.Sprintf("%s AS %s", groupBy[i], r.quote(col)))
+	}
+	selectCols = append(selectCols, selectExprs...)
+
+	qb, err := r.applyOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := qb.checkExpr(selectExprs...); err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(selectCols, ", "), qb.from())
+	if len(qb.joinClauses) > 0 {
+		query += " " + strings.Join(qb.joinClauses, " ")
+	}
+	if where := qb.whereSQL(); where != "" {
+		query += " WHERE " + where
+	}
+	query += " GROUP BY " + strings.Join(groupBy, ", ")
+	query += orderLimitSQL(r.dialect, strings.Join(qb.orderByClauses, ", "), qb.limit, qb.offset)
+	query += qb.limitSQL()
+
+	restore, err := r.applyTxSettings(ctx, qb)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+	e, err := r.getReadExecutor(ctx, qb)
+	if err != nil {
+		return nil, err
+	}
+	qctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	rows, err := e.QueryContext(qctx, query, qb.args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanInto[R](rows)
+}
+
+// Sum returns SUM(column) over the records matching opts, or 0 when the aggregate is NULL
+// (no record matches). Only joins and WHERE conditions from opts are taken into account; use
+// SumNull to tell "no rows" apart from a genuine 0.
+func (r *Repository[T]) Sum(ctx context.Context, column string, opts ...Option[T]) (float64, error) {
+	result, err := r.aggregate(ctx, "SUM", column, opts)
+	return result.Float64, err
+}
+
+// SumNull is like Sum but returns an invalid sql.NullFloat64 when the aggregate is NULL.
+func (r *Repository[T]) SumNull(ctx context.Context, column string, opts ...Option[T]) (sql.NullFloat64, error) {
+	return r.aggregate(ctx, "SUM", column, opts)
+}
+
+// Avg returns AVG(column) over the records matching opts, or 0 when the aggregate is NULL
+// (no record matches). Only joins and WHERE conditions from opts are taken into account; use
+// AvgNull to tell "no rows" apart from a genuine 0.
+func (r *Repository[T]) Avg(ctx context.Context, column string, opts ...Option[T]) (float64, error) {
+	result, err := r.aggregate(ctx, "AVG", column, opts)
+	return result.Float64, err
+}
+
+// AvgNull is like Avg but returns an invalid sql.NullFloat64 when the aggregate is NULL.
+func (r *Repository[T]) AvgNull(ctx context.Context, column string, opts ...Option[T]) (sql.NullFloat64, error) {
+	return r.aggregate(ctx, "AVG", column, opts)
+}
+
+// Min returns MIN(column) over the records matching opts, or 0 when the aggregate is NULL
+// (no record matches). Only joins and WHERE conditions from opts are taken into account; use
+// MinNull to tell "no rows" apart from a genuine 0.
+func (r *Repository[T]) Min(ctx context.Context, column string, opts ...Option[T]) (float64, error) {
+	result, err := r.aggregate(ctx, "MIN", column, opts)
+	return result.Float64, err
+}
+
+// MinNull is like Min but returns an invalid sql.NullFloat64 when the aggregate is NULL.
+func (r *Repository[T]) MinNull(ctx context.Context, column string, opts ...Option[T]) (sql.NullFloat64, error) {
+	return r.aggregate(ctx, "MIN", column, opts)
+}
+
+// Max returns MAX(column) over the records matching opts, or 0 when the aggregate is NULL
+// (no record matches). Only joins and WHERE conditions from opts are taken into account; use
+// MaxNull to tell "no rows" apart from a genuine 0.
+func (r *Repository[T]) Max(ctx context.Context, column string, opts ...Option[T]) (float64, error) {
+	result, err := r.aggregate(ctx, "MAX", column, opts)
+	return result.Float64, err
+}
+
+// MaxNull is like Max but returns an invalid sql.NullFloat64 when the aggregate is NULL.
+func (r *Repository[T]) MaxNull(ctx context.Context, column string, opts ...Option[T]) (sql.NullFloat64, error) {
+	return r.aggregate(ctx, "MAX", column, opts)
+}
+
+// aggregate runs SELECT fn(table.column) with the joins and WHERE conditions of opts. The result is NULL
+// when no record matches (or all values are NULL). The column is validated since it is inserted into the SQL,
+// and must be mapped to a numeric field, since the result is scanned as a float64.
+func (r *Repository[T]) aggregate(ctx context.Context, fn, column string, opts []Option[T]) (sql.NullFloat64, error) {
+	var result sql.NullFloat64
+	if _, ok := r.scanMap[column]; !ok {
+		return result, fmt.Errorf("unknown column '%s' for table %s in %s", column, r.tableName, fn)
+	}
+	for _, f := range r.fields {
+		t := f.fieldType
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if f.columnName == column && (t.Kind() == reflect.String || t.Kind() == reflect.Bool || nonNumericTypes[t]) {
+			return result, fmt.Errorf("%s requires a numeric column, but '%s' is mapped to %s; use GroupedAggregate or a raw query",
+				fn, column, f.fieldType)
+		}
+	}
+
+	qb, err := r.applyOptions(opts)
+	if err != nil {
+		return result, err
+	}
+
+	query := r.dialect.SelectSQL(
+		qb.from(),
+		[]string{fmt.Sprintf("%s(%s)", fn, r.qualify(column))},
+		strings.Join(qb.joinClauses, " "),
+		qb.whereSQL(),
+		"", "", 0, 0,
+	)
+	restore, err := r.applyTxSettings(ctx, qb)
+	if err != nil {
+		return result, err
+	}
+	defer restore()
+	e, err := r.getReadExecutor(ctx, qb)
+	if err != nil {
+		return result, err
+	}
+	qctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	if err := e.QueryRowContext(qctx, query, qb.args...).Scan(&result); err != nil {
+		return result, fmt.Errorf("%s query failed: %w", strings.ToLower(fn), err)
+	}
+	return result, nil
+}
@@ -0,0 +1,452 @@
+package crud
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// The interfaces below are optional extensions of Dialect. The built-in dialects implement all of them; a
+// custom dialect only needs to implement those whose default does not suit its database. A dialect that
+// embeds a built-in one inherits its implementations.
+
+// ParameterLimiter reports how many bind parameters a single statement may carry, which bounds the rows of
+// a CreateMany batch. Without it, the limit is 999, the lowest among the databases in common use.
+type ParameterLimiter interface {
+	MaxParameters() int
+}
+
+// UniqueViolationDetector recognizes unique constraint violations in driver errors, so that they can be
+// reported as ErrDuplicate. Without it, no error is treated as a unique violation.
+type UniqueViolationDetector interface {
+	IsUniqueViolation(err error) (constraint string, ok bool)
+}
+
+// RowLocker returns the row locking clause for LockForUpdate, LockForShare and SkipLocked. Without it, the
+// standard FOR UPDATE / FOR SHARE [SKIP LOCKED] clause is used.
+type RowLocker interface {
+	RowLockSQL(mode LockMode, skipLocked bool) (string, error)
+}
+
+// IdentifierQuoter quotes table and column names that are reserved words. Without it, each segment of the
+// name is quoted with ANSI double quotes.
+type IdentifierQuoter interface {
+	QuoteIdentifier(name string) string
+}
+
+// ReservedWordChecker reports which words are reserved by the database, so that table and column names
+// using them are quoted with QuoteIdentifier. Without it, a list of the words reserved by the common
+// databases that are likely to be used as names (order, group, user, ...) is used.
+type ReservedWordChecker interface {
+	IsReserved(word string) bool
+}
+
+// TimestampProvider returns the SQL expression for the database clock used by WhereColumnOpNow. Without it,
+// CURRENT_TIMESTAMP is used.
+type TimestampProvider interface {
+	CurrentTimestampSQL() string
+}
+
+// TableCreator customizes the statements of AutoMigrate. Without it, auto-incremented primary keys get no
+// extra attributes and tables are created with CREATE TABLE IF NOT EXISTS.
+type TableCreator interface {
+	AutoIncrementSQL() string
+	CreateTableSQL(tableName string, columnDefs []string) string
+}
+
+// LiteralProvider returns the literals emitted in place of bound values, for boolean comparisons and by
+// WhereNull and WhereNotNull. Without it, TRUE, FALSE and NULL are used.
+type LiteralProvider interface {
+	TrueLiteral() string
+	FalseLiteral() string
+	NullLiteral() string
+}
+
+// ReturningInserter lets inserts read generated columns back in the same statement. Without it, or when
+// SupportsReturning is false, generated keys are read with LastInsertId.
+type ReturningInserter interface {
+	SupportsReturning() bool
+	InsertReturningSQL(insertSQL string, returningCols []string) string
+}
+
+// OrderLimiter builds the ORDER BY, LIMIT and OFFSET part of queries that SelectSQL cannot produce, such as
+// the GROUP BY queries of GroupedAggregate. OrderLimitSQL returns the clauses with a leading space, or "" if
+// there is nothing to add. Without it, the standard clauses are used, with a bare OFFSET when no limit is set.
+type OrderLimiter interface {
+	OrderLimitSQL(orderByClause string, limit, offset int) string
+}
+
+// InsertIDSelector is implemented by dialects whose drivers do not support LastInsertId. LastInsertIDSQL
+// returns the statement appended to an INSERT, in the same batch, that selects the last key generated by
+// it as a single integer.
+type InsertIDSelector interface {
+	LastInsertIDSQL() string
+}
+
+// Savepointer returns the statements of the savepoints behind PropagationNested, for a TxBundle that was
+// given the dialect with SetDialect. Without it, the standard SAVEPOINT, RELEASE SAVEPOINT and ROLLBACK TO
+// SAVEPOINT statements are used. An empty release statement is not executed.
+type Savepointer interface {
+	SavepointSQL(name string) string
+	ReleaseSavepointSQL(name string) string
+	RollbackToSavepointSQL(name string) string
+}
+
+// LockTimeoutResetter is implemented by dialects whose LockTimeoutSQL statement changes the session rather
+// than the transaction. The statement returned by ResetLockTimeoutSQL runs after the query, on the same
+// transaction, so that the timeout does not carry over to later users of the pooled connection.
+type LockTimeoutResetter interface {
+	ResetLockTimeoutSQL() string
+}
+
+// ReturningDeleter lets DeleteWhereReturning read the deleted rows back in the DELETE statement itself.
+// Without it, or when SupportsDeleteReturning is false, the rows are selected and then deleted in a single
+// transaction.
+type ReturningDeleter interface {
+	SupportsDeleteReturning() bool
+	DeleteReturningSQL(deleteSQL string, returningCols []string) string
+}
+
+// FirstInsertIDReporter is implemented by dialects whose LastInsertId reports the key generated for the
+// first row of a multi-row INSERT, rather than for the last one, when FirstInsertID returns true.
+type FirstInsertIDReporter interface {
+	FirstInsertID() bool
+}
+
+// WindowCounter lets Paginate read the total number of matching records in the same query as the page,
+// with COUNT(*) OVER(). Without it, or when SupportsWindowCount is false, a separate COUNT query is run.
+type WindowCounter interface {
+	SupportsWindowCount() bool
+}
+
+// ValuesLister builds typed VALUES lists usable as a table in FROM or JOIN, which lets UpdateMany apply a
+// chunk of items with a single UPDATE ... FROM statement and WhereInLarge match large sets with a semi-join.
+// ValuesType returns the type a parameter of Go type t is cast to in the list, or false if it cannot be
+// inferred. Without it, UpdateMany updates the items one by one and WhereInLarge uses a plain IN list.
+type ValuesLister interface {
+	ValuesSQL(alias string, cols, types []string, rows [][]string) string
+	ValuesType(t reflect.Type) (string, bool)
+}
+
+// CostEstimator lets EstimateCost read the planner's cost of a query. ExplainSQL wraps the SELECT statement
+// in the dialect's EXPLAIN, which must return the plan as a single value, and ExplainCost reads the total
+// cost from it. Without it, EstimateCost returns an error wrapping errors.ErrUnsupported.
+type CostEstimator interface {
+	ExplainSQL(selectSQL string) string
+	ExplainCost(plan []byte) (float64, error)
+}
+
+// BulkInserter builds the multi-row INSERT statements of CreateMany. Without it, DefaultBulkInsertSQL is used.
+type BulkInserter interface {
+	BulkInsertSQL(tableName string, cols []string, rows [][]string) string
+}
+
+// Collator returns the COLLATE clause of OrderByCollate. Without it, COLLATE followed by the unquoted
+// collation name is used.
+type Collator interface {
+	Collate(collation string) string
+}
+
+// JoinTypeChecker reports which join kinds (JoinInner, JoinLeft, ...) the database supports, so that the
+// typed join options fail early with errors.ErrUnsupported. Without it, every kind is assumed to be supported.
+type JoinTypeChecker interface {
+	SupportsJoinType(kind string) bool
+}
+
+// Partitioner returns the FROM target that restricts a query to one partition of the table, for
+// FromPartition. Without it, FromPartition returns an error wrapping errors.ErrUnsupported.
+type Partitioner interface {
+	PartitionTable(tableName, partition string) (string, error)
+}
+
+// Notifier returns the statement that publishes the change notifications of WithChangeNotifications, taking
+// the channel and payload as arguments. Without it, NewRepository rejects the option with errors.ErrUnsupported.
+type Notifier interface {
+	NotifySQL() (string, error)
+}
+
+// LockTimeoutSetter returns the statement that bounds lock waits for WithLockTimeout, or "" if the database
+// needs none. Without it, WithLockTimeout fails with errors.ErrUnsupported.
+type LockTimeoutSetter interface {
+	LockTimeoutSQL(d time.Duration) (string, error)
+}
+
+// TiesLimiter returns the clause of LimitWithTies. Without it, LimitWithTies fails with errors.ErrUnsupported.
+type TiesLimiter interface {
+	LimitWithTiesSQL(n int) (string, error)
+}
+
+// SessionSetter returns the statement that changes a setting for the rest of the transaction, for
+// WithSessionSetting. Without it, WithSessionSetting fails with errors.ErrUnsupported.
+type SessionSetter interface {
+	SessionSettingSQL(setting, value string) (string, error)
+}
+
+// JSONMatcher returns the JSON containment condition of WhereJSONContains. Without it, WhereJSONContains
+// fails with errors.ErrUnsupported.
+type JSONMatcher interface {
+	JSONContainsSQL(column, placeholder string) (string, error)
+}
+
+// ColumnTyper maps Go field types to column types for AutoMigrate. Without it, AutoMigrate fails with
+// errors.ErrUnsupported.
+type ColumnTyper interface {
+	ColumnType(goType reflect.Type) string
+}
+
+// ColumnUpserter builds the upsert of CreateOrUpdate restricted to updateCols, so that insertonly columns
+// and the created column of WithTimestamps keep their stored value. Without it, UpsertSQL is used, which
+// overwrites every column but the primary key, and CreateOrUpdate fails with errors.ErrUnsupported for
+// models whose upsert must leave some columns untouched.
+type ColumnUpserter interface {
+	UpsertColumnsSQL(tableName string, pkColumn string, cols, updateCols []string) string
+}
+
+// defaultMaxParameters is the parameter limit of dialects that do not implement ParameterLimiter.
+const defaultMaxParameters = 999
+
+// maxParameters returns the parameter limit of d; see ParameterLimiter.
+func maxParameters(d Dialect) int {
+	if l, ok := d.(ParameterLimiter); ok {
+		return l.MaxParameters()
+	}
+	return defaultMaxParameters
+}
+
+// isUniqueViolation reports whether err is a unique violation according to d; see UniqueViolationDetector.
+func isUniqueViolation(d Dialect, err error) (string, bool) {
+	if u, ok := d.(UniqueViolationDetector); ok {
+		return u.IsUniqueViolation(err)
+	}
+	return "", false
+}
+
+// rowLockSQL returns the locking clause of d for the mode; see RowLocker.
+func rowLockSQL(d Dialect, mode LockMode, skipLocked bool) (string, error) {
+	if l, ok := d.(RowLocker); ok {
+		return l.RowLockSQL(mode, skipLocked)
+	}
+	return forLockSQL(mode, skipLocked)
+}
+
+// quoteIdentifier quotes name for d; see IdentifierQuoter.
+func quoteIdentifier(d Dialect, name string) string {
+	if q, ok := d.(IdentifierQuoter); ok {
+		return q.QuoteIdentifier(name)
+	}
+	return quoteSegments(name, `"`, `"`)
+}
+
+// isReservedWord reports whether word must be quoted for d; see ReservedWordChecker.
+func isReservedWord(d Dialect, word string) bool {
+	if c, ok := d.(ReservedWordChecker); ok {
+		return c.IsReserved(word)
+	}
+	return commonReservedWords[strings.ToLower(word)]
+}
+
+// currentTimestampSQL returns the clock expression of d; see TimestampProvider.
+func currentTimestampSQL(d Dialect) string {
+	if t, ok := d.(TimestampProvider); ok {
+		return t.CurrentTimestampSQL()
+	}
+	return "CURRENT_TIMESTAMP"
+}
+
+// autoIncrementSQL returns the attributes of an auto-incremented primary key for d; see TableCreator.
+func autoIncrementSQL(d Dialect) string {
+	if c, ok := d.(TableCreator); ok {
+		return c.AutoIncrementSQL()
+	}
+	return ""
+}
+
+// createTableSQL returns the CREATE TABLE statement of d; see TableCreator.
+func createTableSQL(d Dialect, tableName string, columnDefs []string) string {
+	if c, ok := d.(TableCreator); ok {
+		return c.CreateTableSQL(tableName, columnDefs)
+	}
+	return defaultCreateTableSQL(tableName, columnDefs)
+}
+
+// boolLiteral returns the boolean literal of d for value; see LiteralProvider.
+func boolLiteral(d Dialect, value bool) string {
+	l, ok := d.(LiteralProvider)
+	switch {
+	case ok && value:
+		return l.TrueLiteral()
+	case ok:
+		return l.FalseLiteral()
+	}
+	if value {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+// nullLiteral returns the NULL literal of d; see LiteralProvider.
+func nullLiteral(d Dialect) string {
+	if l, ok := d.(LiteralProvider); ok {
+		return l.NullLiteral()
+	}
+	return "NULL"
+}
+
+// orderLimitSQL returns the ORDER BY, LIMIT and OFFSET clauses of d, with a leading space; see OrderLimiter.
+func orderLimitSQL(d Dialect, orderByClause string, limit, offset int) string {
+	if l, ok := d.(OrderLimiter); ok {
+		return l.OrderLimitSQL(orderByClause, limit, offset)
+	}
+	return orderLimitTail(orderByClause, limit, offset, "")
+}
+
+// lastInsertIDSQL returns the statement of d that selects the generated key after an INSERT, or "" if
+// LastInsertId is used; see InsertIDSelector.
+func lastInsertIDSQL(d Dialect) string {
+	if s, ok := d.(InsertIDSelector); ok {
+		return s.LastInsertIDSQL()
+	}
+	return ""
+}
+
+// savepointStatements returns the statements that create, release and roll back to the savepoint name;
+// see Savepointer. d may be nil.
+func savepointStatements(d Dialect, name string) (create, release, rollback string) {
+	if s, ok := d.(Savepointer); ok {
+		return s.SavepointSQL(name), s.ReleaseSavepointSQL(name), s.RollbackToSavepointSQL(name)
+	}
+	return "SAVEPOINT " + name, "RELEASE SAVEPOINT " + name, "ROLLBACK TO SAVEPOINT " + name
+}
+
+// supportsReturning reports whether inserts can read generated columns back with RETURNING.
+func supportsReturning(d Dialect) bool {
+	r, ok := d.(ReturningInserter)
+	return ok && r.SupportsReturning()
+}
+
+// insertReturningSQL adds the RETURNING clause to insertSQL; only valid if supportsReturning(d) is true.
+func insertReturningSQL(d Dialect, insertSQL string, returningCols []string) string {
+	return d.(ReturningInserter).InsertReturningSQL(insertSQL, returningCols)
+}
+
+// supportsDeleteReturning reports whether DELETE statements can read the deleted rows back with RETURNING.
+func supportsDeleteReturning(d Dialect) bool {
+	r, ok := d.(ReturningDeleter)
+	return ok && r.SupportsDeleteReturning()
+}
+
+// deleteReturningSQL adds the RETURNING clause to deleteSQL; only valid if supportsDeleteReturning(d) is true.
+func deleteReturningSQL(d Dialect, deleteSQL string, returningCols []string) string {
+	return d.(ReturningDeleter).DeleteReturningSQL(deleteSQL, returningCols)
+}
+
+// firstInsertID reports whether LastInsertId is the key of the first row of a multi-row INSERT for d; see
+// FirstInsertIDReporter.
+func firstInsertID(d Dialect) bool {
+	r, ok := d.(FirstInsertIDReporter)
+	return ok && r.FirstInsertID()
+}
+
+// supportsWindowCount reports whether Paginate can count with COUNT(*) OVER(); see WindowCounter.
+func supportsWindowCount(d Dialect) bool {
+	w, ok := d.(WindowCounter)
+	return ok && w.SupportsWindowCount()
+}
+
+// unsupported returns the error of a feature that d does not implement.
+func unsupported(d Dialect, feature string) error {
+	return fmt.Errorf("%s is not supported by %T: %w", feature, d, errors.ErrUnsupported)
+}
+
+// bulkInsertSQL returns the multi-row INSERT statement of d; see BulkInserter.
+func bulkInsertSQL(d Dialect, tableName string, cols []string, rows [][]string) string {
+	if b, ok := d.(BulkInserter); ok {
+		return b.BulkInsertSQL(tableName, cols, rows)
+	}
+	return DefaultBulkInsertSQL(tableName, cols, rows)
+}
+
+// collateSQL returns the COLLATE clause of d; see Collator.
+func collateSQL(d Dialect, collation string) string {
+	if c, ok := d.(Collator); ok {
+		return c.Collate(collation)
+	}
+	return "COLLATE " + collation
+}
+
+// supportsJoinType reports whether d supports the join kind; see JoinTypeChecker.
+func supportsJoinType(d Dialect, kind string) bool {
+	if c, ok := d.(JoinTypeChecker); ok {
+		return c.SupportsJoinType(kind)
+	}
+	return true
+}
+
+// partitionTableSQL returns the FROM target of d for the partition; see Partitioner.
+func partitionTableSQL(d Dialect, tableName, partition string) (string, error) {
+	if p, ok := d.(Partitioner); ok {
+		return p.PartitionTable(tableName, partition)
+	}
+	return "", unsupported(d, "partition selection")
+}
+
+// notifySQL returns the notification statement of d; see Notifier.
+func notifySQL(d Dialect) (string, error) {
+	if n, ok := d.(Notifier); ok {
+		return n.NotifySQL()
+	}
+	return "", unsupported(d, "change notifications")
+}
+
+// lockTimeoutSQL returns the lock timeout statement of d; see LockTimeoutSetter.
+func lockTimeoutSQL(d Dialect, timeout time.Duration) (string, error) {
+	if s, ok := d.(LockTimeoutSetter); ok {
+		return s.LockTimeoutSQL(timeout)
+	}
+	return "", unsupported(d, "WithLockTimeout")
+}
+
+// limitWithTiesSQL returns the WITH TIES clause of d; see TiesLimiter.
+func limitWithTiesSQL(d Dialect, n int) (string, error) {
+	if l, ok := d.(TiesLimiter); ok {
+		return l.LimitWithTiesSQL(n)
+	}
+	return "", unsupported(d, "LimitWithTies")
+}
+
+// sessionSettingSQL returns the statement of d that changes a setting; see SessionSetter.
+func sessionSettingSQL(d Dialect, setting, value string) (string, error) {
+	if s, ok := d.(SessionSetter); ok {
+		return s.SessionSettingSQL(setting, value)
+	}
+	return "", unsupported(d, "session settings")
+}
+
+// jsonContainsSQL returns the JSON containment condition of d; see JSONMatcher.
+func jsonContainsSQL(d Dialect, column, placeholder string) (string, error) {
+	if m, ok := d.(JSONMatcher); ok {
+		return m.JSONContainsSQL(column, placeholder)
+	}
+	return "", unsupported(d, "JSON containment")
+}
+
+// columnType returns the column type of d for goType; see ColumnTyper.
+func columnType(d Dialect, goType reflect.Type) (string, error) {
+	if t, ok := d.(ColumnTyper); ok {
+		return t.ColumnType(goType), nil
+	}
+	return "", unsupported(d, "AutoMigrate")
+}
+
+// upsertSQL returns the upsert of d that overwrites only updateCols; see ColumnUpserter.
+func upsertSQL(d Dialect, tableName, pkColumn string, cols, updateCols []string) (string, error) {
+	if u, ok := d.(ColumnUpserter); ok {
+		return u.UpsertColumnsSQL(tableName, pkColumn, cols, updateCols), nil
+	}
+	if len(updateCols) != len(cols)-1 {
+		return "", unsupported(d, "an upsert that leaves insertonly columns untouched")
+	}
+	return d.UpsertSQL(tableName, pkColumn, cols), nil
+}
@@ -0,0 +1,234 @@
+// Command crudgen generates the reflection-free GeneratedModel methods used by crud.Repository
+// for a struct type with `db` tags. It is meant to be run with go:generate from the model's package:
+//
+//	//go:generate go run github.com/dimatock/crud/cmd/crudgen -type User
+//
+// The generated file declares CrudColumns, CrudScanTargets and CrudValues on *User. Re-run the generator
+// whenever the struct's db tags change; NewRepository rejects generated code that is out of date.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// column is one mapped column with the Go selector of its field relative to the receiver.
+type column struct {
+	name     string
+	selector string // e.g. "Address.City"
+}
+
+func main() {
+	typeName := flag.String("type", "", "name of the struct type to generate methods for (required)")
+	output := flag.String("output", "", "output file name; default <type>_crud.go in the package directory")
+	dir := flag.String("dir", ".", "directory of the package containing the type")
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("crudgen: ")
+	if *typeName == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *output == "" {
+		*output = strings.ToLower(*typeName) + "_crud.go"
+	}
+
+	src, err := generate(*dir, *typeName, filepath.Base(*output))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(*dir, *output), src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate parses the package in dir, skipping the output file, and returns the formatted source of the
+// methods for typeName.
+func generate(dir, typeName, outputName string) ([]byte, error) {
+	pkgName, structs, err := parseStructs(dir, outputName)
+	if err != nil {
+		return nil, err
+	}
+
+	st, ok := structs[typeName]
+	if !ok {
+		return nil, fmt.Errorf("struct type %s not found in %s", typeName, dir)
+	}
+	cols, err := collectColumns(structs, st, "", "")
+	if err != nil {
+		return nil, err
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("no 'db' tags found in struct %s", typeName)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by crudgen; DO NOT EDIT.\n\npackage %s\n\n", pkgName)
+
+	fmt.Fprintf(&buf, "// CrudColumns returns the columns mapped by %s.\n", typeName)
+	fmt.Fprintf(&buf, "func (*%s) CrudColumns() []string {\n\treturn []string{", typeName)
+	for i, c := range cols {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(strconv.Quote(c.name))
+	}
+	buf.WriteString("}\n}\n\n")
+
+	fmt.Fprintf(&buf, "// CrudScanTargets returns pointers to the mapped fields of m, in CrudColumns order.\n")
+	fmt.Fprintf(&buf, "func (m *%s) CrudScanTargets() []any {\n\treturn []any{", typeName)
+	for i, c := range cols {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString("&m." + c.selector)
+	}
+	buf.WriteString("}\n}\n\n")
+
+	fmt.Fprintf(&buf, "// CrudValues returns the values of the mapped fields of m, in CrudColumns order.\n")
+	fmt.Fprintf(&buf, "func (m *%s) CrudValues() []any {\n\treturn []any{", typeName)
+	for i, c := range cols {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString("m." + c.selector)
+	}
+	buf.WriteString("}\n}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+// parseStructs parses the Go files in dir except skipName and returns the package name along with the
+// struct types declared in it, by name.
+func parseStructs(dir, skipName string) (string, map[string]*ast.StructType, error) {
+	fset := token.NewFileSet()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	structs := make(map[string]*ast.StructType)
+	pkgName := ""
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || name == skipName {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return "", nil, err
+		}
+		pkgName = file.Name.Name
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if st, ok := ts.Type.(*ast.StructType); ok {
+					structs[ts.Name.Name] = st
+				}
+			}
+		}
+	}
+	return pkgName, structs, nil
+}
+
+// collectColumns mirrors the tag parsing of crud.NewRepository: fields without a db tag (or tagged "-")
+// are skipped, struct fields with a prefix modifier are flattened, and so are untagged embedded structs
+// declared in the package; embedding one of them by pointer is an error. Embedded structs declared in
+// other packages cannot be inspected and must not carry db tags.
+func collectColumns(structs map[string]*ast.StructType, st *ast.StructType, prefix, selector string) ([]column, error) {
+	var cols []column
+	for _, field := range st.Fields.List {
+		tag := ""
+		if field.Tag != nil {
+			rawTag, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				return nil, err
+			}
+			tag = reflect.StructTag(rawTag).Get("db")
+		}
+		names := make([]string, len(field.Names))
+		for i, name := range field.Names {
+			names[i] = name.Name
+		}
+		if len(field.Names) == 0 {
+			embedded, local, isPointer := embeddedName(field.Type)
+			if tag == "" {
+				if !local || structs[embedded] == nil {
+					continue
+				}
+				nested, err := collectColumns(structs, structs[embedded], prefix, selector+embedded+".")
+				if err != nil {
+					return nil, err
+				}
+				if isPointer && len(nested) > 0 {
+					return nil, fmt.Errorf("embedded field %s must not be a pointer; embed %s by value", embedded, embedded)
+				}
+				if !isPointer {
+					cols = append(cols, nested...)
+				}
+				continue
+			}
+			names = []string{embedded}
+		}
+		if tag == "" || tag == "-" {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+
+		nestedPrefix, isNested := "", false
+		for _, part := range parts[1:] {
+			if strings.HasPrefix(part, "prefix:") {
+				nestedPrefix, isNested = strings.TrimPrefix(part, "prefix:"), true
+			}
+		}
+
+		for _, name := range names {
+			fieldSelector := selector + name
+			if !isNested {
+				cols = append(cols, column{name: prefix + parts[0], selector: fieldSelector})
+				continue
+			}
+			ident, ok := field.Type.(*ast.Ident)
+			if !ok || structs[ident.Name] == nil {
+				return nil, fmt.Errorf("field %s uses the prefix modifier but is not a struct type declared in the package", name)
+			}
+			nested, err := collectColumns(structs, structs[ident.Name], prefix+nestedPrefix, fieldSelector+".")
+			if err != nil {
+				return nil, err
+			}
+			cols = append(cols, nested...)
+		}
+	}
+	return cols, nil
+}
+
+// embeddedName returns the field name of an embedded type expression (T, *T, pkg.T or *pkg.T), whether the
+// type is declared in the package and whether it is embedded by pointer.
+func embeddedName(expr ast.Expr) (name string, local, isPointer bool) {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr, isPointer = star.X, true
+	}
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name, true, isPointer
+	case *ast.SelectorExpr:
+		return t.Sel.Name, false, isPointer
+	}
+	return "", false, isPointer
+}
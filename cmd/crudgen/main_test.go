@@ -0,0 +1,96 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dimatock/crud"
+	"github.com/dimatock/crud/cmd/crudgen/testdata/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func TestGenerateGolden(t *testing.T) {
+	src, err := generate(filepath.Join("testdata", "fixture"), "Account", "account_crud.go")
+	require.NoError(t, err)
+
+	golden := filepath.Join("testdata", "account_crud.golden")
+	if *update {
+		require.NoError(t, os.WriteFile(golden, src, 0o644))
+	}
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(src))
+}
+
+func TestGenerateErrors(t *testing.T) {
+	dir := filepath.Join("testdata", "fixture")
+
+	_, err := generate(dir, "Missing", "missing_crud.go")
+	assert.ErrorContains(t, err, "struct type Missing not found")
+
+	_, err = generate(dir, "Unmapped", "unmapped_crud.go")
+	assert.ErrorContains(t, err, "no 'db' tags found in struct Unmapped")
+
+	_, err = generate(dir, "BadPrefix", "badprefix_crud.go")
+	assert.ErrorContains(t, err, "field City uses the prefix modifier but is not a struct type")
+
+	// The output file is not parsed, so stale generated code cannot get in the way
+	_, err = generate(dir, "Account", "model.go")
+	assert.ErrorContains(t, err, "struct type Account not found")
+}
+
+// reflectedColumns returns the columns crud.NewRepository maps for T, read from the select list of ToSQL.
+func reflectedColumns[T any]() ([]string, error) {
+	repo, err := crud.NewRepository[T](nil, "t", crud.SQLiteDialect{})
+	if err != nil {
+		return nil, err
+	}
+	query, _, err := repo.ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	list := strings.TrimSuffix(strings.TrimPrefix(query, "SELECT "), " FROM t")
+	cols := strings.Split(list, ", ")
+	for i, col := range cols {
+		cols[i] = strings.TrimPrefix(col, "t.")
+	}
+	return cols, nil
+}
+
+// TestColumnsMatchRepository checks that the generator maps every fixture model like crud.NewRepository.
+func TestColumnsMatchRepository(t *testing.T) {
+	dir := filepath.Join("testdata", "fixture")
+	for name, reflected := range map[string]func() ([]string, error){
+		"Account":     reflectedColumns[fixture.Account],
+		"Ledger":      reflectedColumns[fixture.Ledger],
+		"Event":       reflectedColumns[fixture.Event],
+		"PointerBase": reflectedColumns[fixture.PointerBase],
+		"BadPrefix":   reflectedColumns[fixture.BadPrefix],
+	} {
+		t.Run(name, func(t *testing.T) {
+			want, wantErr := reflected()
+			_, err := generate(dir, name, strings.ToLower(name)+"_crud.go")
+			if wantErr != nil {
+				assert.Error(t, err, "NewRepository fails with: %v", wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			_, structs, err := parseStructs(dir, "")
+			require.NoError(t, err)
+			cols, err := collectColumns(structs, structs[name], "", "")
+			require.NoError(t, err)
+			got := make([]string, len(cols))
+			for i, c := range cols {
+				got[i] = c.name
+			}
+			assert.Equal(t, want, got)
+		})
+	}
+}
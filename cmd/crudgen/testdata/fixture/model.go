@@ -0,0 +1,54 @@
+package fixture
+
+import "time"
+
+// Base is embedded without a tag, so its columns are mapped as if declared in the embedding struct.
+type Base struct {
+	ID        int       `db:"id,pk"`
+	CreatedAt time.Time `db:"created_at,readonly"`
+}
+
+// Address is flattened into prefixed columns.
+type Address struct {
+	City string `db:"city"`
+	Zip  string `db:"zip"`
+}
+
+type Account struct {
+	Base
+	Name     string  `db:"name"`
+	Email    *string `db:"email"`
+	Home     Address `db:"home,prefix:home_"`
+	Internal string  `db:"-"`
+	Notes    string
+}
+
+type Unmapped struct {
+	Name string
+}
+
+type BadPrefix struct {
+	ID   int    `db:"id,pk"`
+	City string `db:"city,prefix:city_"`
+}
+
+// Ledger flattens a tagged embedded struct and uses the column modifiers that do not change the mapping.
+type Ledger struct {
+	Base   `db:"base,prefix:base_"`
+	Status string `db:"status,default:open"`
+	Total  int    `db:"total,insertonly"`
+	Ref    string `db:"ref,pgtype:uuid"`
+}
+
+// Event has a generated key and embeds a struct from another package without db tags.
+type Event struct {
+	time.Time
+	ID   int64  `db:"id,pk,auto"`
+	Kind string `db:"kind"`
+}
+
+// PointerBase embeds a mapped struct by pointer, which is rejected.
+type PointerBase struct {
+	*Base
+	Name string `db:"name"`
+}
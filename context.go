@@ -0,0 +1,22 @@
+package crud
+
+import "context"
+
+// queryLabelKey is the context key for the logical operation name attached via WithQueryLabel.
+type queryLabelKey struct{}
+
+// WithQueryLabel returns a copy of ctx carrying a logical operation name (e.g., "GetUserDashboard").
+// The label is not sent to the database; it is read by the repository's observability hooks so that
+// application-side logs and metrics can be grouped by operation.
+func WithQueryLabel(ctx context.Context, label string) context.Context {
+	return context.WithValue(ctx, queryLabelKey{}, label)
+}
+
+// QueryLabel returns the label attached to ctx by WithQueryLabel, or an empty string if there is none.
+func QueryLabel(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	label, _ := ctx.Value(queryLabelKey{}).(string)
+	return label
+}
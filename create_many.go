@@ -0,0 +1,201 @@
+package crud
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// CreateMany inserts all items with multi-row INSERT statements and returns them with their generated
+// primary keys populated, in the same order. Large batches are split into chunks that stay under the
+// dialect's MaxParameters; when more than one chunk is needed, they are inserted in a single transaction
+// (the repository's own if it was created with WithTx), so the batch is all-or-nothing.
+//
+// If the dialect supports RETURNING (see ReturningInserter) the records are read back with it. The order
+// of the returned rows is not guaranteed (SQLite's is arbitrary), so they are matched to the items by their
+// supplied key, or sorted by an integer key, which the database assigns in row order; rows with another kind
+// of generated key are returned in the order the database produced them. Otherwise the generated IDs are derived from LastInsertId, assuming each statement receives consecutive
+// IDs (the default for a single multi-row INSERT with InnoDB's auto-increment lock modes and SQLite's
+// rowids); other database defaults are not reflected in the returned items.
+//
+// BeforeCreateHook runs for every item before anything is inserted, and AfterCreateHook for every created record.
+func (r *Repository[T]) CreateMany(ctx context.Context, items []T) ([]T, error) {
+	if len(items) == 0 {
+		return []T{}, nil
+	}
+
+	items = append([]T(nil), items...)
+	for i := range items {
+		if err := callHook(&items[i], "BeforeCreate", func(h BeforeCreateHook) error { return h.BeforeCreate(ctx) }); err != nil {
+			return nil, err
+		}
+		r.applyDefaults(&items[i])
+		r.stampTimes(&items[i], true)
+	}
+
+	cols := r.insertColumns()
+	chunkSize := rowsPerStatement(r.dialect, len(cols))
+
+	var created []T
+	var err error
+	if len(items) <= chunkSize || r.tx != nil {
+		e, execErr := r.getExecutor(ctx)
+		if execErr != nil {
+			return nil, execErr
+		}
+		created, err = r.createChunks(ctx, e, items, cols, chunkSize)
+	} else {
+		tx, txErr := r.beginTx(ctx)
+		if txErr != nil {
+			return nil, txErr
+		}
+		created, err = r.createChunks(ctx, r.instrument(tx), items, cols, chunkSize)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.notifyChange(ctx, "insert", r.pkValues(created)...); err != nil {
+		return created, err
+	}
+	for i := range created {
+		if err := callHook(&created[i], "AfterCreate", func(h AfterCreateHook) error { return h.AfterCreate(ctx) }); err != nil {
+			return created, err
+		}
+	}
+	return created, nil
+}
+
+// rowsPerStatement returns how many rows of numColumns bind parameters fit in one statement of the dialect.
+func rowsPerStatement(d Dialect, numColumns int) int {
+	return max(1, maxParameters(d)/max(1, numColumns))
+}
+
+// createChunks inserts items in chunks of at most chunkSize rows using the given executor.
+func (r *Repository[T]) createChunks(ctx context.Context, e executor, items []T, cols []string, chunkSize int) ([]T, error) {
+	created := make([]T, 0, len(items))
+	for start := 0; start < len(items); start += chunkSize {
+		chunk, err := r.createChunk(ctx, e, items[start:min(start+chunkSize, len(items))], cols)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, chunk...)
+	}
+	return created, nil
+}
+
+// createChunk inserts the items with a single multi-row INSERT statement.
+func (r *Repository[T]) createChunk(ctx context.Context, e executor, items []T, cols []string) ([]T, error) {
+	rows := make([][]string, len(items))
+	args := make([]any, 0, len(items)*len(cols))
+	for i, item := range items {
+		vals, err := r.insertValues(item)
+		if err != nil {
+			return nil, err
+		}
+		rows[i] = make([]string, len(vals))
+		for j := range vals {
+			rows[i][j] = r.dialect.Placeholder(len(args) + j + 1)
+		}
+		args = append(args, vals...)
+	}
+	sqlQuery := bulkInsertSQL(r.dialect, r.quote(r.tableName), quoteIdents(r.dialect, cols), rows)
+
+	if supportsReturning(r.dialect) {
+		sqlQuery = insertReturningSQL(r.dialect, sqlQuery, quoteIdents(r.dialect, r.columns))
+		qctx, cancel := r.queryContext(ctx)
+		defer cancel()
+		result, err := e.QueryContext(qctx, sqlQuery, args...)
+		if err != nil {
+			return nil, fmt.Errorf("bulk insert failed: %w", duplicateError(r.dialect, err))
+		}
+		created, err := r.scanRows(result)
+		if err != nil {
+			return nil, duplicateError(r.dialect, err)
+		}
+		return r.inInsertOrder(items, created), nil
+	}
+
+	created := append([]T(nil), items...)
+	var lastID int64
+	if idSQL := lastInsertIDSQL(r.dialect); idSQL != "" && r.pkIsAutoIncrement {
+		// The driver does not implement LastInsertId; the generated ID is selected in the same batch.
+		qctx, cancel := r.queryContext(ctx)
+		defer cancel()
+		if err := e.QueryRowContext(qctx, sqlQuery+idSQL, args...).Scan(&lastID); err != nil {
+			return nil, fmt.Errorf("bulk insert failed: %w", duplicateError(r.dialect, err))
+		}
+	} else {
+		res, err := e.ExecContext(ctx, sqlQuery, args...)
+		if err != nil {
+			return nil, fmt.Errorf("bulk insert failed: %w", duplicateError(r.dialect, err))
+		}
+		if !r.pkIsAutoIncrement {
+			return created, nil
+		}
+		if lastID, err = res.LastInsertId(); err != nil {
+			return nil, fmt.Errorf("bulk insert successful, but failed to retrieve last insert ID: %w", err)
+		}
+	}
+	// MySQL reports the ID of the first inserted row, SQLite and SQL Server the ID of the last one.
+	firstID := lastID
+	if !firstInsertID(r.dialect) {
+		firstID = lastID - int64(len(items)) + 1
+	}
+
+	pk := r.fields[r.pkFieldPos()]
+	for i := range created {
+		field := reflect.ValueOf(&created[i]).Elem().FieldByIndex(pk.index)
+		id := firstID + int64(i)
+		if field.CanInt() {
+			field.SetInt(id)
+		} else {
+			field.SetUint(uint64(id))
+		}
+	}
+	return created, nil
+}
+
+// inInsertOrder reorders the rows read back with RETURNING to match the inserted items: by the supplied
+// primary key, or by ascending integer key. Other generated keys cannot be matched, and created is
+// returned as it is.
+func (r *Repository[T]) inInsertOrder(items, created []T) []T {
+	pk := r.fields[r.pkFieldPos()]
+	key := func(item *T) reflect.Value { return reflect.ValueOf(item).Elem().FieldByIndex(pk.index) }
+	switch {
+	case isIntegerKind(pk.fieldType.Kind()):
+		slices.SortStableFunc(created, func(a, b T) int {
+			ka, kb := key(&a), key(&b)
+			if ka.CanInt() {
+				return cmp.Compare(ka.Int(), kb.Int())
+			}
+			return cmp.Compare(ka.Uint(), kb.Uint())
+		})
+		return created
+	case r.pkIsAutoIncrement || !pk.fieldType.Comparable() || len(created) != len(items):
+		return created
+	}
+
+	positions := make(map[any]int, len(items))
+	for i := range items {
+		positions[key(&items[i]).Interface()] = i
+	}
+	ordered := make([]T, len(created))
+	for i := range created {
+		pos, ok := positions[key(&created[i]).Interface()]
+		if !ok {
+			return created
+		}
+		ordered[pos] = created[i]
+	}
+	return ordered
+}
@@ -0,0 +1,76 @@
+package crud
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// parseDefault converts the text of a default tag modifier to a value of type t. Pointers to the
+// supported kinds get a pointer to the parsed value.
+func parseDefault(t reflect.Type, text string) (reflect.Value, error) {
+	if t.Kind() == reflect.Pointer {
+		elem, err := parseDefault(t.Elem(), text)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, nil
+	}
+
+	v := reflect.New(t).Elem()
+	var err error
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(text)
+	case reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(text); err == nil {
+			v.SetBool(b)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if n, err = strconv.ParseInt(text, 10, t.Bits()); err == nil {
+			v.SetInt(n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		if n, err = strconv.ParseUint(text, 10, t.Bits()); err == nil {
+			v.SetUint(n)
+		}
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if f, err = strconv.ParseFloat(text, t.Bits()); err == nil {
+			v.SetFloat(f)
+		}
+	default:
+		return reflect.Value{}, fmt.Errorf("default values are not supported for type %s", t)
+	}
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("invalid default '%s' for type %s: %w", text, t, err)
+	}
+	return v, nil
+}
+
+// applyDefaults replaces the zero-valued fields of item that have a default tag modifier with their default.
+func (r *Repository[T]) applyDefaults(item *T) {
+	val := reflect.ValueOf(item).Elem()
+	for _, f := range r.fields {
+		if !f.defaultValue.IsValid() {
+			continue
+		}
+		field := val.FieldByIndex(f.index)
+		if !field.IsZero() {
+			continue
+		}
+		if f.defaultValue.Kind() == reflect.Pointer {
+			// Every item gets its own pointer so that changing one default does not change the others.
+			ptr := reflect.New(f.defaultValue.Type().Elem())
+			ptr.Elem().Set(f.defaultValue.Elem())
+			field.Set(ptr)
+			continue
+		}
+		field.Set(f.defaultValue)
+	}
+}
@@ -1,11 +1,18 @@
 package crud
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"reflect"
+	"regexp"
 	"strings"
+	"time"
 )
 
-// Dialect defines the interface for database-specific SQL generation.
+// Dialect defines the interface for database-specific SQL generation. Further capabilities are picked up
+// from optional interfaces such as ParameterLimiter or RowLocker, with portable defaults for dialects that
+// do not implement them.
 type Dialect interface {
 	Placeholder(idx int) string
 	InsertSQL(tableName string, cols, placeholders []string) string
@@ -15,8 +22,102 @@ type Dialect interface {
 	UpsertSQL(tableName string, pkColumn string, cols []string) string
 }
 
+// nonKeyColumns returns cols without pkColumn, the columns a plain UpsertSQL overwrites.
+func nonKeyColumns(cols []string, pkColumn string) []string {
+	updateCols := make([]string, 0, len(cols))
+	for _, col := range cols {
+		if col != pkColumn {
+			updateCols = append(updateCols, col)
+		}
+	}
+	return updateCols
+}
+
+// identifierRe matches a plain, possibly qualified identifier such as order or users.order.
+var identifierRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
+// quoteIdent quotes the segments of a possibly qualified identifier that are reserved words of the dialect
+// (see ReservedWordChecker) with its QuoteIdentifier (order -> "order", users.order -> users."order").
+// Other segments are left as they are, since quoting would make PostgreSQL compare them case-sensitively,
+// and anything that is not a plain identifier (an expression or an already quoted name) is returned
+// unchanged.
+func quoteIdent(d Dialect, name string) string {
+	if !identifierRe.MatchString(name) {
+		return name
+	}
+	segments := strings.Split(name, ".")
+	quoted := false
+	for i, segment := range segments {
+		if isReservedWord(d, segment) {
+			segments[i] = quoteIdentifier(d, segment)
+			quoted = true
+		}
+	}
+	if !quoted {
+		return name
+	}
+	return strings.Join(segments, ".")
+}
+
+// quoteIdents applies quoteIdent to each of names.
+func quoteIdents(d Dialect, names []string) []string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = quoteIdent(d, name)
+	}
+	return quoted
+}
+
+// quoteSegments quotes each dot-separated segment of name with open and close, doubling any close
+// character inside a segment.
+func quoteSegments(name, open, close string) string {
+	segments := strings.Split(name, ".")
+	for i, segment := range segments {
+		segments[i] = open + strings.ReplaceAll(segment, close, close+close) + close
+	}
+	return strings.Join(segments, ".")
+}
+
 // DefaultSelectSQL provides a default implementation for building a SELECT query.
+// An offset without a limit is emitted as a bare OFFSET, which PostgreSQL accepts.
 func DefaultSelectSQL(tableName string, cols []string, joins, whereClause, orderByClause, lockClause string, limit, offset int) string {
+	return selectSQL(tableName, cols, joins, whereClause, orderByClause, lockClause, limit, offset, "")
+}
+
+// Sentinels for "no limit", for dialects that only accept OFFSET after a LIMIT.
+const (
+	mysqlNoLimit  = "18446744073709551615" // The largest BIGINT UNSIGNED, as recommended by the MySQL manual
+	sqliteNoLimit = "-1"                   // A negative LIMIT means no upper bound
+)
+
+// limitOffsetSQL returns the LIMIT and OFFSET clauses, with a leading space. When only an offset is set
+// and noLimit is non-empty, LIMIT noLimit is emitted in front of it.
+func limitOffsetSQL(limit, offset int, noLimit string) string {
+	var sql string
+	switch {
+	case limit > 0:
+		sql = fmt.Sprintf(" LIMIT %d", limit)
+	case offset > 0 && noLimit != "":
+		sql = " LIMIT " + noLimit
+	}
+	if offset > 0 {
+		sql += fmt.Sprintf(" OFFSET %d", offset)
+	}
+	return sql
+}
+
+// orderLimitTail returns the ORDER BY, LIMIT and OFFSET clauses, with a leading space, using noLimit as in
+// limitOffsetSQL.
+func orderLimitTail(orderByClause string, limit, offset int, noLimit string) string {
+	var sql string
+	if orderByClause != "" {
+		sql = " ORDER BY " + orderByClause
+	}
+	return sql + limitOffsetSQL(limit, offset, noLimit)
+}
+
+// selectSQL builds a SELECT query like DefaultSelectSQL, using noLimit as in limitOffsetSQL.
+func selectSQL(tableName string, cols []string, joins, whereClause, orderByClause, lockClause string, limit, offset int, noLimit string) string {
 	sql := fmt.Sprintf("SELECT %s FROM %s", strings.Join(cols, ", "), tableName)
 	if joins != "" {
 		sql += " " + joins
@@ -24,23 +125,37 @@ func DefaultSelectSQL(tableName string, cols []string, joins, whereClause, order
 	if whereClause != "" {
 		sql += " WHERE " + whereClause
 	}
-	if orderByClause != "" {
-		sql += " ORDER BY " + orderByClause
-	}
-	if limit > 0 {
-		sql += fmt.Sprintf(" LIMIT %d", limit)
-	}
-	if offset > 0 {
-		sql += fmt.Sprintf(" OFFSET %d", offset)
-	}
+	sql += orderLimitTail(orderByClause, limit, offset, noLimit)
 	if lockClause != "" {
 		sql += " " + lockClause
 	}
 	return sql
 }
 
-// MySQLDialect implements Dialect for MySQL.
-type MySQLDialect struct{}
+// defaultInsertReturningSQL appends a RETURNING clause to a single- or multi-row INSERT statement, or to
+// a DELETE statement.
+func defaultInsertReturningSQL(insertSQL string, returningCols []string) string {
+	return insertSQL + " RETURNING " + strings.Join(returningCols, ", ")
+}
+
+// DefaultBulkInsertSQL provides a default implementation for building a multi-row INSERT statement,
+// with one placeholder list per row: INSERT INTO t (a, b) VALUES (?, ?), (?, ?).
+func DefaultBulkInsertSQL(tableName string, cols []string, rows [][]string) string {
+	values := make([]string, len(rows))
+	for i, placeholders := range rows {
+		values[i] = "(" + strings.Join(placeholders, ", ") + ")"
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", tableName, strings.Join(cols, ", "), strings.Join(values, ", "))
+}
+
+// MySQLDialect implements Dialect for MySQL and MariaDB.
+//
+// MySQL has no INSERT ... RETURNING, so inserted rows are read back with LastInsertId and a SELECT by the
+// primary key. MariaDB 10.5 and newer support RETURNING; set Returning to use it.
+type MySQLDialect struct {
+	// Returning enables INSERT ... RETURNING and DELETE ... RETURNING (MariaDB 10.5+ only).
+	Returning bool
+}
 
 func (d MySQLDialect) Placeholder(idx int) string {
 	return "?"
@@ -50,26 +165,46 @@ func (d MySQLDialect) InsertSQL(tableName string, cols, placeholders []string) s
 	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", tableName, strings.Join(cols, ", "), strings.Join(placeholders, ", "))
 }
 
+func (d MySQLDialect) BulkInsertSQL(tableName string, cols []string, rows [][]string) string {
+	return DefaultBulkInsertSQL(tableName, cols, rows)
+}
+
 func (d MySQLDialect) UpdateSQL(tableName string, setClauses string, pkColumn string, pkPlaceholder string) string {
 	return fmt.Sprintf("UPDATE %s SET %s WHERE %s = %s", tableName, setClauses, pkColumn, pkPlaceholder)
 }
 
 func (d MySQLDialect) SelectSQL(tableName string, cols []string, joins, whereClause, orderByClause, lockClause string, limit, offset int) string {
-	return DefaultSelectSQL(tableName, cols, joins, whereClause, orderByClause, lockClause, limit, offset)
+	return selectSQL(tableName, cols, joins, whereClause, orderByClause, lockClause, limit, offset, mysqlNoLimit)
+}
+
+// OrderLimitSQL returns the ORDER BY, LIMIT and OFFSET clauses. MySQL needs a LIMIT in front of OFFSET, so
+// an offset without a limit gets the largest possible one.
+func (d MySQLDialect) OrderLimitSQL(orderByClause string, limit, offset int) string {
+	return orderLimitTail(orderByClause, limit, offset, mysqlNoLimit)
 }
 
 func (d MySQLDialect) DeleteSQL(tableName string, pkColumn string, pkPlaceholder string) string {
 	return fmt.Sprintf("DELETE FROM %s WHERE %s = %s", tableName, pkColumn, pkPlaceholder)
 }
 
+// UpsertSQL generates the INSERT ... ON DUPLICATE KEY UPDATE statement for MySQL, overwriting every column but the primary key.
 func (d MySQLDialect) UpsertSQL(tableName string, pkColumn string, cols []string) string {
+	return d.UpsertColumnsSQL(tableName, pkColumn, cols, nonKeyColumns(cols, pkColumn))
+}
+
+// UpsertColumnsSQL generates the INSERT ... ON DUPLICATE KEY UPDATE statement for MySQL, overwriting only updateCols.
+// With no updateCols an existing row is left untouched.
+func (d MySQLDialect) UpsertColumnsSQL(tableName string, pkColumn string, cols, updateCols []string) string {
 	placeholders := make([]string, len(cols))
-	updateClauses := make([]string, 0, len(cols))
-	for i, col := range cols {
+	for i := range cols {
 		placeholders[i] = "?"
-		if col != pkColumn {
-			updateClauses = append(updateClauses, fmt.Sprintf("%s = VALUES(%s)", col, col))
-		}
+	}
+	updateClauses := make([]string, 0, len(updateCols))
+	for _, col := range updateCols {
+		updateClauses = append(updateClauses, fmt.Sprintf("%s = VALUES(%s)", col, col))
+	}
+	if len(updateClauses) == 0 {
+		updateClauses = append(updateClauses, fmt.Sprintf("%s = %s", pkColumn, pkColumn))
 	}
 
 	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON DUPLICATE KEY UPDATE %s",
@@ -80,8 +215,212 @@ func (d MySQLDialect) UpsertSQL(tableName string, pkColumn string, cols []string
 	)
 }
 
+// Collate returns the COLLATE clause for MySQL (e.g., COLLATE utf8mb4_unicode_ci).
+func (d MySQLDialect) Collate(collation string) string {
+	return "COLLATE " + collation
+}
+
+// SupportsJoinType reports whether MySQL supports the join kind. MySQL has no FULL OUTER JOIN.
+func (d MySQLDialect) SupportsJoinType(kind string) bool {
+	switch strings.ToUpper(kind) {
+	case JoinInner, JoinLeft, JoinRight, JoinCross:
+		return true
+	}
+	return false
+}
+
+// PartitionTable returns the FROM target that selects a single partition on MySQL (e.g., users PARTITION (p2024)).
+func (d MySQLDialect) PartitionTable(tableName, partition string) (string, error) {
+	return fmt.Sprintf("%s PARTITION (%s)", tableName, partition), nil
+}
+
+// NotifySQL is not supported by MySQL, which has no LISTEN/NOTIFY mechanism.
+func (d MySQLDialect) NotifySQL() (string, error) {
+	return "", fmt.Errorf("change notifications are not supported by MySQL: %w", errors.ErrUnsupported)
+}
+
+// LockTimeoutSQL returns the statement that bounds InnoDB row lock waits for the session.
+// MySQL only accepts whole seconds, so the timeout is rounded up.
+func (d MySQLDialect) LockTimeoutSQL(timeout time.Duration) (string, error) {
+	seconds := int64(math.Ceil(timeout.Seconds()))
+	return fmt.Sprintf("SET SESSION innodb_lock_wait_timeout = %d", max(seconds, 1)), nil
+}
+
+// ResetLockTimeoutSQL restores the server's default lock wait timeout, so that the session value set by
+// LockTimeoutSQL does not stay on the pooled connection.
+func (d MySQLDialect) ResetLockTimeoutSQL() string {
+	return "SET SESSION innodb_lock_wait_timeout = DEFAULT"
+}
+
+// LimitWithTiesSQL returns an error: MySQL has no FETCH ... WITH TIES.
+func (d MySQLDialect) LimitWithTiesSQL(n int) (string, error) {
+	return "", fmt.Errorf("LimitWithTies is not supported by MySQL: %w", errors.ErrUnsupported)
+}
+
+// SessionSettingSQL returns an error: WithSessionSetting only supports PostgreSQL settings.
+func (d MySQLDialect) SessionSettingSQL(setting, value string) (string, error) {
+	return "", fmt.Errorf("session settings are not supported by MySQL: %w", errors.ErrUnsupported)
+}
+
+// JSONContainsSQL returns a JSON_CONTAINS condition; the placeholder is bound to the fragment's JSON text.
+func (d MySQLDialect) JSONContainsSQL(column, placeholder string) (string, error) {
+	return fmt.Sprintf("JSON_CONTAINS(%s, %s)", column, placeholder), nil
+}
+
+// MaxParameters returns the number of bind parameters a single MySQL prepared statement accepts.
+func (d MySQLDialect) MaxParameters() int {
+	return 65535
+}
+
+// RowLockSQL returns the locking clause for the mode (FOR SHARE requires MySQL 8.0, like SKIP LOCKED).
+func (d MySQLDialect) RowLockSQL(mode LockMode, skipLocked bool) (string, error) {
+	return forLockSQL(mode, skipLocked)
+}
+
+// forLockSQL builds the standard FOR UPDATE / FOR SHARE [SKIP LOCKED] clause.
+func forLockSQL(mode LockMode, skipLocked bool) (string, error) {
+	var clause string
+	switch mode {
+	case LockModeUpdate:
+		clause = "FOR UPDATE"
+	case LockModeShare:
+		clause = "FOR SHARE"
+	default:
+		return "", fmt.Errorf("unknown lock mode %d", mode)
+	}
+	if skipLocked {
+		clause += " SKIP LOCKED"
+	}
+	return clause, nil
+}
+
+// QuoteIdentifier quotes each segment of name with backticks (users.order -> `users`.`order`).
+func (d MySQLDialect) QuoteIdentifier(name string) string {
+	return quoteSegments(name, "`", "`")
+}
+
+// CurrentTimestampSQL returns the current UTC time with microseconds. CURRENT_TIMESTAMP would be in the
+// session time zone, which does not match columns stored in UTC.
+func (d MySQLDialect) CurrentTimestampSQL() string {
+	return "UTC_TIMESTAMP(6)"
+}
+
+// ColumnType returns the MySQL column type for values of goType. Strings map to VARCHAR(255) so that they
+// can be indexed; other unknown types (e.g., maps or structs) map to TEXT.
+func (d MySQLDialect) ColumnType(goType reflect.Type) string {
+	t := columnGoType(goType)
+	if t == reflect.TypeFor[time.Time]() {
+		return "DATETIME(6)"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "BOOLEAN"
+	case reflect.Int8:
+		return "TINYINT"
+	case reflect.Uint8:
+		return "TINYINT UNSIGNED"
+	case reflect.Int16:
+		return "SMALLINT"
+	case reflect.Uint16:
+		return "SMALLINT UNSIGNED"
+	case reflect.Int32:
+		return "INT"
+	case reflect.Uint32:
+		return "INT UNSIGNED"
+	case reflect.Int, reflect.Int64:
+		return "BIGINT"
+	case reflect.Uint, reflect.Uint64:
+		return "BIGINT UNSIGNED"
+	case reflect.Float32:
+		return "FLOAT"
+	case reflect.Float64:
+		return "DOUBLE"
+	case reflect.String:
+		return "VARCHAR(255)"
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "BLOB"
+		}
+	}
+	return "TEXT"
+}
+
+// AutoIncrementSQL returns the column attributes of an auto-incremented primary key.
+func (d MySQLDialect) AutoIncrementSQL() string {
+	return "NOT NULL AUTO_INCREMENT"
+}
+
+// CreateTableSQL generates CREATE TABLE IF NOT EXISTS with the given column definitions.
+func (d MySQLDialect) CreateTableSQL(tableName string, columnDefs []string) string {
+	return defaultCreateTableSQL(tableName, columnDefs)
+}
+
+// TrueLiteral returns TRUE, an alias of 1 for MySQL's BOOLEAN (TINYINT(1)) columns.
+func (d MySQLDialect) TrueLiteral() string {
+	return "TRUE"
+}
+
+// FalseLiteral returns FALSE, an alias of 0.
+func (d MySQLDialect) FalseLiteral() string {
+	return "FALSE"
+}
+
+// NullLiteral returns NULL.
+func (d MySQLDialect) NullLiteral() string {
+	return "NULL"
+}
+
+// SupportsReturning reports whether Returning is set.
+func (d MySQLDialect) SupportsReturning() bool {
+	return d.Returning
+}
+
+// InsertReturningSQL appends a RETURNING clause to the INSERT statement.
+func (d MySQLDialect) InsertReturningSQL(insertSQL string, returningCols []string) string {
+	return defaultInsertReturningSQL(insertSQL, returningCols)
+}
+
+// SupportsDeleteReturning reports whether Returning is set.
+func (d MySQLDialect) SupportsDeleteReturning() bool {
+	return d.Returning
+}
+
+// DeleteReturningSQL appends a RETURNING clause to the DELETE statement.
+func (d MySQLDialect) DeleteReturningSQL(deleteSQL string, returningCols []string) string {
+	return defaultInsertReturningSQL(deleteSQL, returningCols)
+}
+
+// FirstInsertID returns true: for a multi-row INSERT, LastInsertId is the key generated for the first row.
+func (d MySQLDialect) FirstInsertID() bool {
+	return true
+}
+
+// mysqlDuplicateEntryRe matches the message of MySQL error 1062 (ER_DUP_ENTRY).
+var mysqlDuplicateEntryRe = regexp.MustCompile(`Error 1062.*Duplicate entry .* for key '([^']*)'`)
+
+// IsUniqueViolation reports whether err is MySQL's duplicate entry error and returns the violated key.
+// MySQL 8 qualifies the key with the table name ("users.email"); the qualifier is removed.
+func (d MySQLDialect) IsUniqueViolation(err error) (string, bool) {
+	m := mysqlDuplicateEntryRe.FindStringSubmatch(err.Error())
+	if m == nil {
+		return "", false
+	}
+	key := m[1]
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		key = key[i+1:]
+	}
+	return key, true
+}
+
 // SQLiteDialect implements Dialect for SQLite.
-type SQLiteDialect struct{}
+//
+// By default Create reads an inserted row back with a SELECT by its primary key (the rowid of an
+// auto-increment key), which populates columns filled in by the database. Set Returning to read it back
+// with INSERT ... RETURNING in the same statement instead; it requires SQLite 3.35 or newer.
+type SQLiteDialect struct {
+	// Returning enables INSERT ... RETURNING for Create and DELETE ... RETURNING (SQLite 3.35+).
+	Returning bool
+}
 
 func (d SQLiteDialect) Placeholder(idx int) string {
 	return "?"
@@ -91,33 +430,206 @@ func (d SQLiteDialect) InsertSQL(tableName string, cols, placeholders []string)
 	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", tableName, strings.Join(cols, ", "), strings.Join(placeholders, ", "))
 }
 
+func (d SQLiteDialect) BulkInsertSQL(tableName string, cols []string, rows [][]string) string {
+	return DefaultBulkInsertSQL(tableName, cols, rows)
+}
+
 func (d SQLiteDialect) UpdateSQL(tableName string, setClauses string, pkColumn string, pkPlaceholder string) string {
 	return fmt.Sprintf("UPDATE %s SET %s WHERE %s = %s", tableName, setClauses, pkColumn, pkPlaceholder)
 }
 
 func (d SQLiteDialect) SelectSQL(tableName string, cols []string, joins, whereClause, orderByClause, lockClause string, limit, offset int) string {
-	return DefaultSelectSQL(tableName, cols, joins, whereClause, orderByClause, lockClause, limit, offset)
+	return selectSQL(tableName, cols, joins, whereClause, orderByClause, lockClause, limit, offset, sqliteNoLimit)
+}
+
+// OrderLimitSQL returns the ORDER BY, LIMIT and OFFSET clauses. SQLite needs a LIMIT in front of OFFSET, so
+// an offset without a limit gets LIMIT -1.
+func (d SQLiteDialect) OrderLimitSQL(orderByClause string, limit, offset int) string {
+	return orderLimitTail(orderByClause, limit, offset, sqliteNoLimit)
 }
 
 func (d SQLiteDialect) DeleteSQL(tableName string, pkColumn string, pkPlaceholder string) string {
 	return fmt.Sprintf("DELETE FROM %s WHERE %s = %s", tableName, pkColumn, pkPlaceholder)
 }
 
+// UpsertSQL generates the INSERT ... ON CONFLICT statement for SQLite, overwriting every column but the primary key.
 func (d SQLiteDialect) UpsertSQL(tableName string, pkColumn string, cols []string) string {
+	return d.UpsertColumnsSQL(tableName, pkColumn, cols, nonKeyColumns(cols, pkColumn))
+}
+
+// UpsertColumnsSQL generates the INSERT ... ON CONFLICT statement for SQLite, overwriting only updateCols.
+// With no updateCols an existing row is left untouched.
+func (d SQLiteDialect) UpsertColumnsSQL(tableName string, pkColumn string, cols, updateCols []string) string {
 	placeholders := make([]string, len(cols))
-	updateClauses := make([]string, 0, len(cols))
-	for i, col := range cols {
+	for i := range cols {
 		placeholders[i] = "?"
-		if col != pkColumn {
-			updateClauses = append(updateClauses, fmt.Sprintf("%s = excluded.%s", col, col))
-		}
+	}
+	updateClauses := make([]string, 0, len(updateCols))
+	for _, col := range updateCols {
+		updateClauses = append(updateClauses, fmt.Sprintf("%s = excluded.%s", col, col))
 	}
 
-	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT(%s) DO UPDATE SET %s",
+	action := "DO NOTHING"
+	if len(updateClauses) > 0 {
+		action = "DO UPDATE SET " + strings.Join(updateClauses, ", ")
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT(%s) %s",
 		tableName,
 		strings.Join(cols, ", "),
 		strings.Join(placeholders, ", "),
 		pkColumn,
-		strings.Join(updateClauses, ", "),
+		action,
 	)
 }
+
+// Collate returns the COLLATE clause for SQLite (e.g., COLLATE NOCASE).
+func (d SQLiteDialect) Collate(collation string) string {
+	return "COLLATE " + collation
+}
+
+// SupportsJoinType reports whether SQLite supports the join kind.
+// RIGHT and FULL OUTER JOIN require SQLite 3.39.0 or newer; the SQLite library bundled with recent
+// versions of github.com/mattn/go-sqlite3 satisfies this. Older SQLite versions reject these joins.
+func (d SQLiteDialect) SupportsJoinType(kind string) bool {
+	switch strings.ToUpper(kind) {
+	case JoinInner, JoinLeft, JoinRight, JoinFull, JoinCross:
+		return true
+	}
+	return false
+}
+
+// PartitionTable is not supported by SQLite, which has no table partitioning.
+func (d SQLiteDialect) PartitionTable(tableName, partition string) (string, error) {
+	return "", fmt.Errorf("table partitions are not supported by SQLite: %w", errors.ErrUnsupported)
+}
+
+// NotifySQL is not supported by SQLite, which has no LISTEN/NOTIFY mechanism.
+func (d SQLiteDialect) NotifySQL() (string, error) {
+	return "", fmt.Errorf("change notifications are not supported by SQLite: %w", errors.ErrUnsupported)
+}
+
+// LockTimeoutSQL returns no statement: SQLite locks the whole database rather than rows.
+func (d SQLiteDialect) LockTimeoutSQL(timeout time.Duration) (string, error) {
+	return "", nil
+}
+
+// LimitWithTiesSQL returns an error: SQLite has no FETCH ... WITH TIES.
+func (d SQLiteDialect) LimitWithTiesSQL(n int) (string, error) {
+	return "", fmt.Errorf("LimitWithTies is not supported by SQLite: %w", errors.ErrUnsupported)
+}
+
+// SessionSettingSQL returns an error: WithSessionSetting only supports PostgreSQL settings.
+func (d SQLiteDialect) SessionSettingSQL(setting, value string) (string, error) {
+	return "", fmt.Errorf("session settings are not supported by SQLite: %w", errors.ErrUnsupported)
+}
+
+// JSONContainsSQL returns an error: SQLite has no JSON containment operator.
+func (d SQLiteDialect) JSONContainsSQL(column, placeholder string) (string, error) {
+	return "", fmt.Errorf("JSON containment is not supported by SQLite: %w", errors.ErrUnsupported)
+}
+
+// MaxParameters returns SQLite's SQLITE_MAX_VARIABLE_NUMBER. Builds before 3.32 default to 999, which is
+// used since the limit of the linked library cannot be queried portably.
+func (d SQLiteDialect) MaxParameters() int {
+	return 999
+}
+
+// sqliteUniqueRe matches SQLite's unique and primary key violation messages.
+var sqliteUniqueRe = regexp.MustCompile(`UNIQUE constraint failed: (.+)$`)
+
+// IsUniqueViolation reports whether err is a SQLite unique or primary key violation. SQLite does not name
+// the constraint, so the offending columns are returned instead ("users.email", or "t.a, t.b" for a
+// composite key), or the index name for a violation of a unique index on expressions.
+func (d SQLiteDialect) IsUniqueViolation(err error) (string, bool) {
+	m := sqliteUniqueRe.FindStringSubmatch(err.Error())
+	if m == nil {
+		return "", false
+	}
+	if name, ok := strings.CutPrefix(m[1], "index '"); ok {
+		return strings.TrimSuffix(name, "'"), true
+	}
+	return m[1], true
+}
+
+// RowLockSQL returns no clause: SQLite has no row locks, and a write transaction locks the whole database.
+func (d SQLiteDialect) RowLockSQL(mode LockMode, skipLocked bool) (string, error) {
+	return "", nil
+}
+
+// QuoteIdentifier quotes each segment of name with double quotes (users.order -> "users"."order").
+func (d SQLiteDialect) QuoteIdentifier(name string) string {
+	return quoteSegments(name, `"`, `"`)
+}
+
+// CurrentTimestampSQL returns the current UTC time as YYYY-MM-DD HH:MM:SS text, which compares correctly
+// with time values stored in UTC.
+func (d SQLiteDialect) CurrentTimestampSQL() string {
+	return "CURRENT_TIMESTAMP"
+}
+
+// ColumnType returns the SQLite column type for values of goType: INTEGER for integers and booleans, so that an
+// integer primary key becomes the rowid, REAL, TEXT, BLOB or DATETIME. Unknown types map to TEXT.
+func (d SQLiteDialect) ColumnType(goType reflect.Type) string {
+	t := columnGoType(goType)
+	if t == reflect.TypeFor[time.Time]() {
+		return "DATETIME"
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "INTEGER"
+	case reflect.Float32, reflect.Float64:
+		return "REAL"
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "BLOB"
+		}
+	}
+	return "TEXT"
+}
+
+// AutoIncrementSQL returns no attributes: an INTEGER PRIMARY KEY column is assigned the next rowid by SQLite.
+func (d SQLiteDialect) AutoIncrementSQL() string {
+	return ""
+}
+
+// CreateTableSQL generates CREATE TABLE IF NOT EXISTS with the given column definitions.
+func (d SQLiteDialect) CreateTableSQL(tableName string, columnDefs []string) string {
+	return defaultCreateTableSQL(tableName, columnDefs)
+}
+
+// SupportsReturning reports whether Returning is set.
+func (d SQLiteDialect) SupportsReturning() bool {
+	return d.Returning
+}
+
+// InsertReturningSQL appends a RETURNING clause to the INSERT statement.
+func (d SQLiteDialect) InsertReturningSQL(insertSQL string, returningCols []string) string {
+	return defaultInsertReturningSQL(insertSQL, returningCols)
+}
+
+// SupportsDeleteReturning reports whether Returning is set.
+func (d SQLiteDialect) SupportsDeleteReturning() bool {
+	return d.Returning
+}
+
+// DeleteReturningSQL appends a RETURNING clause to the DELETE statement.
+func (d SQLiteDialect) DeleteReturningSQL(deleteSQL string, returningCols []string) string {
+	return defaultInsertReturningSQL(deleteSQL, returningCols)
+}
+
+// TrueLiteral returns 1: SQLite has no boolean storage class, so booleans are stored as integers, and the
+// TRUE keyword is only understood by SQLite 3.23 and newer.
+func (d SQLiteDialect) TrueLiteral() string {
+	return "1"
+}
+
+// FalseLiteral returns 0.
+func (d SQLiteDialect) FalseLiteral() string {
+	return "0"
+}
+
+// NullLiteral returns NULL.
+func (d SQLiteDialect) NullLiteral() string {
+	return "NULL"
+}
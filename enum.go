@@ -0,0 +1,68 @@
+package crud
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var (
+	enumValidatorsMu sync.RWMutex
+	enumValidators   = map[reflect.Type]func(any) bool{}
+)
+
+// RegisterEnumValidator registers a validation function for the enum type E (typically a named string
+// or integer type, e.g. `type Status string`). Every scanned value of a field of type E (or *E) is checked,
+// and scanning fails with a descriptive error if valid returns false, e.g. after schema drift introduced
+// a value the code does not know. Validators are resolved when a repository is created, so they must be
+// registered before NewRepository is called.
+func RegisterEnumValidator[E any](valid func(E) bool) {
+	enumValidatorsMu.Lock()
+	defer enumValidatorsMu.Unlock()
+	enumValidators[reflect.TypeFor[E]()] = func(v any) bool {
+		return valid(v.(E))
+	}
+}
+
+// RegisterEnum registers the allowed values of the enum type E, e.g. RegisterEnum(StatusActive, StatusBanned).
+// It is a shorthand for RegisterEnumValidator with a membership check.
+func RegisterEnum[E comparable](allowed ...E) {
+	set := make(map[E]struct{}, len(allowed))
+	for _, v := range allowed {
+		set[v] = struct{}{}
+	}
+	RegisterEnumValidator(func(v E) bool {
+		_, ok := set[v]
+		return ok
+	})
+}
+
+// lookupEnumValidator returns the validator registered for t, or for its element type if t is a pointer.
+func lookupEnumValidator(t reflect.Type) func(any) bool {
+	enumValidatorsMu.RLock()
+	defer enumValidatorsMu.RUnlock()
+	if valid, ok := enumValidators[t]; ok {
+		return valid
+	}
+	if t.Kind() == reflect.Pointer {
+		if valid, ok := enumValidators[t.Elem()]; ok {
+			return func(v any) bool {
+				ptr := reflect.ValueOf(v)
+				return ptr.IsNil() || valid(ptr.Elem().Interface())
+			}
+		}
+	}
+	return nil
+}
+
+// validateEnumField checks a scanned field value against its enum validator.
+func validateEnumField(val reflect.Value, f fieldInfo) error {
+	value := val.FieldByIndex(f.index).Interface()
+	if !f.enumValid(value) {
+		if ptr := reflect.ValueOf(value); ptr.Kind() == reflect.Pointer {
+			value = ptr.Elem().Interface()
+		}
+		return fmt.Errorf("invalid value %v for enum type %s in column '%s'", value, f.fieldType, f.columnName)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package crud
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is returned (possibly wrapped) when a lookup such as GetByID matches no rows.
+// It is the same value as sql.ErrNoRows, so errors.Is(err, ErrNotFound) and errors.Is(err, sql.ErrNoRows)
+// are interchangeable. Connection failures, timeouts and cancellations never satisfy it, which makes it
+// safe to map directly to a "not found" response.
+var ErrNotFound = sql.ErrNoRows
+
+// ErrTxRequired is returned by operations that only make sense inside a transaction, such as
+// row locking helpers, when they are called on a repository that was not created with WithTx.
+var ErrTxRequired = errors.New("operation requires a transaction; use a repository created with WithTx")
+
+// ErrStaleObject is returned (wrapped) by Update on a repository created with WithVersionColumn when the
+// stored version no longer matches the item's, i.e. the record was modified since it was read.
+var ErrStaleObject = errors.New("record was modified concurrently")
+
+// ErrTooManyRows is returned (wrapped) by List and the other listing methods when a query matches more rows
+// than the limit configured with WithMaxRows.
+var ErrTooManyRows = errors.New("query returned too many rows")
+
+// ErrDuplicate is returned (wrapped) by Create, Update and the other writes when the statement violates a
+// unique constraint or primary key. Use errors.As to read the constraint and map it to a field-level
+// validation message:
+//
+//	var dup *crud.ErrDuplicate
+//	if errors.As(err, &dup) && dup.Constraint == "users_email_key" { ... }
+type ErrDuplicate struct {
+	// Constraint is the violated constraint or unique index as reported by the database, or empty if the
+	// driver error does not name it. SQLite does not name constraints and reports the columns instead
+	// (e.g. "users.email").
+	Constraint string
+	Err        error // The driver error
+}
+
+func (e *ErrDuplicate) Error() string {
+	if e.Constraint == "" {
+		return fmt.Sprintf("duplicate value violates a unique constraint: %v", e.Err)
+	}
+	return fmt.Sprintf("duplicate value violates unique constraint %s: %v", e.Constraint, e.Err)
+}
+
+func (e *ErrDuplicate) Unwrap() error {
+	return e.Err
+}
+
+// duplicateError wraps err in an ErrDuplicate if the dialect recognizes it as a unique violation.
+func duplicateError(d Dialect, err error) error {
+	if err == nil {
+		return nil
+	}
+	if constraint, ok := isUniqueViolation(d, err); ok {
+		return &ErrDuplicate{Constraint: constraint, Err: err}
+	}
+	return err
+}
@@ -0,0 +1,92 @@
+package crud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// EstimateCost returns the planner's estimated total cost for the List query described by the options,
+// without running it. It executes EXPLAIN (FORMAT JSON) on PostgreSQL and EXPLAIN FORMAT=JSON on MySQL
+// and reads the top-level cost. This is best-effort: cost units are planner-specific and the value is
+// only meaningful when compared against thresholds tuned for the same database.
+// Dialects that do not implement CostEstimator return an error wrapping errors.ErrUnsupported.
+func (r *Repository[T]) EstimateCost(ctx context.Context, opts ...Option[T]) (float64, error) {
+	estimator, ok := r.dialect.(CostEstimator)
+	if !ok {
+		return 0, fmt.Errorf("EstimateCost is not supported by %T: %w", r.dialect, errors.ErrUnsupported)
+	}
+
+	qb, err := r.applyOptions(opts)
+	if err != nil {
+		return 0, err
+	}
+
+	e, err := r.getReadExecutor(ctx, qb)
+	if err != nil {
+		return 0, err
+	}
+	var plan []byte
+	qctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	if err := e.QueryRowContext(qctx, estimator.ExplainSQL(r.buildSelect(qb)), qb.args...).Scan(&plan); err != nil {
+		return 0, fmt.Errorf("explain failed: %w", err)
+	}
+	return estimator.ExplainCost(plan)
+}
+
+// ToSQL returns the SELECT statement and bind arguments that List would run for the options, without
+// executing anything. Relations requested with With are loaded by separate queries and are not included.
+func (r *Repository[T]) ToSQL(opts ...Option[T]) (string, []any, error) {
+	qb, err := r.applyOptions(opts)
+	if err != nil {
+		return "", nil, err
+	}
+	return r.buildSelect(qb), qb.args, nil
+}
+
+// ExplainSQL prefixes the query with EXPLAIN (FORMAT JSON).
+func (d PostgresDialect) ExplainSQL(selectSQL string) string {
+	return "EXPLAIN (FORMAT JSON) " + selectSQL
+}
+
+// ExplainCost extracts the top-level "Total Cost" from PostgreSQL's JSON plan output.
+func (d PostgresDialect) ExplainCost(plan []byte) (float64, error) {
+	var result []struct {
+		Plan struct {
+			TotalCost float64 `json:"Total Cost"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(plan, &result); err != nil {
+		return 0, fmt.Errorf("failed to parse explain output: %w", err)
+	}
+	if len(result) == 0 {
+		return 0, fmt.Errorf("explain output contains no plan")
+	}
+	return result[0].Plan.TotalCost, nil
+}
+
+// ExplainSQL prefixes the query with EXPLAIN FORMAT=JSON.
+func (d MySQLDialect) ExplainSQL(selectSQL string) string {
+	return "EXPLAIN FORMAT=JSON " + selectSQL
+}
+
+// ExplainCost extracts query_block.cost_info.query_cost from MySQL's JSON plan output.
+func (d MySQLDialect) ExplainCost(plan []byte) (float64, error) {
+	var result struct {
+		QueryBlock struct {
+			CostInfo struct {
+				QueryCost string `json:"query_cost"`
+			} `json:"cost_info"`
+		} `json:"query_block"`
+	}
+	if err := json.Unmarshal(plan, &result); err != nil {
+		return 0, fmt.Errorf("failed to parse explain output: %w", err)
+	}
+	if result.QueryBlock.CostInfo.QueryCost == "" {
+		return 0, fmt.Errorf("explain output contains no query cost")
+	}
+	return strconv.ParseFloat(result.QueryBlock.CostInfo.QueryCost, 64)
+}
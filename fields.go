@@ -0,0 +1,134 @@
+package crud
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// fieldInfo caches metadata about a struct field.
+type fieldInfo struct {
+	columnName   string
+	index        []int // Index path of the field, suitable for reflect.Value.FieldByIndex
+	fieldType    reflect.Type
+	isPK         bool
+	insertOnly   bool           // Written on INSERT but never overwritten by an upsert (insertonly modifier)
+	readOnly     bool           // Selected but never written (readonly modifier)
+	auto         bool           // Generated by the database on INSERT, so never inserted (auto modifier)
+	defaultValue reflect.Value  // Value bound on insert when the field is zero (default modifier); invalid if none
+	pgType       string         // Explicit PostgreSQL type for typed VALUES lists (pgtype modifier)
+	transform    string         // Name of the transformer from the transform tag modifier, if any
+	transformer  *Transformer   // Resolved by NewRepository
+	enumValid    func(any) bool // Enum validator for the field's type, resolved by NewRepository
+}
+
+// parseFields walks the struct type t and returns metadata for every field with a `db` tag, in field
+// declaration order. A struct field tagged with a prefix modifier, e.g. `db:"addr,prefix:address_"`,
+// is flattened: each of its own tagged fields becomes a column named prefix + column (address_city, ...).
+// The transform modifier, e.g. `db:"ssn,transform:aes"`, names the Transformer applied to the column.
+// The insertonly modifier, e.g. `db:"created_at,insertonly"`, keeps an upsert from updating the column.
+// The readonly modifier, e.g. `db:"created_at,readonly"`, marks a column that is selected but never inserted
+// or updated, and the auto modifier, e.g. `db:"id,pk,auto"`, one whose value the database generates on insert.
+// The default modifier, e.g. `db:"status,default:pending"`, is parsed to the field's type and inserted
+// in place of the zero value; since the modifiers are comma-separated, a default cannot contain a comma.
+// The pgtype modifier, e.g. `db:"id,pk,pgtype:uuid"`, sets the cast used for the column by UpdateMany on PostgreSQL.
+// The tagged fields of an untagged embedded struct, such as a common Base with the ID and timestamps, are
+// mapped as if they were declared in t.
+func parseFields(t reflect.Type, prefix string, parentIndex []int) ([]fieldInfo, error) {
+	var fields []fieldInfo
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("db")
+		index := append(append([]int(nil), parentIndex...), i)
+
+		if tag == "" && field.Anonymous {
+			embedded, err := parseEmbedded(field, prefix, index)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, embedded...)
+			continue
+		}
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		tagParts := strings.Split(tag, ",")
+		columnName := tagParts[0]
+
+		isPK, insertOnly, readOnly, auto := false, false, false, false
+		transform := ""
+		defaultText, hasDefault := "", false
+		pgType := ""
+		nestedPrefix, isNested := "", false
+		for _, part := range tagParts[1:] {
+			switch {
+			case part == "pk":
+				isPK = true
+			case part == "insertonly":
+				insertOnly = true
+			case part == "readonly":
+				readOnly = true
+			case part == "auto":
+				auto = true
+			case strings.HasPrefix(part, "pgtype:"):
+				pgType = strings.TrimPrefix(part, "pgtype:")
+			case strings.HasPrefix(part, "default:"):
+				defaultText, hasDefault = strings.TrimPrefix(part, "default:"), true
+			case strings.HasPrefix(part, "transform:"):
+				transform = strings.TrimPrefix(part, "transform:")
+			case strings.HasPrefix(part, "prefix:"):
+				nestedPrefix, isNested = strings.TrimPrefix(part, "prefix:"), true
+			}
+		}
+
+		if isNested {
+			if field.Type.Kind() != reflect.Struct {
+				return nil, fmt.Errorf("field %s uses the prefix modifier but is not a struct", field.Name)
+			}
+			nested, err := parseFields(field.Type, prefix+nestedPrefix, index)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, nested...)
+			continue
+		}
+
+		var defaultValue reflect.Value
+		if hasDefault {
+			var err error
+			if defaultValue, err = parseDefault(field.Type, defaultText); err != nil {
+				return nil, fmt.Errorf("field %s: %w", field.Name, err)
+			}
+		}
+
+		fields = append(fields, fieldInfo{
+			columnName:   prefix + columnName,
+			index:        index,
+			fieldType:    field.Type,
+			isPK:         isPK,
+			insertOnly:   insertOnly,
+			readOnly:     readOnly,
+			auto:         auto,
+			defaultValue: defaultValue,
+			pgType:       pgType,
+			transform:    transform,
+		})
+	}
+	return fields, nil
+}
+
+// parseEmbedded returns the fields of the untagged embedded struct field, flattened with the same prefix as
+// the struct embedding it. Embedded pointers cannot be scanned into before they are allocated, so an
+// embedded pointer to a struct with db tags is an error.
+func parseEmbedded(field reflect.StructField, prefix string, index []int) ([]fieldInfo, error) {
+	switch {
+	case field.Type.Kind() == reflect.Struct:
+		return parseFields(field.Type, prefix, index)
+	case field.Type.Kind() == reflect.Pointer && field.Type.Elem().Kind() == reflect.Struct:
+		if nested, err := parseFields(field.Type.Elem(), prefix, index); err != nil || len(nested) > 0 {
+			return nil, fmt.Errorf("embedded field %s must not be a pointer; embed %s by value", field.Name, field.Type.Elem().Name())
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,94 @@
+package crud
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// GeneratedModel is implemented by the methods that cmd/crudgen generates for a model type (on its pointer).
+// When *T implements it, NewRepository uses these methods instead of reflection to build scan destinations
+// and statement arguments. Models with a transformer column still go through the reflective path, and so do
+// the arguments of pointer fields and of fields whose driver.Valuer has a pointer receiver.
+//
+//	//go:generate go run github.com/dimatock/crud/cmd/crudgen -type User
+type GeneratedModel interface {
+	// CrudColumns returns the mapped columns in the order NewRepository derives from the struct tags.
+	CrudColumns() []string
+	// CrudScanTargets returns pointers to the mapped fields, in CrudColumns order.
+	CrudScanTargets() []any
+	// CrudValues returns the values of the mapped fields, in CrudColumns order.
+	CrudValues() []any
+}
+
+// useGeneratedModel reports whether the repository can use the GeneratedModel methods of *T. It fails if the
+// generated columns no longer match the struct tags, which means the generator needs to be re-run.
+func (r *Repository[T]) useGeneratedModel() (bool, error) {
+	model, ok := any(new(T)).(GeneratedModel)
+	if !ok {
+		return false, nil
+	}
+	if cols := model.CrudColumns(); !slices.Equal(cols, r.columns) {
+		return false, fmt.Errorf("generated code for %s is out of date (columns %v, struct tags %v); re-run crudgen",
+			reflect.TypeFor[T]().Name(), cols, r.columns)
+	}
+	for _, f := range r.fields {
+		if f.transformer != nil {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// scanGenerated is the scanRow path for models implementing GeneratedModel.
+func (r *Repository[T]) scanGenerated(scannable interface{ Scan(...any) error }, extra []any) (T, error) {
+	var instance T
+	scanDest := append(any(&instance).(GeneratedModel).CrudScanTargets(), extra...)
+	if err := scannable.Scan(scanDest...); err != nil {
+		return instance, err
+	}
+
+	for _, f := range r.fields {
+		if f.enumValid != nil {
+			if err := validateEnumField(reflect.ValueOf(&instance).Elem(), f); err != nil {
+				return instance, err
+			}
+		}
+	}
+	return instance, nil
+}
+
+// reflectedArgFields returns the positions of the fields whose values CrudValues cannot hand to database/sql as
+// they are: pointers, which are bound as NULL when nil without calling a Value method, and fields whose
+// Value method has a pointer receiver, which are passed by address. See driverArg.
+func reflectedArgFields(fields []fieldInfo) []int {
+	var positions []int
+	for i, f := range fields {
+		t := f.fieldType
+		if t.Kind() == reflect.Pointer || (!t.Implements(valuerType) && reflect.PointerTo(t).Implements(valuerType)) {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
+// fieldValues returns the values of all mapped fields of item, in field order, ready to be bound.
+func (r *Repository[T]) fieldValues(item *T) ([]any, error) {
+	val := reflect.ValueOf(item).Elem()
+	if r.generated {
+		vals := any(item).(GeneratedModel).CrudValues()
+		for _, i := range r.reflectedArgs {
+			vals[i] = driverArg(val.FieldByIndex(r.fields[i].index))
+		}
+		return vals, nil
+	}
+	vals := make([]any, len(r.fields))
+	for i, f := range r.fields {
+		value, err := encodeField(val, f)
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = value
+	}
+	return vals, nil
+}
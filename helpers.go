@@ -9,3 +9,24 @@ func IntsToAnys[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~ui
 	}
 	return ans
 }
+
+// MapKeysToAnys returns the keys of a map as a slice of any.
+// This is a helper function for the WhereIn option when the keys to query by are held in a map.
+// The order of the returned keys is unspecified, as with map iteration.
+func MapKeysToAnys[K comparable, V any](m map[K]V) []any {
+	ans := make([]any, 0, len(m))
+	for k := range m {
+		ans = append(ans, k)
+	}
+	return ans
+}
+
+// MapValuesToAnys returns the values of a map as a slice of any.
+// The order of the returned values is unspecified, as with map iteration.
+func MapValuesToAnys[K comparable, V any](m map[K]V) []any {
+	ans := make([]any, 0, len(m))
+	for _, v := range m {
+		ans = append(ans, v)
+	}
+	return ans
+}
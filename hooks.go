@@ -0,0 +1,63 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+)
+
+// BeforeCreateHook is implemented by models that need to validate or derive fields before Create and
+// CreateMany insert them. Returning an error aborts the insert.
+type BeforeCreateHook interface {
+	BeforeCreate(ctx context.Context) error
+}
+
+// AfterCreateHook is implemented by models that need to run logic once Create or CreateMany inserted them.
+// The hook receives the record as returned by the database; its error is returned to the caller.
+type AfterCreateHook interface {
+	AfterCreate(ctx context.Context) error
+}
+
+// BeforeUpdateHook is implemented by models that need to validate or derive fields before Update.
+// Returning an error aborts the update.
+type BeforeUpdateHook interface {
+	BeforeUpdate(ctx context.Context) error
+}
+
+// AfterUpdateHook is implemented by models that need to run logic once Update succeeded.
+type AfterUpdateHook interface {
+	AfterUpdate(ctx context.Context) error
+}
+
+// BeforeDeleteHook is implemented by models that need to run logic before Delete or ForceDelete.
+// Since deletes only receive a primary key, the record is loaded first; returning an error aborts the delete.
+type BeforeDeleteHook interface {
+	BeforeDelete(ctx context.Context) error
+}
+
+// AfterDeleteHook is implemented by models that need to run logic once Delete or ForceDelete succeeded.
+// It is called on the record as it was loaded before the delete.
+type AfterDeleteHook interface {
+	AfterDelete(ctx context.Context) error
+}
+
+// callHook invokes call if item implements the hook interface H. Hooks are looked up on a pointer to the
+// item, so both value and pointer receivers work and pointer receivers may modify the item.
+func callHook[H any](item any, name string, call func(H) error) error {
+	h, ok := item.(H)
+	if !ok {
+		return nil
+	}
+	if err := call(h); err != nil {
+		return fmt.Errorf("%s hook: %w", name, err)
+	}
+	return nil
+}
+
+// hasDeleteHooks reports whether T implements BeforeDeleteHook or AfterDeleteHook, in which case
+// deletes need to load the record first.
+func (r *Repository[T]) hasDeleteHooks() bool {
+	var item T
+	_, before := any(&item).(BeforeDeleteHook)
+	_, after := any(&item).(AfterDeleteHook)
+	return before || after
+}
@@ -0,0 +1,128 @@
+package crud
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+	"time"
+)
+
+// QueryLogger receives every statement executed by a repository configured with WithLogger, after it ran.
+// For queries returning rows, duration covers the round trip that produced the first result, not the
+// iteration over the rows. LogQuery may be called concurrently and must not retain or modify args.
+type QueryLogger interface {
+	LogQuery(ctx context.Context, sql string, args []any, duration time.Duration, err error)
+}
+
+// RecordedQuery is a statement captured by the recorder configured with WithQueryRecorder.
+type RecordedQuery struct {
+	Op    string // Leading SQL keyword of the statement, e.g. "SELECT" or "INSERT"
+	SQL   string
+	Args  []any
+	Label string // Logical operation name attached to the context with WithQueryLabel
+}
+
+// queryRecorder is a bounded, concurrency-safe buffer of the most recent statements.
+type queryRecorder struct {
+	mu      sync.Mutex
+	limit   int
+	queries []RecordedQuery
+}
+
+func (rec *queryRecorder) record(q RecordedQuery) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.queries = append(rec.queries, q)
+	if len(rec.queries) > rec.limit {
+		rec.queries = rec.queries[len(rec.queries)-rec.limit:]
+	}
+}
+
+func (rec *queryRecorder) snapshot() []RecordedQuery {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return append([]RecordedQuery(nil), rec.queries...)
+}
+
+// instrumentedExecutor wraps an executor and reports every statement to the repository's observability hooks.
+type instrumentedExecutor struct {
+	executor
+	config *repositoryConfig
+}
+
+// instrument wraps e with the configured observability hooks, or returns it unchanged if there are none.
+func (r *Repository[T]) instrument(e executor) executor {
+	if r.config.recorder == nil && r.config.logger == nil && r.config.timeout <= 0 {
+		return e
+	}
+	return instrumentedExecutor{executor: e, config: &r.config}
+}
+
+func (e instrumentedExecutor) observe(ctx context.Context, query string, args []any, start time.Time, err error) {
+	if e.config.logger != nil {
+		e.config.logger.LogQuery(ctx, query, args, time.Since(start), err)
+	}
+	if e.config.recorder != nil {
+		e.config.recorder.record(RecordedQuery{
+			Op:    statementOp(query),
+			SQL:   query,
+			Args:  append([]any(nil), args...),
+			Label: QueryLabel(ctx),
+		})
+	}
+}
+
+// boundedContext applies the timeout configured with WithDefaultTimeout to ctx, unless ctx already has an
+// earlier deadline.
+func boundedContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= timeout {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// queryContext applies the timeout configured with WithDefaultTimeout to a query. Rows are read after
+// QueryContext returns, so the deadline cannot be released by the executor: the caller defers cancel until
+// the rows have been scanned.
+func (r *Repository[T]) queryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return boundedContext(ctx, r.config.timeout)
+}
+
+func (e instrumentedExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, cancel := boundedContext(ctx, e.config.timeout)
+	defer cancel()
+	start := time.Now()
+	res, err := e.executor.ExecContext(ctx, query, args...)
+	e.observe(ctx, query, args, start, err)
+	return res, err
+}
+
+// QueryContext runs the query without a deadline of its own; see Repository.queryContext.
+func (e instrumentedExecutor) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := e.executor.QueryContext(ctx, query, args...)
+	e.observe(ctx, query, args, start, err)
+	return rows, err
+}
+
+// QueryRowContext runs the query without a deadline of its own; see Repository.queryContext.
+func (e instrumentedExecutor) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	start := time.Now()
+	row := e.executor.QueryRowContext(ctx, query, args...)
+	// The error of a row is deferred until Scan; Err reports it early without consuming the row
+	e.observe(ctx, query, args, start, row.Err())
+	return row
+}
+
+// statementOp returns the upper-cased leading keyword of a SQL statement.
+func statementOp(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[0])
+}
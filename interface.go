@@ -3,43 +3,169 @@ package crud
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 // RepositoryInterface defines the interface for a generic CRUD repository.
 type RepositoryInterface[T any] interface {
+	// LastQueries returns the statements captured by WithQueryRecorder, oldest first.
+	LastQueries() []RecordedQuery
+
 	// WithTx returns a new repository instance that will run queries within the given transaction.
 	WithTx(tx *sql.Tx) RepositoryInterface[T]
 
 	// Create inserts a new record into the database.
 	Create(ctx context.Context, item T) (T, error)
 
+	// BuildInsert returns the INSERT statement and arguments that Create would execute, without running it.
+	BuildInsert(item T) (string, []any, error)
+
+	// CreateMany inserts multiple records with multi-row INSERT statements and returns them with generated IDs.
+	CreateMany(ctx context.Context, items []T) ([]T, error)
+
 	// CreateOrUpdate inserts a new record or updates it if it already exists.
 	CreateOrUpdate(ctx context.Context, item T) (T, error)
 
-	// GetByID retrieves a single record by its primary key.
+	// GetByID retrieves a single record by its primary key. It returns ErrNotFound if no record exists.
 	GetByID(ctx context.Context, id any, opts ...Option[T]) (T, error)
 
+	// GetForUpdateIf locks the record FOR UPDATE within a transaction and reports whether cond holds for it.
+	GetForUpdateIf(ctx context.Context, id any, cond func(T) bool) (T, bool, error)
+
+	// GetByIDsOrdered retrieves records by primary key, preserving the order of ids and reporting missing ids.
+	GetByIDsOrdered(ctx context.Context, ids []any) ([]T, []any, error)
+
 	// List retrieves a slice of records based on the provided options.
 	List(ctx context.Context, opts ...Option[T]) ([]T, error)
 
+	// Pluck returns the values of a single column for the records matching the options.
+	Pluck(ctx context.Context, column string, opts ...Option[T]) ([]any, error)
+
+	// ListInto is like List but appends the records to the provided slice, reusing its capacity.
+	ListInto(ctx context.Context, dest *[]T, opts ...Option[T]) error
+
+	// ListChan streams the records matching the options over a channel; the error channel reports failures.
+	// The returned stop function ends the stream early and must be called once the consumer is done.
+	ListChan(ctx context.Context, opts ...Option[T]) (<-chan T, <-chan error, func())
+
+	// EstimateCost returns the planner's estimated cost for the List query described by the options.
+	EstimateCost(ctx context.Context, opts ...Option[T]) (float64, error)
+
+	// ToSQL returns the SELECT statement and arguments List would run for the options, without executing it.
+	ToSQL(opts ...Option[T]) (string, []any, error)
+
+	// ListAfter returns the records following cursorValue in cursorColumn order (keyset pagination).
+	ListAfter(ctx context.Context, cursorColumn string, cursorValue any, limit int, opts ...Option[T]) ([]T, error)
+
+	// GetBy retrieves a single record by the value of the given column.
+	GetBy(ctx context.Context, column string, value any, opts ...Option[T]) (T, error)
+
+	// First returns the first record matching the options, or ErrNotFound if there is none.
+	First(ctx context.Context, opts ...Option[T]) (T, error)
+
+	// Count returns the number of records matching the options, ignoring ordering, limits and relations.
+	Count(ctx context.Context, opts ...Option[T]) (int64, error)
+
+	// Sum, Avg, Min and Max return the aggregate of a numeric column over the matching records, or 0 if none match.
+	Sum(ctx context.Context, column string, opts ...Option[T]) (float64, error)
+	Avg(ctx context.Context, column string, opts ...Option[T]) (float64, error)
+	Min(ctx context.Context, column string, opts ...Option[T]) (float64, error)
+	Max(ctx context.Context, column string, opts ...Option[T]) (float64, error)
+	// SumNull, AvgNull, MinNull and MaxNull preserve a NULL aggregate as an invalid sql.NullFloat64.
+	SumNull(ctx context.Context, column string, opts ...Option[T]) (sql.NullFloat64, error)
+	AvgNull(ctx context.Context, column string, opts ...Option[T]) (sql.NullFloat64, error)
+	MinNull(ctx context.Context, column string, opts ...Option[T]) (sql.NullFloat64, error)
+	MaxNull(ctx context.Context, column string, opts ...Option[T]) (sql.NullFloat64, error)
+
+	// Exists reports whether at least one record matches the options.
+	Exists(ctx context.Context, opts ...Option[T]) (bool, error)
+
+	// Paginate returns one page of records along with the total number of matching records.
+	Paginate(ctx context.Context, page, perPage int, opts ...Option[T]) (PageResult[T], error)
+
+	// ListByQuery returns the page of records described by the filters, sort and pagination of q.
+	ListByQuery(ctx context.Context, q ListQuery[T]) (PageResult[T], error)
+
 	// Update modifies an existing record.
 	Update(ctx context.Context, item T) (T, error)
 
+	// UpdateWithResult is like Update but returns the number of affected rows instead of the item.
+	UpdateWithResult(ctx context.Context, item T) (int64, error)
+
+	// UpdateMany updates all items by primary key and returns the number of updated rows.
+	UpdateMany(ctx context.Context, items []T) (int64, error)
+
+	// SyncInsertMissing inserts the items whose key column values are not present yet and returns how many were inserted.
+	SyncInsertMissing(ctx context.Context, items []T, keyColumns []string) (int64, error)
+
+	// UpdateFields updates only the named columns of the record with the given primary key.
+	UpdateFields(ctx context.Context, id any, fields map[string]any) error
+
+	// UpdateWhere sets the given columns on all records matching the options and returns the affected row count.
+	UpdateWhere(ctx context.Context, fields map[string]any, opts ...Option[T]) (int64, error)
+
 	// Delete removes a record from the database by its primary key.
 	Delete(ctx context.Context, id any) error
 
+	// ForceDelete physically removes a record by its primary key, bypassing soft deletes.
+	ForceDelete(ctx context.Context, id any) error
+
+	// DeleteWhere removes all records matching the options and returns the number of deleted rows.
+	DeleteWhere(ctx context.Context, opts ...Option[T]) (int64, error)
+
+	// DeleteWhereReturning removes all records matching the options and returns the deleted rows.
+	DeleteWhereReturning(ctx context.Context, opts ...Option[T]) ([]T, error)
+
+	// AutoMigrate creates the table from the record type's db tags if it does not exist (development only).
+	AutoMigrate(ctx context.Context) error
+
+	// Query starts a fluent query that collects options and passes them to List, Count and friends.
+	Query() *Query[T]
+
 	// =========================================================================
 	// Query Option Methods
 	// =========================================================================
 
 	Where(args ...any) Option[T]
 	OrderBy(column string, direction SortDirection) Option[T]
+	OrderByCollate(column string, collation string, direction SortDirection) Option[T]
+	WithPartition(partitionName string) Option[T]
 	Limit(limit int) Option[T]
 	Offset(offset int) Option[T]
+	WithPage(page, size int) Option[T]
+	LimitWithTies(n int) Option[T]
+	Columns(cols ...string) Option[T]
+	SelectAs(expr, alias string) Option[T]
+	Distinct() Option[T]
 	Join(joinClause string) Option[T]
+	InnerJoin(table, on string) Option[T]
+	LeftJoin(table, on string) Option[T]
+	RightJoin(table, on string) Option[T]
+	FullJoin(table, on string) Option[T]
 	Lock(clause string) Option[T]
+	LockForUpdate() Option[T]
+	LockForShare() Option[T]
+	SkipLocked() Option[T]
+	WithLockTimeout(d time.Duration) Option[T]
+	WithSessionSetting(setting, value string) Option[T]
 	WhereIn(column string, values ...any) Option[T]
+	WhereNotIn(column string, values ...any) Option[T]
+	WhereInOrAll(column string, values ...any) Option[T]
+	WhereInLarge(column string, values []any) Option[T]
 	WhereLike(column string, value any) Option[T]
+	WhereJSONContains(column string, fragment any) Option[T]
+	WhereTimeBetween(column string, from, to time.Time) Option[T]
+	WhereColumnOpNow(column, operator string) Option[T]
+	WhereNull(column string) Option[T]
+	WhereNotNull(column string) Option[T]
 	WhereSubquery(column, operator, subquery string, args ...any) Option[T]
+	WhereMatch(example T) Option[T]
+	WhereNot(opts ...Option[T]) Option[T]
+	Or(opts ...Option[T]) Option[T]
+	And(opts ...Option[T]) Option[T]
+	PreferPrimary() Option[T]
+	WithTrashed() Option[T]
+	PreferReplica() Option[T]
+	ApplyScope(scope Scope[T]) Option[T]
 	WithRelation(mapper Relation[T]) Option[T]
 }
@@ -0,0 +1,60 @@
+package crud
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// JSON stores a value of type V as a JSON document, e.g. in a text, json or jsonb column:
+//
+//	type User struct {
+//	    ID       int              `db:"id,pk"`
+//	    Settings crud.JSON[Prefs] `db:"settings"`
+//	}
+//
+// The value is marshaled with encoding/json when it is written and unmarshaled when it is read, so models
+// do not need a transformer or their own Valuer/Scanner for JSON columns. A NULL column scans into the zero
+// value of V; use *JSON[V] to tell NULL apart from an empty document.
+type JSON[V any] struct {
+	Data V
+}
+
+// NewJSON wraps data for storage as a JSON document.
+func NewJSON[V any](data V) JSON[V] {
+	return JSON[V]{Data: data}
+}
+
+// Value implements driver.Valuer. The document is bound as a string so that it is stored as text by
+// drivers which would store []byte as a blob.
+func (j JSON[V]) Value() (driver.Value, error) {
+	data, err := json.Marshal(j.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON column value: %w", err)
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner for documents scanned as []byte or string.
+func (j *JSON[V]) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		var zero V
+		j.Data = zero
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into a JSON column value", src)
+	}
+	// Unmarshal into a fresh value: decoding into j.Data would merge with a map or struct scanned before.
+	var value V
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to unmarshal JSON column value: %w", err)
+	}
+	j.Data = value
+	return nil
+}
@@ -0,0 +1,107 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+)
+
+// ListChan runs the List query described by opts in a goroutine and streams the scanned records over the
+// returned channel, so large result sets can be processed (or fanned out to workers) without holding them
+// in memory. The record channel is closed when the rows are exhausted or an error occurs; the error
+// channel then yields at most one error and is closed too, so the usual pattern is:
+//
+//	items, errc, stop := repo.ListChan(ctx, opts...)
+//	defer stop()
+//	for item := range items {
+//		// ...
+//	}
+//	if err := <-errc; err != nil {
+//		// ...
+//	}
+//
+// The stop function must be called once the consumer is done, like the cancel function of a context. It
+// ends the query if records are still pending and waits until the goroutine has closed the rows, so a
+// consumer that stops reading early never leaves the goroutine blocked or the connection held; the error
+// channel then reports context.Canceled. Cancelling ctx has the same effect. Eager loading with With is not
+// supported, since relations are loaded in batches after the query; such options make ListChan fail.
+// WithMaxRows applies as in List.
+func (r *Repository[T]) ListChan(ctx context.Context, opts ...Option[T]) (<-chan T, <-chan error, func()) {
+	items := make(chan T)
+	errc := make(chan error, 1)
+	done := make(chan struct{})
+	ctx, cancel := context.WithCancel(ctx)
+
+	go func() {
+		defer close(done)
+		defer close(errc)
+		defer close(items)
+		if err := r.streamList(ctx, opts, items); err != nil {
+			errc <- err
+		}
+	}()
+	stop := func() {
+		cancel()
+		<-done
+	}
+	return items, errc, stop
+}
+
+// streamList runs the query described by opts and sends every scanned record to out.
+func (r *Repository[T]) streamList(ctx context.Context, opts []Option[T], out chan<- T) error {
+	qb, err := r.applyOptions(opts)
+	if err != nil {
+		return err
+	}
+	if len(qb.relations) > 0 {
+		return fmt.Errorf("ListChan does not support eager loading relations")
+	}
+
+	sql := r.buildSelect(qb)
+	restore, err := r.applyTxSettings(ctx, qb)
+	if err != nil {
+		return err
+	}
+	defer restore()
+	e, err := r.getReadExecutor(ctx, qb)
+	if err != nil {
+		return err
+	}
+	qctx, cancelQuery := r.queryContext(ctx)
+	defer cancelQuery()
+	rows, err := e.QueryContext(qctx, sql, qb.args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	positions, err := r.fieldPositions(columns)
+	if err != nil {
+		return err
+	}
+
+	maxRows := r.config.maxRows
+	scanned := 0
+	for rows.Next() {
+		// select picks randomly among ready cases, so check for cancellation before offering another record
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if scanned++; maxRows > 0 && scanned > maxRows {
+			return fmt.Errorf("%w: more than %d rows matched in %s", ErrTooManyRows, maxRows, r.tableName)
+		}
+		instance, err := r.scanFields(rows, positions)
+		if err != nil {
+			return err
+		}
+		select {
+		case out <- instance:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return rows.Err()
+}
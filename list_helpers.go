@@ -0,0 +1,42 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+)
+
+// ListGroupedBy lists the records matching opts and groups them by the key returned by keyFn.
+// Within each group, records keep the order in which the query returned them, so combining it with
+// OrderBy yields sorted groups.
+func ListGroupedBy[T any, K comparable](
+	ctx context.Context, repo RepositoryInterface[T], keyFn func(T) K, opts ...Option[T],
+) (map[K][]T, error) {
+	items, err := repo.List(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	grouped := make(map[K][]T)
+	for _, item := range items {
+		key := keyFn(item)
+		grouped[key] = append(grouped[key], item)
+	}
+	return grouped, nil
+}
+
+// WhereInPlucked plucks relatedColumn from the records of relatedRepo matching relatedOpts and returns a
+// WhereIn option on column with the resulting values, e.g. users whose id is among the posts' user_id values.
+// If the pluck returns no values, the returned option matches no records (it is not a no-op), mirroring
+// the semantics of an IN over an empty subquery.
+func WhereInPlucked[T any, RT any](
+	ctx context.Context, column string, relatedRepo RepositoryInterface[RT], relatedColumn string, relatedOpts ...Option[RT],
+) (Option[T], error) {
+	values, err := relatedRepo.Pluck(ctx, relatedColumn, relatedOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("WhereInPlucked: %w", err)
+	}
+	if len(values) == 0 {
+		return rawWhereOption[T]{clause: "1 = 0"}, nil
+	}
+	return WhereIn[T](column, values...), nil
+}
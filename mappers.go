@@ -14,6 +14,23 @@ type Relation[T any] interface {
 	Process(ctx context.Context, parents []*T) error
 }
 
+// processChildren runs the nested relations against the fetched related models, in place.
+func processChildren[RelatedT any](ctx context.Context, children []Relation[RelatedT], related []RelatedT) error {
+	if len(children) == 0 || len(related) == 0 {
+		return nil
+	}
+	ptrs := make([]*RelatedT, len(related))
+	for i := range related {
+		ptrs[i] = &related[i]
+	}
+	for _, child := range children {
+		if err := child.Process(ctx, ptrs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // --- ManyToOneMapper ---
 
 // ManyToOneMapper implements the Relation interface for a many-to-one (Belongs To) relationship.
@@ -29,6 +46,15 @@ type ManyToOneMapper[ParentT any, RelatedT any, FKT comparable] struct {
 	GetPK func(r *RelatedT) FKT
 	// SetRelated sets the single related model onto the parent model.
 	SetRelated func(p *ParentT, r *RelatedT)
+
+	children []Relation[RelatedT] // Nested relations loaded on the related models (see With)
+}
+
+// With returns a copy of the mapper that also eager-loads the child relation on the related models,
+// e.g. the authors of the posts' comments.
+func (m ManyToOneMapper[ParentT, RelatedT, FKT]) With(child Relation[RelatedT]) ManyToOneMapper[ParentT, RelatedT, FKT] {
+	m.children = append(m.children[:len(m.children):len(m.children)], child)
+	return m
 }
 
 // Process executes the eager loading logic for the many-to-one relationship.
@@ -59,6 +85,9 @@ func (m ManyToOneMapper[ParentT, RelatedT, FKT]) Process(ctx context.Context, pa
 	if err != nil {
 		return fmt.Errorf("failed to fetch related entities for ManyToOne: %w", err)
 	}
+	if err := processChildren(ctx, m.children, related); err != nil {
+		return err
+	}
 
 	relatedMap := make(map[FKT]RelatedT)
 	for i := range related {
@@ -84,6 +113,15 @@ type OneToManyMapper[ParentT any, RelatedT any, PKT comparable] struct {
 	GetPK      func(p *ParentT) PKT
 	GetFK      func(r *RelatedT) PKT
 	SetRelated func(p *ParentT, r []*RelatedT)
+
+	children []Relation[RelatedT] // Nested relations loaded on the related models (see With)
+}
+
+// With returns a copy of the mapper that also eager-loads the child relation on the related models,
+// e.g. the comments of each user's posts.
+func (m OneToManyMapper[ParentT, RelatedT, PKT]) With(child Relation[RelatedT]) OneToManyMapper[ParentT, RelatedT, PKT] {
+	m.children = append(m.children[:len(m.children):len(m.children)], child)
+	return m
 }
 
 // Process executes the eager loading logic for the one-to-many relationship.
@@ -105,6 +143,9 @@ func (m OneToManyMapper[ParentT, RelatedT, PKT]) Process(ctx context.Context, pa
 	if err != nil {
 		return fmt.Errorf("failed to fetch related entities for OneToMany: %w", err)
 	}
+	if err := processChildren(ctx, m.children, related); err != nil {
+		return err
+	}
 
 	groupedRelated := make(map[PKT][]*RelatedT)
 	for i := range related {
@@ -133,6 +174,14 @@ type HasOneMapper[ParentT any, RelatedT any, PKT comparable] struct {
 	GetPK      func(p *ParentT) PKT
 	GetFK      func(r *RelatedT) PKT
 	SetRelated func(p *ParentT, r *RelatedT)
+
+	children []Relation[RelatedT] // Nested relations loaded on the related models (see With)
+}
+
+// With returns a copy of the mapper that also eager-loads the child relation on the related models.
+func (m HasOneMapper[ParentT, RelatedT, PKT]) With(child Relation[RelatedT]) HasOneMapper[ParentT, RelatedT, PKT] {
+	m.children = append(m.children[:len(m.children):len(m.children)], child)
+	return m
 }
 
 // Process executes the eager loading logic for the one-to-one relationship.
@@ -154,6 +203,9 @@ func (m HasOneMapper[ParentT, RelatedT, PKT]) Process(ctx context.Context, paren
 	if err != nil {
 		return fmt.Errorf("failed to fetch related entities for HasOne: %w", err)
 	}
+	if err := processChildren(ctx, m.children, related); err != nil {
+		return err
+	}
 
 	relatedMap := make(map[PKT]RelatedT)
 	for i := range related {
@@ -170,3 +222,104 @@ func (m HasOneMapper[ParentT, RelatedT, PKT]) Process(ctx context.Context, paren
 	}
 	return nil
 }
+
+// --- ManyToManyMapper ---
+
+// JoinPair is a row of a join table, linking a parent key to a related key (e.g., user_id, role_id).
+type JoinPair[K comparable] struct {
+	ParentKey  K
+	RelatedKey K
+}
+
+// JoinFetcher is a function type that fetches the join-table rows for a given set of parent keys.
+type JoinFetcher[K comparable] func(ctx context.Context, parentKeys []K) ([]JoinPair[K], error)
+
+// ManyToManyMapper implements the Relation interface for a many-to-many relationship through a join table
+// (e.g., users <-> roles via user_roles).
+// ParentT is the type of the model being queried (e.g., User).
+// RelatedT is the type of the model to be loaded (e.g., Role).
+// PKT is the type of the keys stored in the join table (e.g., int).
+type ManyToManyMapper[ParentT any, RelatedT any, PKT comparable] struct {
+	// JoinFetcher retrieves the join-table pairs for the parent keys.
+	JoinFetcher JoinFetcher[PKT]
+	// Fetcher retrieves the related models by their keys.
+	Fetcher RelatedFetcher[PKT, RelatedT]
+	// GetPK extracts the primary key from the parent model.
+	GetPK func(p *ParentT) PKT
+	// GetRelatedPK extracts the primary key from the related model.
+	GetRelatedPK func(r *RelatedT) PKT
+	// SetRelated sets the related models onto the parent model.
+	SetRelated func(p *ParentT, r []*RelatedT)
+
+	children []Relation[RelatedT] // Nested relations loaded on the related models (see With)
+}
+
+// With returns a copy of the mapper that also eager-loads the child relation on the related models.
+func (m ManyToManyMapper[ParentT, RelatedT, PKT]) With(child Relation[RelatedT]) ManyToManyMapper[ParentT, RelatedT, PKT] {
+	m.children = append(m.children[:len(m.children):len(m.children)], child)
+	return m
+}
+
+// Process executes the eager loading logic for the many-to-many relationship.
+func (m ManyToManyMapper[ParentT, RelatedT, PKT]) Process(ctx context.Context, parents []*ParentT) error {
+	if m.JoinFetcher == nil || m.Fetcher == nil || m.GetPK == nil || m.GetRelatedPK == nil || m.SetRelated == nil {
+		return fmt.Errorf("ManyToManyMapper is not fully configured")
+	}
+
+	var keys []PKT
+	for _, p := range parents {
+		keys = append(keys, m.GetPK(p))
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	pairs, err := m.JoinFetcher(ctx, keys)
+	if err != nil {
+		return fmt.Errorf("failed to fetch join rows for ManyToMany: %w", err)
+	}
+
+	relatedKeyMap := make(map[PKT]struct{})
+	var relatedKeys []PKT
+	for _, pair := range pairs {
+		if _, exists := relatedKeyMap[pair.RelatedKey]; !exists {
+			relatedKeyMap[pair.RelatedKey] = struct{}{}
+			relatedKeys = append(relatedKeys, pair.RelatedKey)
+		}
+	}
+
+	relatedMap := make(map[PKT]*RelatedT)
+	if len(relatedKeys) > 0 {
+		related, err := m.Fetcher(ctx, relatedKeys)
+		if err != nil {
+			return fmt.Errorf("failed to fetch related entities for ManyToMany: %w", err)
+		}
+		if err := processChildren(ctx, m.children, related); err != nil {
+			return err
+		}
+		for i := range related {
+			rel := &related[i]
+			relatedMap[m.GetRelatedPK(rel)] = rel
+		}
+	}
+
+	// Related models follow the order of the join rows; pairs pointing to missing models are skipped.
+	groupedRelated := make(map[PKT][]*RelatedT)
+	for _, pair := range pairs {
+		if rel, found := relatedMap[pair.RelatedKey]; found {
+			groupedRelated[pair.ParentKey] = append(groupedRelated[pair.ParentKey], rel)
+		}
+	}
+
+	for _, p := range parents {
+		pk := m.GetPK(p)
+		// Always set the slice, even if it's empty, to distinguish between nil (not loaded) and empty (no related items).
+		if rels, found := groupedRelated[pk]; found {
+			m.SetRelated(p, rels)
+		} else {
+			m.SetRelated(p, make([]*RelatedT, 0))
+		}
+	}
+	return nil
+}
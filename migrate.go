@@ -0,0 +1,76 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// AutoMigrate creates the repository's table from the record type's db tags if it does not exist yet.
+// Column types come from ColumnTyper; fields that can hold nil (pointers, slices, sql.Null* types)
+// are nullable, all others are NOT NULL, and an integer primary key is auto-incremented. Columns with a
+// transformer get the type of a string column. Other constraints (unique keys, foreign keys, indexes) are
+// not created.
+//
+// AutoMigrate is meant for prototypes and tests only: it never alters an existing table, so it is not a
+// replacement for versioned migrations in production.
+func (r *Repository[T]) AutoMigrate(ctx context.Context) error {
+	defs := make([]string, len(r.fields))
+	for i, f := range r.fields {
+		goType := f.fieldType
+		if f.transformer != nil {
+			goType = reflect.TypeFor[string]()
+		}
+		typ, err := columnType(r.dialect, goType)
+		if err != nil {
+			return err
+		}
+		def := r.quote(f.columnName) + " " + typ
+		switch {
+		case f.isPK && r.pkIsAutoIncrement && isIntegerKind(f.fieldType.Kind()):
+			if auto := autoIncrementSQL(r.dialect); auto != "" {
+				def += " " + auto
+			}
+			def += " PRIMARY KEY"
+		case f.isPK:
+			def += " NOT NULL PRIMARY KEY"
+		case !isNullableType(f.fieldType) && !f.readOnly && !f.auto:
+			def += " NOT NULL"
+		}
+		defs[i] = def
+	}
+
+	query := createTableSQL(r.dialect, r.quote(r.tableName), defs)
+	e, err := r.getExecutor(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := e.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create table %s: %w", r.tableName, err)
+	}
+	return nil
+}
+
+// columnGoType returns the type whose values are stored for a field of type t: pointers are dereferenced
+// and the database/sql null wrappers (sql.NullString, sql.Null[T], ...) are unwrapped to their value type.
+func columnGoType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if isSQLNullType(t) {
+		return t.Field(0).Type
+	}
+	return t
+}
+
+// isSQLNullType reports whether t is one of the database/sql null wrappers, whose first field holds the value.
+func isSQLNullType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.PkgPath() == "database/sql" && strings.HasPrefix(t.Name(), "Null") &&
+		t.NumField() == 2
+}
+
+// defaultCreateTableSQL generates CREATE TABLE IF NOT EXISTS with the given column definitions.
+func defaultCreateTableSQL(tableName string, columnDefs []string) string {
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", tableName, strings.Join(columnDefs, ", "))
+}
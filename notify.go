@@ -0,0 +1,98 @@
+package crud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// ChangeNotification is the JSON payload sent on the channel configured with WithChangeNotify.
+type ChangeNotification struct {
+	Table string `json:"table"`
+	Op    string `json:"op"`  // "insert", "update", "upsert" or "delete"
+	IDs   []any  `json:"ids"` // Primary keys of the affected records; null for UpdateWhere and DeleteWhere, which do not know them
+}
+
+// changeNotifier holds the channel and statement used to publish change notifications.
+type changeNotifier struct {
+	channel string
+	query   string // Resolved from the dialect by NewRepository
+}
+
+// WithChangeNotify publishes a ChangeNotification on the given channel after every successful write
+// (Create, CreateOrUpdate, Update, UpdateFields, UpdateWhere, Delete, DeleteWhere and DeleteWhereReturning), so that LISTEN-ers on other instances
+// can invalidate their caches. It is only supported on PostgreSQL, where the notification is sent with
+// pg_notify; NewRepository fails for other dialects. Within a transaction the notification is delivered
+// when the transaction commits. If publishing fails after the write succeeded, the write method returns
+// the error so the caller knows the caches may be stale. A notification payload must stay under 8000
+// bytes, so the IDs of a write affecting many records are spread over several notifications; a single
+// key too large to fit is sent with null IDs, as for UpdateWhere.
+func WithChangeNotify(channel string) RepositoryOption {
+	return func(c *repositoryConfig) {
+		if channel == "" {
+			c.notifier = nil
+			return
+		}
+		c.notifier = &changeNotifier{channel: channel}
+	}
+}
+
+// maxNotifyPayload is the largest payload pg_notify accepts with the default server configuration.
+const maxNotifyPayload = 7999
+
+// notifyChange publishes a change notification for the given primary keys, if configured.
+func (r *Repository[T]) notifyChange(ctx context.Context, op string, ids ...any) error {
+	if r.config.notifier == nil {
+		return nil
+	}
+	payloads, err := r.notificationPayloads(op, ids)
+	if err != nil {
+		return fmt.Errorf("failed to encode change notification: %w", err)
+	}
+	e, err := r.getExecutor(ctx)
+	if err != nil {
+		return fmt.Errorf("%s succeeded, but change notification failed: %w", op, err)
+	}
+	for _, payload := range payloads {
+		if _, err := e.ExecContext(ctx, r.config.notifier.query, r.config.notifier.channel, payload); err != nil {
+			return fmt.Errorf("%s succeeded, but change notification failed: %w", op, err)
+		}
+	}
+	return nil
+}
+
+// notificationPayloads encodes the notification of ids, splitting the ids in halves until every payload
+// fits in maxNotifyPayload.
+func (r *Repository[T]) notificationPayloads(op string, ids []any) ([]string, error) {
+	payload, err := json.Marshal(ChangeNotification{Table: r.tableName, Op: op, IDs: ids})
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) <= maxNotifyPayload {
+		return []string{string(payload)}, nil
+	}
+	if len(ids) <= 1 {
+		payload, err = json.Marshal(ChangeNotification{Table: r.tableName, Op: op})
+		return []string{string(payload)}, err
+	}
+	first, err := r.notificationPayloads(op, ids[:len(ids)/2])
+	if err != nil {
+		return nil, err
+	}
+	rest, err := r.notificationPayloads(op, ids[len(ids)/2:])
+	if err != nil {
+		return nil, err
+	}
+	return append(first, rest...), nil
+}
+
+// pkValues returns the primary key values of the given items.
+func (r *Repository[T]) pkValues(items []T) []any {
+	pk := r.fields[r.pkFieldPos()]
+	ids := make([]any, len(items))
+	for i, item := range items {
+		ids[i] = reflect.ValueOf(item).FieldByIndex(pk.index).Interface()
+	}
+	return ids
+}
@@ -2,8 +2,14 @@ package crud
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
 )
 
 // Option configures a query.
@@ -13,15 +19,30 @@ type Option[T any] interface {
 
 // queryBuilder is an internal helper to construct SQL queries and hold relation-loading info.
 type queryBuilder[T any] struct {
-	dialect        Dialect // Reference to the dialect for placeholder generation
-	whereClauses   []string
-	joinClauses    []string
-	orderByClauses []string
-	lockClause     string // For row-locking clauses like FOR UPDATE
-	limit          int
-	offset         int
-	args           []any
-	relations      []Relation[T] // Holds relationship loading configurations
+	dialect         Dialect     // Reference to the dialect for placeholder generation
+	fields          []fieldInfo // Field metadata of the repository's type T
+	tableName       string      // The repository's table
+	fromTable       string      // Overrides the FROM target (e.g., a partition); empty means tableName
+	softDelete      string      // Soft-delete column from WithSoftDelete; empty if disabled
+	withTrashed     bool        // Include soft-deleted rows (see WithTrashed)
+	whereClauses    []string
+	joinClauses     []string
+	orderByClauses  []string
+	lockClause      string        // For row-locking clauses like FOR UPDATE
+	lockMode        LockMode      // Typed row lock from LockForUpdate or LockForShare; 0 if unset
+	skipLocked      bool          // Skip rows locked by other transactions (see SkipLocked)
+	lockTimeout     time.Duration // Lock wait timeout set before the query (see WithLockTimeout)
+	sessionSettings []string      // SET LOCAL statements run before the query (see WithSessionSetting)
+	limit           int
+	fetchClause     string                  // Replaces LIMIT, e.g. FETCH FIRST n ROWS WITH TIES (see LimitWithTies)
+	columns         []string                // Selected columns (see Columns); empty selects every mapped column
+	aliases         []selectAlias           // Expressions selected into mapped columns (see SelectAs)
+	distinct        bool                    // Emit SELECT DISTINCT (see Distinct)
+	validator       func(expr string) error // From WithIdentifierValidator; nil if unset
+	offset          int
+	args            []any
+	relations       []Relation[T]  // Holds relationship loading configurations
+	readPreference  readPreference // Replica routing override for read queries
 }
 
 // Where adds a WHERE clause to the query. It is a flexible method that can handle
@@ -40,6 +61,11 @@ func Where[T any](args ...any) Option[T] {
 		return noOpOption[T]{}
 	}
 
+	// Dialect-native placeholders would not be renumbered and break the argument order.
+	if err := checkNativePlaceholders(clause); err != nil {
+		return errorOption[T]{err: err}
+	}
+
 	// Case 1: Raw query. Check for '?' as a heuristic.
 	if strings.Contains(clause, "?") {
 		return rawWhereOption[T]{clause: clause, args: args[1:]}
@@ -68,6 +94,15 @@ func (o noOpOption[T]) apply(_ *queryBuilder[T]) error {
 	return nil
 }
 
+// errorOption is an option that fails with a preconstructed error when applied.
+type errorOption[T any] struct {
+	err error
+}
+
+func (o errorOption[T]) apply(_ *queryBuilder[T]) error {
+	return o.err
+}
+
 // --- Simple Where Option (column = value) ---
 type simpleWhereOption[T any] struct {
 	column string
@@ -75,8 +110,10 @@ type simpleWhereOption[T any] struct {
 }
 
 func (o simpleWhereOption[T]) apply(qb *queryBuilder[T]) error {
-	qb.whereClauses = append(qb.whereClauses, fmt.Sprintf("%s = %s", o.column, qb.dialect.Placeholder(len(qb.args)+1)))
-	qb.args = append(qb.args, o.value)
+	if err := qb.checkExpr(o.column); err != nil {
+		return err
+	}
+	qb.whereClauses = append(qb.whereClauses, fmt.Sprintf("%s = %s", qb.quote(o.column), qb.bindValue(o.value)))
 	return nil
 }
 
@@ -88,26 +125,70 @@ type operatorWhereOption[T any] struct {
 }
 
 func (o operatorWhereOption[T]) apply(qb *queryBuilder[T]) error {
-	qb.whereClauses = append(qb.whereClauses, fmt.Sprintf("%s %s %s", o.column, o.operator, qb.dialect.Placeholder(len(qb.args)+1)))
-	qb.args = append(qb.args, o.value)
+	if err := qb.checkExpr(o.column, o.operator); err != nil {
+		return err
+	}
+	qb.whereClauses = append(qb.whereClauses, fmt.Sprintf("%s %s %s", qb.quote(o.column), o.operator, qb.bindValue(o.value)))
+	return nil
+}
+
+// --- Match Option (query by example) ---
+type matchOption[T any] struct {
+	example T
+}
+
+func (o matchOption[T]) apply(qb *queryBuilder[T]) error {
+	val := reflect.ValueOf(&o.example).Elem()
+	for _, f := range qb.fields {
+		fieldVal := val.FieldByIndex(f.index)
+		if fieldVal.IsZero() {
+			continue
+		}
+		value, err := encodeField(val, f)
+		if err != nil {
+			return err
+		}
+		qb.whereClauses = append(qb.whereClauses, fmt.Sprintf("%s = %s", qb.quote(f.columnName), qb.dialect.Placeholder(len(qb.args)+1)))
+		qb.args = append(qb.args, value)
+	}
 	return nil
 }
 
+// WhereMatch adds an equality condition for every non-zero `db`-tagged field of the example
+// ("query by example"), e.g. WhereMatch(User{Status: "active"}) -> WHERE status = ?.
+// The primary key is treated like any other field and is only matched when it is non-zero.
+// Zero values (0, "", false, nil) are always ignored, so they cannot be matched this way;
+// use pointer fields in the model, or an explicit Where, when a zero value must be matched exactly.
+func WhereMatch[T any](example T) Option[T] {
+	return matchOption[T]{example: example}
+}
+
 // --- In Option ---
 type inOption[T any] struct {
 	column string
 	values []any
+	not    bool // NOT IN (see WhereNotIn)
 }
 
 func (o inOption[T]) apply(qb *queryBuilder[T]) error {
 	if len(o.values) == 0 {
+		if o.not {
+			return fmt.Errorf("WhereNotIn option requires at least one value for column '%s'", o.column)
+		}
 		return fmt.Errorf("WhereIn option requires at least one value for column '%s'", o.column)
 	}
+	if err := qb.checkExpr(o.column); err != nil {
+		return err
+	}
 	placeholders := make([]string, len(o.values))
 	for i := range o.values {
 		placeholders[i] = qb.dialect.Placeholder(len(qb.args) + 1 + i)
 	}
-	qb.whereClauses = append(qb.whereClauses, fmt.Sprintf("%s IN (%s)", o.column, strings.Join(placeholders, ",")))
+	operator := "IN"
+	if o.not {
+		operator = "NOT IN"
+	}
+	qb.whereClauses = append(qb.whereClauses, fmt.Sprintf("%s %s (%s)", qb.quote(o.column), operator, strings.Join(placeholders, ",")))
 	qb.args = append(qb.args, o.values...)
 	return nil
 }
@@ -117,6 +198,74 @@ func WhereIn[T any](column string, values ...any) Option[T] {
 	return inOption[T]{column: column, values: values}
 }
 
+// WhereNotIn adds a WHERE NOT IN clause to the query, e.g. to exclude a set of ids.
+// Like WhereIn, it returns an error for an empty list of values.
+func WhereNotIn[T any](column string, values ...any) Option[T] {
+	return inOption[T]{column: column, values: values, not: true}
+}
+
+// WhereInOrAll adds a WHERE IN clause to the query, treating an empty list of values as "no filter".
+// Unlike WhereIn, which returns an error for an empty list, this is convenient when building
+// filters from optional lists.
+func WhereInOrAll[T any](column string, values ...any) Option[T] {
+	if len(values) == 0 {
+		return noOpOption[T]{}
+	}
+	return inOption[T]{column: column, values: values}
+}
+
+// whereInLargeThreshold is the number of values from which WhereInLarge stops emitting a plain IN list.
+const whereInLargeThreshold = 1000
+
+type inLargeOption[T any] struct {
+	column string
+	values []any
+}
+
+func (o inLargeOption[T]) apply(qb *queryBuilder[T]) error {
+	lister, ok := qb.dialect.(ValuesLister)
+	if !ok || len(o.values) < whereInLargeThreshold {
+		return inOption[T]{column: o.column, values: o.values}.apply(qb)
+	}
+	if err := qb.checkExpr(o.column); err != nil {
+		return err
+	}
+	rows := make([][]string, len(o.values))
+	for i := range o.values {
+		rows[i] = []string{qb.dialect.Placeholder(len(qb.args) + 1 + i)}
+	}
+	// Untyped parameters in a VALUES list are text, so the list is typed after the column's field, or after
+	// the first value for a column that is not mapped (e.g. of a joined table).
+	var types []string
+	name := strings.TrimPrefix(o.column, qb.tableName+".")
+	if i := slices.IndexFunc(qb.fields, func(f fieldInfo) bool { return f.columnName == name }); i >= 0 {
+		typ, err := valuesFieldType(lister, qb.fields[i])
+		if err != nil {
+			return err
+		}
+		types = []string{typ}
+	} else if o.values[0] != nil {
+		if typ, ok := lister.ValuesType(reflect.TypeOf(o.values[0])); ok {
+			types = []string{typ}
+		}
+	}
+	qb.whereClauses = append(qb.whereClauses, fmt.Sprintf("%s IN (SELECT v FROM %s)",
+		qb.quote(o.column), lister.ValuesSQL("large_in", []string{"v"}, types, rows)))
+	qb.args = append(qb.args, o.values...)
+	return nil
+}
+
+// WhereInLarge adds a WHERE IN condition for a large set of values, e.g. tens of thousands of keys.
+// On PostgreSQL, sets of at least 1000 values are matched against a VALUES list instead
+// (column IN (SELECT v FROM (VALUES ...))), which the planner executes as a hash semi-join rather than
+// evaluating a huge IN list. The list is cast to the type of the column's field, as with UpdateMany, so
+// uuid, enum or citext columns need a pgtype tag modifier. Smaller sets, and all sets on other dialects,
+// use a plain WhereIn. The values are still bound as parameters, so the set must fit within the dialect's
+// MaxParameters.
+func WhereInLarge[T any](column string, values []any) Option[T] {
+	return inLargeOption[T]{column: column, values: values}
+}
+
 // --- Like Option ---
 type likeOption[T any] struct {
 	column string
@@ -124,7 +273,10 @@ type likeOption[T any] struct {
 }
 
 func (o likeOption[T]) apply(qb *queryBuilder[T]) error {
-	qb.whereClauses = append(qb.whereClauses, fmt.Sprintf("%s LIKE %s", o.column, qb.dialect.Placeholder(len(qb.args)+1)))
+	if err := qb.checkExpr(o.column); err != nil {
+		return err
+	}
+	qb.whereClauses = append(qb.whereClauses, fmt.Sprintf("%s LIKE %s", qb.quote(o.column), qb.dialect.Placeholder(len(qb.args)+1)))
 	qb.args = append(qb.args, o.value)
 	return nil
 }
@@ -134,12 +286,127 @@ func WhereLike[T any](column string, value any) Option[T] {
 	return likeOption[T]{column: column, value: value}
 }
 
+// --- Time Range Option ---
+type timeBetweenOption[T any] struct {
+	column   string
+	from, to time.Time
+}
+
+func (o timeBetweenOption[T]) apply(qb *queryBuilder[T]) error {
+	if err := qb.checkExpr(o.column); err != nil {
+		return err
+	}
+	if o.to.Before(o.from) {
+		return fmt.Errorf("WhereTimeBetween option requires from <= to for column '%s'", o.column)
+	}
+	qb.whereClauses = append(qb.whereClauses, fmt.Sprintf("%s >= %s AND %s < %s",
+		qb.quote(o.column), qb.dialect.Placeholder(len(qb.args)+1), qb.quote(o.column), qb.dialect.Placeholder(len(qb.args)+2)))
+	qb.args = append(qb.args, o.from.UTC(), o.to.UTC())
+	return nil
+}
+
+// WhereTimeBetween adds a half-open time range condition, column >= from AND column < to, for columns stored in UTC.
+// Both bounds are converted to UTC before binding, so they can be given in any time zone (e.g., the user's).
+// The end is exclusive so that adjacent ranges (e.g., consecutive days) never count a boundary row twice:
+// pass midnight of the following day as to rather than 23:59:59.
+func WhereTimeBetween[T any](column string, from, to time.Time) Option[T] {
+	return timeBetweenOption[T]{column: column, from: from, to: to}
+}
+
+// --- Current Timestamp Option ---
+type nowOption[T any] struct {
+	column   string
+	operator string
+}
+
+func (o nowOption[T]) apply(qb *queryBuilder[T]) error {
+	if err := qb.checkExpr(o.column); err != nil {
+		return err
+	}
+	switch o.operator {
+	case "=", "<>", "!=", "<", "<=", ">", ">=":
+	default:
+		return fmt.Errorf("WhereColumnOpNow option does not support operator '%s'", o.operator)
+	}
+	qb.whereClauses = append(qb.whereClauses, fmt.Sprintf("%s %s %s", qb.quote(o.column), o.operator, currentTimestampSQL(qb.dialect)))
+	return nil
+}
+
+// WhereColumnOpNow compares a column with the database clock, e.g. WhereColumnOpNow("expires_at", ">") for
+// rows that have not expired yet. The dialect's current-timestamp function is emitted in place of a bound
+// value, so the comparison never depends on the application server's clock.
+func WhereColumnOpNow[T any](column, operator string) Option[T] {
+	return nowOption[T]{column: column, operator: operator}
+}
+
+// --- Null Options ---
+type nullOption[T any] struct {
+	column string
+	not    bool
+}
+
+func (o nullOption[T]) apply(qb *queryBuilder[T]) error {
+	if err := qb.checkExpr(o.column); err != nil {
+		return err
+	}
+	if o.not {
+		qb.whereClauses = append(qb.whereClauses, fmt.Sprintf("%s IS NOT %s", qb.quote(o.column), nullLiteral(qb.dialect)))
+	} else {
+		qb.whereClauses = append(qb.whereClauses, fmt.Sprintf("%s IS %s", qb.quote(o.column), nullLiteral(qb.dialect)))
+	}
+	return nil
+}
+
+// WhereNull adds a WHERE column IS NULL clause to the query.
+// Use it instead of Where(column, nil), which compares with = and never matches NULL.
+func WhereNull[T any](column string) Option[T] {
+	return nullOption[T]{column: column}
+}
+
+// WhereNotNull adds a WHERE column IS NOT NULL clause to the query.
+func WhereNotNull[T any](column string) Option[T] {
+	return nullOption[T]{column: column, not: true}
+}
+
+// --- JSON Containment Option ---
+type jsonContainsOption[T any] struct {
+	column   string
+	fragment any
+}
+
+func (o jsonContainsOption[T]) apply(qb *queryBuilder[T]) error {
+	if err := qb.checkExpr(o.column); err != nil {
+		return err
+	}
+	data, err := json.Marshal(o.fragment)
+	if err != nil {
+		return fmt.Errorf("WhereJSONContains failed to marshal the fragment for column '%s': %w", o.column, err)
+	}
+	clause, err := jsonContainsSQL(qb.dialect, qb.quote(o.column), qb.dialect.Placeholder(len(qb.args)+1))
+	if err != nil {
+		return err
+	}
+	qb.whereClauses = append(qb.whereClauses, clause)
+	qb.args = append(qb.args, string(data))
+	return nil
+}
+
+// WhereJSONContains matches records whose JSON column contains the given fragment, marshaled to JSON,
+// e.g. WhereJSONContains("data", map[string]any{"tags": []string{"go"}}). PostgreSQL emits
+// column @> ?::jsonb and MySQL JSON_CONTAINS(column, ?); SQLite returns an error wrapping errors.ErrUnsupported.
+func WhereJSONContains[T any](column string, fragment any) Option[T] {
+	return jsonContainsOption[T]{column: column, fragment: fragment}
+}
+
 // --- Lock Option ---
 type lockOption[T any] struct {
 	clause string
 }
 
 func (o lockOption[T]) apply(qb *queryBuilder[T]) error {
+	if err := qb.checkExpr(o.clause); err != nil {
+		return err
+	}
 	qb.lockClause = o.clause
 	return nil
 }
@@ -150,6 +417,133 @@ func Lock[T any](clause string) Option[T] {
 	return lockOption[T]{clause: clause}
 }
 
+// LockMode is the strength of a row lock taken with LockForUpdate or LockForShare.
+type LockMode int
+
+const (
+	// LockModeUpdate locks the selected rows against concurrent updates, deletes and locks.
+	LockModeUpdate LockMode = iota + 1
+	// LockModeShare locks the selected rows against concurrent updates and deletes, but lets other
+	// transactions take shared locks too.
+	LockModeShare
+)
+
+type rowLockOption[T any] struct {
+	mode       LockMode
+	skipLocked bool
+}
+
+func (o rowLockOption[T]) apply(qb *queryBuilder[T]) error {
+	if o.mode != 0 {
+		qb.lockMode = o.mode
+	}
+	qb.skipLocked = qb.skipLocked || o.skipLocked
+	if qb.lockMode == 0 {
+		return nil
+	}
+	clause, err := rowLockSQL(qb.dialect, qb.lockMode, qb.skipLocked)
+	if err != nil {
+		return err
+	}
+	qb.lockClause = clause
+	return nil
+}
+
+// LockForUpdate locks the selected rows for the rest of the transaction with the dialect's exclusive row
+// lock (FOR UPDATE, or the UPDLOCK table hint on SQL Server). SQLite locks the whole database instead of
+// rows, so the option is a no-op there. Like Lock, it should only be used within a transaction.
+func LockForUpdate[T any]() Option[T] {
+	return rowLockOption[T]{mode: LockModeUpdate}
+}
+
+// LockForShare is like LockForUpdate with a shared lock (FOR SHARE), which still lets other transactions
+// read and share-lock the rows.
+func LockForShare[T any]() Option[T] {
+	return rowLockOption[T]{mode: LockModeShare}
+}
+
+// SkipLocked makes the lock of LockForUpdate or LockForShare skip rows already locked by other transactions
+// instead of waiting for them (SKIP LOCKED, or READPAST on SQL Server), e.g. for job queues where each
+// worker claims different rows. It can be given before or after the lock option and has no effect without one.
+func SkipLocked[T any]() Option[T] {
+	return rowLockOption[T]{skipLocked: true}
+}
+
+// --- Lock Timeout Option ---
+type lockTimeoutOption[T any] struct {
+	timeout time.Duration
+}
+
+func (o lockTimeoutOption[T]) apply(qb *queryBuilder[T]) error {
+	if o.timeout <= 0 {
+		return fmt.Errorf("WithLockTimeout option requires a positive duration, got %s", o.timeout)
+	}
+	qb.lockTimeout = o.timeout
+	return nil
+}
+
+// WithLockTimeout bounds how long a locking query (see Lock) waits for row locks held by other transactions,
+// so it fails fast instead of blocking. Before the query, the dialect's lock timeout is set on the transaction:
+// SET LOCAL lock_timeout on PostgreSQL (scoped to the transaction), and SET SESSION innodb_lock_wait_timeout
+// on MySQL (whole seconds, rounded up; it is reset to the server default once the query has run).
+// It is a no-op on SQLite, which has no row locks. The query fails with ErrTxRequired outside a transaction.
+func WithLockTimeout[T any](d time.Duration) Option[T] {
+	return lockTimeoutOption[T]{timeout: d}
+}
+
+// --- Session Setting Option ---
+
+// allowedSessionSettings lists the planner and resource settings WithSessionSetting may change.
+var allowedSessionSettings = map[string]bool{
+	"enable_bitmapscan":    true,
+	"enable_hashagg":       true,
+	"enable_hashjoin":      true,
+	"enable_indexonlyscan": true,
+	"enable_indexscan":     true,
+	"enable_material":      true,
+	"enable_mergejoin":     true,
+	"enable_nestloop":      true,
+	"enable_seqscan":       true,
+	"enable_sort":          true,
+	"jit":                  true,
+	"random_page_cost":     true,
+	"seq_page_cost":        true,
+	"statement_timeout":    true,
+	"work_mem":             true,
+}
+
+// sessionSettingValuePattern restricts setting values to plain words and numbers with units (on, 4.0, 64MB),
+// since they are inserted into the SQL text unparameterized.
+var sessionSettingValuePattern = regexp.MustCompile(`^[A-Za-z0-9_.]+$`)
+
+type sessionSettingOption[T any] struct {
+	setting string
+	value   string
+}
+
+func (o sessionSettingOption[T]) apply(qb *queryBuilder[T]) error {
+	if !allowedSessionSettings[o.setting] {
+		return fmt.Errorf("WithSessionSetting does not allow the setting '%s'", o.setting)
+	}
+	if !sessionSettingValuePattern.MatchString(o.value) {
+		return fmt.Errorf("invalid value '%s' for setting '%s' in WithSessionSetting", o.value, o.setting)
+	}
+	stmt, err := sessionSettingSQL(qb.dialect, o.setting, o.value)
+	if err != nil {
+		return err
+	}
+	qb.sessionSettings = append(qb.sessionSettings, stmt)
+	return nil
+}
+
+// WithSessionSetting changes a PostgreSQL setting for the rest of the transaction before the query runs,
+// e.g. WithSessionSetting("enable_seqscan", "off") to force an index scan while investigating a plan.
+// Only planner and resource settings from a fixed allow list are accepted. The query fails with
+// ErrTxRequired outside a transaction; other dialects return an error wrapping errors.ErrUnsupported.
+func WithSessionSetting[T any](setting, value string) Option[T] {
+	return sessionSettingOption[T]{setting: setting, value: value}
+}
+
 // --- Sort Option ---
 type sortOption[T any] struct {
 	column    string
@@ -157,7 +551,10 @@ type sortOption[T any] struct {
 }
 
 func (o sortOption[T]) apply(qb *queryBuilder[T]) error {
-	qb.orderByClauses = append(qb.orderByClauses, fmt.Sprintf("%s %s", o.column, o.direction))
+	if err := qb.checkExpr(o.column); err != nil {
+		return err
+	}
+	qb.orderByClauses = append(qb.orderByClauses, fmt.Sprintf("%s %s", qb.quote(o.column), o.direction))
 	return nil
 }
 
@@ -166,6 +563,197 @@ func OrderBy[T any](column string, direction SortDirection) Option[T] {
 	return sortOption[T]{column: column, direction: direction}
 }
 
+// OrderBySpec parses a client-supplied, comma-separated sort specification such as "name,-created_at"
+// into OrderBy options. A leading '-' sorts the field in descending order (an optional '+' means ascending).
+// Every field must be a key of allowed, which maps the public field name to the column to sort by;
+// this keeps untrusted input out of the SQL text. An empty spec yields no options.
+func OrderBySpec[T any](spec string, allowed map[string]string) ([]Option[T], error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(spec, ",")
+	opts := make([]Option[T], 0, len(parts))
+	for _, part := range parts {
+		field := strings.TrimSpace(part)
+		direction := SortAsc
+		switch {
+		case strings.HasPrefix(field, "-"):
+			direction = SortDesc
+			field = field[1:]
+		case strings.HasPrefix(field, "+"):
+			field = field[1:]
+		}
+		if field == "" {
+			return nil, fmt.Errorf("empty field in sort specification '%s'", spec)
+		}
+
+		column, ok := allowed[field]
+		if !ok {
+			return nil, fmt.Errorf("sorting by '%s' is not allowed", field)
+		}
+		opts = append(opts, OrderBy[T](column, direction))
+	}
+	return opts, nil
+}
+
+// --- Collated Sort Option ---
+type collateSortOption[T any] struct {
+	column    string
+	collation string
+	direction SortDirection
+}
+
+// collationNamePattern restricts collation names to a safe character set, since they are
+// inserted into the SQL text unparameterized.
+var collationNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.@-]+$`)
+
+func (o collateSortOption[T]) apply(qb *queryBuilder[T]) error {
+	if err := qb.checkExpr(o.column); err != nil {
+		return err
+	}
+	if !collationNamePattern.MatchString(o.collation) {
+		return fmt.Errorf("invalid collation name '%s' in OrderByCollate", o.collation)
+	}
+	qb.orderByClauses = append(qb.orderByClauses, fmt.Sprintf("%s %s %s", qb.quote(o.column), collateSQL(qb.dialect, o.collation), o.direction))
+	return nil
+}
+
+// OrderByCollate adds an ORDER BY clause that sorts the column using the given collation,
+// e.g. OrderByCollate("name", "NOCASE", SortAsc) -> ORDER BY name COLLATE NOCASE ASC on SQLite.
+// The collation syntax is provided by the dialect (PostgreSQL quotes the name, MySQL and SQLite do not).
+func OrderByCollate[T any](column string, collation string, direction SortDirection) Option[T] {
+	return collateSortOption[T]{column: column, collation: collation, direction: direction}
+}
+
+// from returns the FROM target of the query.
+func (qb *queryBuilder[T]) from() string {
+	if qb.fromTable != "" {
+		return qb.fromTable
+	}
+	return qb.quote(qb.tableName)
+}
+
+// bindValue returns the SQL for the right-hand side of a comparison with value: a bool is rendered as the
+// dialect's boolean literal, so the comparison matches how the dialect stores booleans, and any other value
+// is appended to the arguments and bound to the next placeholder.
+func (qb *queryBuilder[T]) bindValue(value any) string {
+	if b, ok := value.(bool); ok {
+		return boolLiteral(qb.dialect, b)
+	}
+	qb.args = append(qb.args, value)
+	return qb.dialect.Placeholder(len(qb.args))
+}
+
+// quote quotes name if it is a reserved word (see quoteIdent).
+func (qb *queryBuilder[T]) quote(name string) string {
+	return quoteIdent(qb.dialect, name)
+}
+
+// checkExpr runs SQL fragments that are inserted verbatim through the WithIdentifierValidator validator.
+func (qb *queryBuilder[T]) checkExpr(exprs ...string) error {
+	if qb.validator == nil {
+		return nil
+	}
+	for _, expr := range exprs {
+		if err := qb.validator(expr); err != nil {
+			return fmt.Errorf("SQL fragment '%s' rejected: %w", expr, err)
+		}
+	}
+	return nil
+}
+
+// selectColumns returns the columns chosen with Columns, or all if none were chosen.
+// Columns given with SelectAs are always included.
+func (qb *queryBuilder[T]) selectColumns(all []string) []string {
+	cols := all
+	if len(qb.columns) > 0 {
+		cols = qb.columns
+	}
+	for _, a := range qb.aliases {
+		if !slices.Contains(cols, a.alias) {
+			cols = append(slices.Clip(cols), a.alias)
+		}
+	}
+	return cols
+}
+
+// selectExpr returns the SELECT expression for col: the SelectAs expression aliased to col, if one was
+// given, or plain otherwise.
+func (qb *queryBuilder[T]) selectExpr(col, plain string) string {
+	for _, a := range qb.aliases {
+		if a.alias == col {
+			return a.expr + " AS " + qb.quote(col)
+		}
+	}
+	return plain
+}
+
+// whereSQL returns the combined WHERE conditions of the query, without the WHERE keyword.
+// Unless WithTrashed was applied, soft-deleted rows are excluded.
+func (qb *queryBuilder[T]) whereSQL() string {
+	where := strings.Join(qb.whereClauses, " AND ")
+	if qb.softDelete == "" || qb.withTrashed {
+		return where
+	}
+	notDeleted := fmt.Sprintf("%s IS NULL", qb.quote(qb.tableName+"."+qb.softDelete))
+	if where == "" {
+		return notDeleted
+	}
+	return fmt.Sprintf("(%s) AND %s", where, notDeleted)
+}
+
+// --- Soft Delete Options ---
+type withTrashedOption[T any] struct{}
+
+func (o withTrashedOption[T]) apply(qb *queryBuilder[T]) error {
+	qb.withTrashed = true
+	return nil
+}
+
+// WithTrashed includes soft-deleted rows in the query of a repository created with WithSoftDelete.
+// It has no effect on other repositories.
+func WithTrashed[T any]() Option[T] {
+	return withTrashedOption[T]{}
+}
+
+// --- Partition Option ---
+type partitionOption[T any] struct {
+	partition string
+}
+
+// partitionNamePattern restricts partition names to plain identifiers, since they are
+// inserted into the SQL text unparameterized.
+var partitionNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*$`)
+
+func (o partitionOption[T]) apply(qb *queryBuilder[T]) error {
+	if !partitionNamePattern.MatchString(o.partition) {
+		return fmt.Errorf("invalid partition name '%s' in WithPartition", o.partition)
+	}
+	from, err := partitionTableSQL(qb.dialect, qb.quote(qb.tableName), o.partition)
+	if err != nil {
+		return err
+	}
+	qb.fromTable = from
+	return nil
+}
+
+// limitSQL returns the clause that follows OFFSET in place of LIMIT when LimitWithTies is used.
+func (qb *queryBuilder[T]) limitSQL() string {
+	if qb.fetchClause == "" {
+		return ""
+	}
+	return " " + qb.fetchClause
+}
+
+// WithPartition restricts the query to the named partition of a partitioned table.
+// On MySQL this emits "FROM table PARTITION (name)"; on PostgreSQL the partition child table is
+// queried directly under the parent's name ("FROM name AS table"), so joins and qualified columns keep working.
+// Dialects without partition support return an error wrapping errors.ErrUnsupported.
+func WithPartition[T any](partitionName string) Option[T] {
+	return partitionOption[T]{partition: partitionName}
+}
+
 // --- Limit Option ---
 type limitOption[T any] struct {
 	limit int
@@ -173,6 +761,7 @@ type limitOption[T any] struct {
 
 func (o limitOption[T]) apply(qb *queryBuilder[T]) error {
 	qb.limit = o.limit
+	qb.fetchClause = ""
 	return nil
 }
 
@@ -181,6 +770,32 @@ func Limit[T any](limit int) Option[T] {
 	return limitOption[T]{limit: limit}
 }
 
+// --- Limit With Ties Option ---
+type limitWithTiesOption[T any] struct {
+	limit int
+}
+
+func (o limitWithTiesOption[T]) apply(qb *queryBuilder[T]) error {
+	if o.limit <= 0 {
+		return fmt.Errorf("LimitWithTies option requires n > 0, got %d", o.limit)
+	}
+	clause, err := limitWithTiesSQL(qb.dialect, o.limit)
+	if err != nil {
+		return err
+	}
+	qb.limit = 0
+	qb.fetchClause = clause
+	return nil
+}
+
+// LimitWithTies limits the query to the first n rows plus any further rows that tie with the nth one
+// on the ORDER BY columns, which makes "top N" reports correct when values repeat. The query must have
+// an OrderBy. On PostgreSQL this emits FETCH FIRST n ROWS WITH TIES; dialects without support (MySQL,
+// SQLite) return an error wrapping errors.ErrUnsupported. A later Limit or WithPage replaces it.
+func LimitWithTies[T any](n int) Option[T] {
+	return limitWithTiesOption[T]{limit: n}
+}
+
 // --- Offset Option ---
 type offsetOption[T any] struct {
 	offset int
@@ -196,12 +811,40 @@ func Offset[T any](offset int) Option[T] {
 	return offsetOption[T]{offset: offset}
 }
 
+// --- Page Option ---
+type pageOption[T any] struct {
+	page int
+	size int
+}
+
+func (o pageOption[T]) apply(qb *queryBuilder[T]) error {
+	if o.page < 1 {
+		return fmt.Errorf("WithPage option requires page >= 1, got %d", o.page)
+	}
+	if o.size <= 0 {
+		return fmt.Errorf("WithPage option requires size > 0, got %d", o.size)
+	}
+	qb.limit = o.size
+	qb.fetchClause = ""
+	qb.offset = (o.page - 1) * o.size
+	return nil
+}
+
+// WithPage applies LIMIT and OFFSET for the given 1-based page number and page size.
+// For example, WithPage(3, 20) is equivalent to Limit(20) and Offset(40).
+func WithPage[T any](page, size int) Option[T] {
+	return pageOption[T]{page: page, size: size}
+}
+
 // --- Join Option ---
 type joinOption[T any] struct {
 	joinClause string
 }
 
 func (o joinOption[T]) apply(qb *queryBuilder[T]) error {
+	if err := qb.checkExpr(o.joinClause); err != nil {
+		return err
+	}
 	qb.joinClauses = append(qb.joinClauses, o.joinClause)
 	return nil
 }
@@ -212,6 +855,50 @@ func Join[T any](joinClause string) Option[T] {
 	return joinOption[T]{joinClause: joinClause}
 }
 
+// --- Typed Join Options ---
+type typedJoinOption[T any] struct {
+	kind  string
+	table string
+	on    string
+}
+
+func (o typedJoinOption[T]) apply(qb *queryBuilder[T]) error {
+	if err := qb.checkExpr(o.table, o.on); err != nil {
+		return err
+	}
+	if !supportsJoinType(qb.dialect, o.kind) {
+		return fmt.Errorf("%s JOIN is not supported by %T: %w", o.kind, qb.dialect, errors.ErrUnsupported)
+	}
+	keyword := o.kind + " JOIN"
+	if o.kind == JoinFull {
+		keyword = "FULL OUTER JOIN"
+	}
+	qb.joinClauses = append(qb.joinClauses, fmt.Sprintf("%s %s ON %s", keyword, qb.quote(o.table), o.on))
+	return nil
+}
+
+// InnerJoin adds an INNER JOIN clause, e.g. InnerJoin("roles", "roles.id = users.role_id").
+func InnerJoin[T any](table, on string) Option[T] {
+	return typedJoinOption[T]{kind: JoinInner, table: table, on: on}
+}
+
+// LeftJoin adds a LEFT JOIN clause.
+func LeftJoin[T any](table, on string) Option[T] {
+	return typedJoinOption[T]{kind: JoinLeft, table: table, on: on}
+}
+
+// RightJoin adds a RIGHT JOIN clause. It returns an error on dialects that do not support it. SQLiteDialect
+// accepts it, but the SQLite library must be 3.39.0 or newer to run it.
+func RightJoin[T any](table, on string) Option[T] {
+	return typedJoinOption[T]{kind: JoinRight, table: table, on: on}
+}
+
+// FullJoin adds a FULL OUTER JOIN clause. It returns an error on dialects that do not support it (e.g., MySQL).
+// SQLiteDialect accepts it, but the SQLite library must be 3.39.0 or newer to run it.
+func FullJoin[T any](table, on string) Option[T] {
+	return typedJoinOption[T]{kind: JoinFull, table: table, on: on}
+}
+
 // --- Subquery Option ---
 type subqueryOption[T any] struct {
 	column   string
@@ -221,12 +908,23 @@ type subqueryOption[T any] struct {
 }
 
 func (o subqueryOption[T]) apply(qb *queryBuilder[T]) error {
-	qb.whereClauses = append(qb.whereClauses, fmt.Sprintf("%s %s (%s)", o.column, o.operator, o.subquery))
+	if err := qb.checkExpr(o.column, o.operator, o.subquery); err != nil {
+		return err
+	}
+	if err := checkNativePlaceholders(o.subquery); err != nil {
+		return err
+	}
+	subquery, err := bindPlaceholders(qb, o.subquery, o.args)
+	if err != nil {
+		return err
+	}
+	qb.whereClauses = append(qb.whereClauses, fmt.Sprintf("%s %s (%s)", qb.quote(o.column), o.operator, subquery))
 	qb.args = append(qb.args, o.args...)
 	return nil
 }
 
 // WhereSubquery adds a subquery clause (e.g., "id IN (SELECT user_id FROM ...)").
+// Placeholders in the subquery must be written as '?' and are rewritten for the dialect.
 func WhereSubquery[T any](column, operator, subquery string, args ...any) Option[T] {
 	return subqueryOption[T]{column: column, operator: operator, subquery: subquery, args: args}
 }
@@ -238,12 +936,28 @@ type rawWhereOption[T any] struct {
 }
 
 func (o rawWhereOption[T]) apply(qb *queryBuilder[T]) error {
+	if err := qb.checkExpr(o.clause); err != nil {
+		return err
+	}
+	finalClause, err := bindPlaceholders(qb, o.clause, o.args)
+	if err != nil {
+		return err
+	}
+
+	qb.whereClauses = append(qb.whereClauses, finalClause)
+	qb.args = append(qb.args, o.args...)
+	return nil
+}
+
+// bindPlaceholders rewrites each '?' in clause to the dialect's placeholder, numbered after the
+// arguments already in qb. It fails if the number of placeholders does not match len(args).
+func bindPlaceholders[T any](qb *queryBuilder[T], clause string, args []any) (string, error) {
 	// The number of arguments *before* this clause is added
 	argStartIndex := len(qb.args)
 
 	finalClause := ""
 	argCounterForThisClause := 0
-	for _, char := range o.clause {
+	for _, char := range clause {
 		if char == '?' {
 			// Use the global argument index
 			globalArgIndex := argStartIndex + argCounterForThisClause
@@ -254,15 +968,298 @@ func (o rawWhereOption[T]) apply(qb *queryBuilder[T]) error {
 		}
 	}
 
-	if argCounterForThisClause != len(o.args) {
-		return fmt.Errorf("mismatched number of placeholders (?) and arguments in Where clause: '%s'", o.clause)
+	if argCounterForThisClause != len(args) {
+		return "", fmt.Errorf("mismatched number of placeholders (?) and arguments in Where clause: '%s'", clause)
 	}
+	return finalClause, nil
+}
 
-	qb.whereClauses = append(qb.whereClauses, finalClause)
-	qb.args = append(qb.args, o.args...)
+var (
+	// sqlStringLiteralPattern matches single-quoted SQL string literals, which may contain placeholder-like text.
+	sqlStringLiteralPattern = regexp.MustCompile(`'(?:[^']|'')*'`)
+	// nativePlaceholderPattern matches PostgreSQL ($1), named (:name) and SQL Server (@p1) placeholders.
+	// A "::" cast is not mistaken for a named placeholder.
+	nativePlaceholderPattern = regexp.MustCompile(`(\$\d+)|(?:^|[^:\w])(:[A-Za-z_]\w*)|(@p\d+)\b`)
+)
+
+// checkNativePlaceholders returns an error if a raw clause contains dialect-native placeholder syntax.
+func checkNativePlaceholders(clause string) error {
+	stripped := sqlStringLiteralPattern.ReplaceAllString(clause, "''")
+	groups := nativePlaceholderPattern.FindStringSubmatch(stripped)
+	if groups == nil {
+		return nil
+	}
+	for _, match := range groups[1:] {
+		if match != "" {
+			return fmt.Errorf("raw clause '%s' contains the dialect-specific placeholder '%s'; always use '?', which is rewritten for the dialect",
+				clause, match)
+		}
+	}
 	return nil
 }
 
+// --- Read Routing Options ---
+
+// readPreference selects which connection a read query is sent to when a read replica is configured.
+type readPreference int
+
+const (
+	readDefault readPreference = iota
+	readFromPrimary
+	readFromReplica
+)
+
+type readPreferenceOption[T any] struct {
+	preference readPreference
+}
+
+func (o readPreferenceOption[T]) apply(qb *queryBuilder[T]) error {
+	qb.readPreference = o.preference
+	return nil
+}
+
+// PreferPrimary forces a read query to use the primary connection (or the repository's transaction)
+// even when a read replica is configured, e.g. for reads that must see the caller's own writes.
+func PreferPrimary[T any]() Option[T] {
+	return readPreferenceOption[T]{preference: readFromPrimary}
+}
+
+// PreferReplica sends a read query to the configured read replica, even from a transactional
+// repository, for reads that are known to tolerate replication lag. Without a replica it has no effect.
+func PreferReplica[T any]() Option[T] {
+	return readPreferenceOption[T]{preference: readFromReplica}
+}
+
+// --- Condition Group Options ---
+
+// applyGroup applies opts to a child queryBuilder and returns the WHERE clauses and arguments they produced.
+// The child starts with a copy of the parent's arguments so that placeholder numbering (e.g., $N on
+// PostgreSQL) continues from the parent and stays globally sequential when the group is merged back.
+// Only WHERE conditions are collected; other settings made by the child options are ignored.
+func applyGroup[T any](qb *queryBuilder[T], opts []Option[T]) ([]string, []any, error) {
+	child := &queryBuilder[T]{
+		dialect:   qb.dialect,
+		fields:    qb.fields,
+		validator: qb.validator,
+		args:      append([]any(nil), qb.args...),
+	}
+	for _, opt := range opts {
+		if err := opt.apply(child); err != nil {
+			return nil, nil, err
+		}
+	}
+	return child.whereClauses, child.args[len(qb.args):], nil
+}
+
+type notOption[T any] struct {
+	opts []Option[T]
+}
+
+func (o notOption[T]) apply(qb *queryBuilder[T]) error {
+	clauses, args, err := applyGroup(qb, o.opts)
+	if err != nil {
+		return err
+	}
+	if len(clauses) == 0 {
+		return nil
+	}
+	qb.whereClauses = append(qb.whereClauses, fmt.Sprintf("NOT (%s)", strings.Join(clauses, " AND ")))
+	qb.args = append(qb.args, args...)
+	return nil
+}
+
+// WhereNot negates the combined conditions of the given options,
+// e.g. WhereNot(Where("status", "banned"), Where("role", "guest")) -> NOT (status = ? AND role = ?).
+func WhereNot[T any](opts ...Option[T]) Option[T] {
+	return notOption[T]{opts: opts}
+}
+
+type orOption[T any] struct {
+	opts []Option[T]
+}
+
+func (o orOption[T]) apply(qb *queryBuilder[T]) error {
+	// Options are applied one at a time so that each becomes one OR term; the scratch builder carries the
+	// arguments of the previous terms to keep placeholder numbering sequential.
+	scratch := &queryBuilder[T]{dialect: qb.dialect, fields: qb.fields, validator: qb.validator, args: qb.args}
+	terms := make([]string, 0, len(o.opts))
+	for _, opt := range o.opts {
+		clauses, args, err := applyGroup(scratch, []Option[T]{opt})
+		if err != nil {
+			return err
+		}
+		if len(clauses) == 0 {
+			continue
+		}
+		term := strings.Join(clauses, " AND ")
+		if upper := strings.ToUpper(term); !isParenthesized(term) && (strings.Contains(upper, " AND ") || strings.Contains(upper, " OR ")) {
+			term = "(" + term + ")"
+		}
+		terms = append(terms, term)
+		scratch.args = append(scratch.args[:len(scratch.args):len(scratch.args)], args...)
+	}
+	if len(terms) == 0 {
+		return nil
+	}
+	qb.whereClauses = append(qb.whereClauses, "("+strings.Join(terms, " OR ")+")")
+	qb.args = append(qb.args, scratch.args[len(qb.args):]...)
+	return nil
+}
+
+// Or combines the conditions of the given options with OR, e.g. Or(Where("a", 1), Where("b", 2)) ->
+// (a = ? OR b = ?). Each option forms one term; an option producing several conditions (or a compound
+// condition) is parenthesized as a whole. The group is ANDed with the other conditions of the query.
+func Or[T any](opts ...Option[T]) Option[T] {
+	return orOption[T]{opts: opts}
+}
+
+type andOption[T any] struct {
+	opts []Option[T]
+}
+
+func (o andOption[T]) apply(qb *queryBuilder[T]) error {
+	clauses, args, err := applyGroup(qb, o.opts)
+	if err != nil {
+		return err
+	}
+	if len(clauses) == 0 {
+		return nil
+	}
+	group := strings.Join(clauses, " AND ")
+	if len(clauses) > 1 || !isParenthesized(group) {
+		group = "(" + group + ")"
+	}
+	qb.whereClauses = append(qb.whereClauses, group)
+	qb.args = append(qb.args, args...)
+	return nil
+}
+
+// And combines the conditions of the given options into one parenthesized AND group, for nesting inside Or,
+// e.g. Or(And(Where("a", 1), Or(Where("b", 2), Where("c", 3))), Where("d", 4)) ->
+// ((a = ? AND (b = ? OR c = ?)) OR d = ?). Placeholders stay sequential across any depth of nesting.
+func And[T any](opts ...Option[T]) Option[T] {
+	return andOption[T]{opts: opts}
+}
+
+// isParenthesized reports whether clause is entirely enclosed in one pair of parentheses, e.g. "(a OR b)"
+// but not "(a) OR (b)".
+func isParenthesized(clause string) bool {
+	if !strings.HasPrefix(clause, "(") || !strings.HasSuffix(clause, ")") {
+		return false
+	}
+	depth := 0
+	for i, c := range clause {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 && i < len(clause)-1 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
+
+// --- Columns Option ---
+type columnsOption[T any] struct {
+	columns []string
+}
+
+func (o columnsOption[T]) apply(qb *queryBuilder[T]) error {
+	if len(o.columns) == 0 {
+		return fmt.Errorf("Columns option requires at least one column")
+	}
+	for _, col := range o.columns {
+		if !slices.ContainsFunc(qb.fields, func(f fieldInfo) bool { return f.columnName == col }) {
+			return fmt.Errorf("unknown column '%s' for table %s in Columns", col, qb.tableName)
+		}
+	}
+	qb.columns = o.columns
+	return nil
+}
+
+// Columns restricts the SELECT to the given columns, which must match db tags of T, to reduce I/O on wide
+// tables. The fields of unselected columns are left at their zero values, so relations loaded with
+// WithRelation need their key columns selected. A later Columns option replaces earlier ones.
+func Columns[T any](cols ...string) Option[T] {
+	return columnsOption[T]{columns: cols}
+}
+
+// selectAlias is an expression selected into the mapped column alias.
+type selectAlias struct {
+	expr  string
+	alias string
+}
+
+type selectAsOption[T any] struct {
+	selectAlias
+}
+
+func (o selectAsOption[T]) apply(qb *queryBuilder[T]) error {
+	if !slices.ContainsFunc(qb.fields, func(f fieldInfo) bool { return f.columnName == o.alias }) {
+		return fmt.Errorf("unknown column '%s' for table %s in SelectAs", o.alias, qb.tableName)
+	}
+	if err := qb.checkExpr(o.expr); err != nil {
+		return err
+	}
+	qb.aliases = slices.DeleteFunc(slices.Clone(qb.aliases), func(a selectAlias) bool { return a.alias == o.alias })
+	qb.aliases = append(qb.aliases, o.selectAlias)
+	return nil
+}
+
+// SelectAs selects expr AS alias in place of the mapped column alias, which must match a db tag of T, so
+// that the field is scanned from expr. This resolves name collisions in joins, e.g.
+// SelectAs("users.name", "author_name") with a LeftJoin on users, and lets fields that are not columns of
+// the table be filled from joined tables or computed expressions. A later SelectAs for the same alias
+// replaces the earlier one; with Columns, aliased columns are selected even when not listed.
+func SelectAs[T any](expr, alias string) Option[T] {
+	return selectAsOption[T]{selectAlias{expr: expr, alias: alias}}
+}
+
+// --- Distinct Option ---
+type distinctOption[T any] struct{}
+
+func (o distinctOption[T]) apply(qb *queryBuilder[T]) error {
+	qb.distinct = true
+	return nil
+}
+
+// Distinct makes the query return only distinct rows (SELECT DISTINCT), e.g. to collapse the duplicate
+// parent rows produced by a join to a one-to-many table. Combined with Columns only the selected columns
+// are compared. Count and Paginate count the distinct rows.
+func Distinct[T any]() Option[T] {
+	return distinctOption[T]{}
+}
+
+// --- Scope Options ---
+
+// Scope is a named, reusable set of query options, e.g. an "active users" filter.
+type Scope[T any] func() []Option[T]
+
+type scopeOption[T any] struct {
+	scope Scope[T]
+}
+
+func (o scopeOption[T]) apply(qb *queryBuilder[T]) error {
+	if o.scope == nil {
+		return nil
+	}
+	for _, opt := range o.scope() {
+		if err := opt.apply(qb); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyScope expands the scope's options into the query, e.g. List(ctx, ApplyScope(activeUsers), Limit[User](10)).
+// Scopes can be combined with each other and with regular options; a nil scope is a no-op.
+func ApplyScope[T any](scope Scope[T]) Option[T] {
+	return scopeOption[T]{scope: scope}
+}
+
 // --- Eager Loading Options ---
 
 // RelatedFetcher is a function type that fetches related entities for a given set of parent keys.
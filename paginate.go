@@ -0,0 +1,245 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// PageResult holds one page of records together with the pagination metadata.
+type PageResult[T any] struct {
+	Items      []T
+	Total      int64 // Total number of records matching the filters, across all pages
+	Page       int   // 1-based page number
+	PerPage    int
+	TotalPages int
+}
+
+// Paginate returns the given 1-based page of records matching opts, along with the total number of
+// matching records. On PostgreSQL the total is obtained in the same query with COUNT(*) OVER() (see
+// WindowCounter); other dialects run a separate COUNT query. Limit and Offset options are overridden by page and perPage.
+func (r *Repository[T]) Paginate(ctx context.Context, page, perPage int, opts ...Option[T]) (PageResult[T], error) {
+	qb, err := r.applyOptions(append(opts[:len(opts):len(opts)], WithPage[T](page, perPage)))
+	if err != nil {
+		return PageResult[T]{}, err
+	}
+
+	var items []T
+	var total int64
+	countKnown := false
+
+	// COUNT(*) OVER() is computed before DISTINCT removes duplicates, so distinct queries count separately
+	if supportsWindowCount(r.dialect) && !qb.distinct {
+		restore, err := r.applyTxSettings(ctx, qb)
+		if err != nil {
+			return PageResult[T]{}, err
+		}
+		defer restore()
+		e, err := r.getReadExecutor(ctx, qb)
+		if err != nil {
+			return PageResult[T]{}, err
+		}
+		qctx, cancel := r.queryContext(ctx)
+		defer cancel()
+		rows, err := e.QueryContext(qctx, r.buildSelect(qb, "COUNT(*) OVER() AS total_count"), qb.args...)
+		if err != nil {
+			return PageResult[T]{}, err
+		}
+		items, err = r.scanRowsInto(rows, nil, r.config.maxRows, &total)
+		if err != nil {
+			return PageResult[T]{}, err
+		}
+		if err := r.loadRelations(ctx, qb, items); err != nil {
+			return PageResult[T]{}, err
+		}
+		// The window count is only available when the page contains at least one row.
+		countKnown = len(items) > 0
+	} else {
+		items, err = r.list(ctx, qb)
+		if err != nil {
+			return PageResult[T]{}, err
+		}
+	}
+
+	if !countKnown {
+		if total, err = r.count(ctx, qb); err != nil {
+			return PageResult[T]{}, err
+		}
+	}
+
+	if items == nil {
+		items = []T{}
+	}
+	return PageResult[T]{
+		Items:      items,
+		Total:      total,
+		Page:       page,
+		PerPage:    perPage,
+		TotalPages: int((total + int64(perPage) - 1) / int64(perPage)),
+	}, nil
+}
+
+// DefaultPerPage is the page size ListByQuery uses when ListQuery.PerPage is zero.
+const DefaultPerPage = 20
+
+// ListQuery bundles the filters, sort order and pagination of a list request, e.g. as decoded by an
+// HTTP handler, so that it can be run with a single ListByQuery call.
+type ListQuery[T any] struct {
+	Filters []Option[T] // Conditions such as Where, WhereIn or Join
+	Sort    []Option[T] // Ordering options such as OrderBy
+	Page    int         // 1-based page number; zero means the first page
+	PerPage int         // Page size; zero means DefaultPerPage
+}
+
+// ListByQuery runs Paginate with the filters and sort order of q, applied in that order.
+func (r *Repository[T]) ListByQuery(ctx context.Context, q ListQuery[T]) (PageResult[T], error) {
+	page, perPage := q.Page, q.PerPage
+	if page == 0 {
+		page = 1
+	}
+	if perPage == 0 {
+		perPage = DefaultPerPage
+	}
+
+	opts := make([]Option[T], 0, len(q.Filters)+len(q.Sort))
+	opts = append(opts, q.Filters...)
+	opts = append(opts, q.Sort...)
+	return r.Paginate(ctx, page, perPage, opts...)
+}
+
+// Count returns the number of records matching the options. Only joins, WHERE conditions and Distinct
+// (with its Columns) are taken into account; ordering, limits, offsets and relations are ignored.
+func (r *Repository[T]) Count(ctx context.Context, opts ...Option[T]) (int64, error) {
+	qb, err := r.applyOptions(opts)
+	if err != nil {
+		return 0, err
+	}
+	return r.count(ctx, qb)
+}
+
+// Exists reports whether at least one record matches the options, using SELECT EXISTS(...) so the
+// database can stop at the first match. Only joins and WHERE conditions are taken into account.
+func (r *Repository[T]) Exists(ctx context.Context, opts ...Option[T]) (bool, error) {
+	qb, err := r.applyOptions(opts)
+	if err != nil {
+		return false, err
+	}
+
+	subquery := r.dialect.SelectSQL(
+		qb.from(),
+		[]string{"1"},
+		strings.Join(qb.joinClauses, " "),
+		qb.whereSQL(),
+		"", "", 1, 0,
+	)
+
+	restore, err := r.applyTxSettings(ctx, qb)
+	if err != nil {
+		return false, err
+	}
+	defer restore()
+	e, err := r.getReadExecutor(ctx, qb)
+	if err != nil {
+		return false, err
+	}
+	var exists bool
+	qctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	if err := e.QueryRowContext(qctx, "SELECT EXISTS("+subquery+")", qb.args...).Scan(&exists); err != nil {
+		return false, fmt.Errorf("exists check failed: %w", err)
+	}
+	return exists, nil
+}
+
+// count returns the number of records matching the joins and WHERE conditions of qb, or the number of
+// distinct rows with Distinct. Ordering, limits, locking and relations are ignored.
+func (r *Repository[T]) count(ctx context.Context, qb *queryBuilder[T]) (int64, error) {
+	cols := []string{"COUNT(*)"}
+	if qb.distinct {
+		cols = r.selectList(qb)
+	}
+	query := r.dialect.SelectSQL(
+		qb.from(),
+		cols,
+		strings.Join(qb.joinClauses, " "),
+		qb.whereSQL(),
+		"", "", 0, 0,
+	)
+	if qb.distinct {
+		query = "SELECT COUNT(*) FROM (" + query + ") AS distinct_rows"
+	}
+
+	restore, err := r.applyTxSettings(ctx, qb)
+	if err != nil {
+		return 0, err
+	}
+	defer restore()
+	e, err := r.getReadExecutor(ctx, qb)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	qctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	if err := e.QueryRowContext(qctx, query, qb.args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("count failed: %w", err)
+	}
+	return total, nil
+}
+
+// ListAfter returns up to limit records whose cursorColumn is greater than cursorValue, ordered by
+// cursorColumn ascending (keyset pagination). Pass the cursor column's value of the last record of a page
+// to fetch the next one, or a nil cursorValue to fetch the first page. Additional options filter the
+// results as usual; their ordering applies after the cursor column, and Limit and Offset are overridden.
+//
+// The cursor column must be unique and NOT NULL, otherwise rows can be skipped or repeated between pages:
+// the "column > ?" predicate never matches NULL, while NULLs still sort among the ordered rows (first or
+// last depending on the database). ListAfter therefore rejects columns mapped to nullable Go types
+// (pointers and sql.Null* types).
+func (r *Repository[T]) ListAfter(ctx context.Context, cursorColumn string, cursorValue any, limit int, opts ...Option[T]) ([]T, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("ListAfter requires limit > 0, got %d", limit)
+	}
+
+	var cursorField *fieldInfo
+	for i := range r.fields {
+		if r.fields[i].columnName == cursorColumn {
+			cursorField = &r.fields[i]
+			break
+		}
+	}
+	if cursorField == nil {
+		return nil, fmt.Errorf("unknown cursor column '%s' for table %s", cursorColumn, r.tableName)
+	}
+	if isNullableType(cursorField.fieldType) {
+		return nil, fmt.Errorf("cursor column '%s' is nullable (%s); keyset pagination requires a NOT NULL column",
+			cursorColumn, cursorField.fieldType)
+	}
+
+	qb, err := r.applyOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	column := r.qualify(cursorColumn)
+	if cursorValue != nil {
+		qb.whereClauses = append(qb.whereClauses, fmt.Sprintf("%s > %s", column, r.dialect.Placeholder(len(qb.args)+1)))
+		qb.args = append(qb.args, cursorValue)
+	}
+	qb.orderByClauses = append([]string{fmt.Sprintf("%s %s", column, SortAsc)}, qb.orderByClauses...)
+	qb.limit = limit
+	qb.offset = 0
+
+	return r.list(ctx, qb)
+}
+
+// isNullableType reports whether a field of type t can hold NULL: pointers, interfaces, slices, maps and the
+// database/sql Null types (sql.NullString, sql.Null[T], ...).
+func isNullableType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+		return true
+	}
+	return t.PkgPath() == "database/sql" && strings.HasPrefix(t.Name(), "Null")
+}
@@ -1,9 +1,13 @@
 package crud
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // PostgresDialect implements Dialect for PostgreSQL.
@@ -23,6 +27,11 @@ func (d PostgresDialect) InsertSQL(tableName string, cols, placeholders []string
 	)
 }
 
+// BulkInsertSQL generates the multi-row INSERT statement for PostgreSQL.
+func (d PostgresDialect) BulkInsertSQL(tableName string, cols []string, rows [][]string) string {
+	return DefaultBulkInsertSQL(tableName, cols, rows)
+}
+
 // UpdateSQL generates the UPDATE statement for PostgreSQL.
 func (d PostgresDialect) UpdateSQL(tableName string, setClauses string, pkColumn string, pkPlaceholder string) string {
 	return fmt.Sprintf("UPDATE %s SET %s WHERE %s = %s", tableName, setClauses, pkColumn, pkPlaceholder)
@@ -35,27 +44,255 @@ func (d PostgresDialect) SelectSQL(
 	return DefaultSelectSQL(tableName, cols, joins, whereClause, orderByClause, lockClause, limit, offset)
 }
 
+// OrderLimitSQL returns the ORDER BY, LIMIT and OFFSET clauses; OFFSET may stand alone.
+func (d PostgresDialect) OrderLimitSQL(orderByClause string, limit, offset int) string {
+	return orderLimitTail(orderByClause, limit, offset, "")
+}
+
 // DeleteSQL generates the DELETE statement for PostgreSQL.
 func (d PostgresDialect) DeleteSQL(tableName string, pkColumn string, pkPlaceholder string) string {
 	return fmt.Sprintf("DELETE FROM %s WHERE %s = %s", tableName, pkColumn, pkPlaceholder)
 }
 
-// UpsertSQL generates the INSERT ... ON CONFLICT statement for PostgreSQL.
+// UpsertSQL generates the INSERT ... ON CONFLICT statement for PostgreSQL, overwriting every column but the primary key.
 func (d PostgresDialect) UpsertSQL(tableName string, pkColumn string, cols []string) string {
+	return d.UpsertColumnsSQL(tableName, pkColumn, cols, nonKeyColumns(cols, pkColumn))
+}
+
+// UpsertColumnsSQL generates the INSERT ... ON CONFLICT statement for PostgreSQL, overwriting only updateCols.
+// With no updateCols an existing row is left untouched.
+func (d PostgresDialect) UpsertColumnsSQL(tableName string, pkColumn string, cols, updateCols []string) string {
 	placeholders := make([]string, len(cols))
-	updateClauses := make([]string, 0, len(cols))
-	for i, col := range cols {
+	for i := range cols {
 		placeholders[i] = d.Placeholder(i + 1)
-		if col != pkColumn {
-			updateClauses = append(updateClauses, fmt.Sprintf("%s = EXCLUDED.%s", col, col))
-		}
+	}
+	updateClauses := make([]string, 0, len(updateCols))
+	for _, col := range updateCols {
+		updateClauses = append(updateClauses, fmt.Sprintf("%s = EXCLUDED.%s", col, col))
 	}
 
-	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) DO UPDATE SET %s",
+	action := "DO NOTHING"
+	if len(updateClauses) > 0 {
+		action = "DO UPDATE SET " + strings.Join(updateClauses, ", ")
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) %s",
 		tableName,
 		strings.Join(cols, ", "),
 		strings.Join(placeholders, ", "),
 		pkColumn,
-		strings.Join(updateClauses, ", "),
+		action,
 	)
 }
+
+// Collate returns the COLLATE clause for PostgreSQL, quoting the collation name (e.g., COLLATE "en_US").
+func (d PostgresDialect) Collate(collation string) string {
+	return `COLLATE "` + collation + `"`
+}
+
+// SupportsJoinType reports whether PostgreSQL supports the join kind. All standard joins are supported.
+func (d PostgresDialect) SupportsJoinType(kind string) bool {
+	switch strings.ToUpper(kind) {
+	case JoinInner, JoinLeft, JoinRight, JoinFull, JoinCross:
+		return true
+	}
+	return false
+}
+
+// PartitionTable returns the FROM target for a PostgreSQL partition. Partitions are ordinary child
+// tables, so the partition is queried directly and aliased to the parent's name (e.g., users_2024 AS users).
+func (d PostgresDialect) PartitionTable(tableName, partition string) (string, error) {
+	return fmt.Sprintf("%s AS %s", partition, tableName), nil
+}
+
+// NotifySQL returns the statement that publishes a notification, taking the channel and payload as arguments.
+// pg_notify is used instead of NOTIFY because NOTIFY does not accept bind parameters.
+func (d PostgresDialect) NotifySQL() (string, error) {
+	return "SELECT pg_notify($1, $2)", nil
+}
+
+// LockTimeoutSQL returns the statement that bounds lock waits for the rest of the current transaction.
+func (d PostgresDialect) LockTimeoutSQL(timeout time.Duration) (string, error) {
+	return fmt.Sprintf("SET LOCAL lock_timeout = '%dms'", max(timeout.Milliseconds(), 1)), nil
+}
+
+// LimitWithTiesSQL returns the FETCH clause that keeps the rows tied with the nth one.
+func (d PostgresDialect) LimitWithTiesSQL(n int) (string, error) {
+	return fmt.Sprintf("FETCH FIRST %d ROWS WITH TIES", n), nil
+}
+
+// SessionSettingSQL returns the statement that changes a setting for the rest of the current transaction.
+func (d PostgresDialect) SessionSettingSQL(setting, value string) (string, error) {
+	return fmt.Sprintf("SET LOCAL %s = '%s'", setting, value), nil
+}
+
+// JSONContainsSQL returns a jsonb containment condition, which can use a GIN index on the column.
+func (d PostgresDialect) JSONContainsSQL(column, placeholder string) (string, error) {
+	return fmt.Sprintf("%s @> %s::jsonb", column, placeholder), nil
+}
+
+// MaxParameters returns the number of bind parameters the PostgreSQL wire protocol allows per statement.
+func (d PostgresDialect) MaxParameters() int {
+	return 65535
+}
+
+// RowLockSQL returns the FOR UPDATE or FOR SHARE clause, with SKIP LOCKED if requested.
+func (d PostgresDialect) RowLockSQL(mode LockMode, skipLocked bool) (string, error) {
+	return forLockSQL(mode, skipLocked)
+}
+
+// QuoteIdentifier quotes each segment of name with double quotes (users.order -> "users"."order").
+// Quoted names are case-sensitive in PostgreSQL.
+func (d PostgresDialect) QuoteIdentifier(name string) string {
+	return quoteSegments(name, `"`, `"`)
+}
+
+// CurrentTimestampSQL returns the start time of the current transaction, with time zone.
+func (d PostgresDialect) CurrentTimestampSQL() string {
+	return "CURRENT_TIMESTAMP"
+}
+
+// ColumnType returns the PostgreSQL column type for values of goType, as used for UpdateMany casts.
+// Unknown types (e.g., maps or structs) map to text.
+func (d PostgresDialect) ColumnType(goType reflect.Type) string {
+	if pgType, ok := postgresTypeFor(columnGoType(goType)); ok {
+		return pgType
+	}
+	return "text"
+}
+
+// AutoIncrementSQL returns the identity clause of an auto-incremented primary key.
+func (d PostgresDialect) AutoIncrementSQL() string {
+	return "GENERATED BY DEFAULT AS IDENTITY"
+}
+
+// CreateTableSQL generates CREATE TABLE IF NOT EXISTS with the given column definitions.
+func (d PostgresDialect) CreateTableSQL(tableName string, columnDefs []string) string {
+	return defaultCreateTableSQL(tableName, columnDefs)
+}
+
+// TrueLiteral returns TRUE.
+func (d PostgresDialect) TrueLiteral() string {
+	return "TRUE"
+}
+
+// FalseLiteral returns FALSE.
+func (d PostgresDialect) FalseLiteral() string {
+	return "FALSE"
+}
+
+// NullLiteral returns NULL.
+func (d PostgresDialect) NullLiteral() string {
+	return "NULL"
+}
+
+// SupportsReturning returns true: inserted rows are always read back with RETURNING.
+func (d PostgresDialect) SupportsReturning() bool {
+	return true
+}
+
+// InsertReturningSQL appends a RETURNING clause to the INSERT statement.
+func (d PostgresDialect) InsertReturningSQL(insertSQL string, returningCols []string) string {
+	return defaultInsertReturningSQL(insertSQL, returningCols)
+}
+
+// SupportsDeleteReturning returns true: deleted rows are read back with DELETE ... RETURNING.
+func (d PostgresDialect) SupportsDeleteReturning() bool {
+	return true
+}
+
+// DeleteReturningSQL appends a RETURNING clause to the DELETE statement.
+func (d PostgresDialect) DeleteReturningSQL(deleteSQL string, returningCols []string) string {
+	return defaultInsertReturningSQL(deleteSQL, returningCols)
+}
+
+// SupportsWindowCount returns true: Paginate counts the matching records with COUNT(*) OVER().
+func (d PostgresDialect) SupportsWindowCount() bool {
+	return true
+}
+
+// postgresUniqueViolationCode is the SQLSTATE of unique_violation.
+const postgresUniqueViolationCode = "23505"
+
+// postgresUniqueRe extracts the constraint name from the message of a unique violation.
+var postgresUniqueRe = regexp.MustCompile(`duplicate key value violates unique constraint "([^"]+)"`)
+
+// IsUniqueViolation reports whether err is a unique_violation (SQLSTATE 23505) and returns the constraint
+// name. lib/pq errors are inspected through their Get accessor and pgx errors through SQLState, so neither
+// driver needs to be imported; other drivers are recognized by the server's message.
+func (d PostgresDialect) IsUniqueViolation(err error) (string, bool) {
+	var pqErr interface{ Get(k byte) string }
+	if errors.As(err, &pqErr) {
+		if pqErr.Get('C') != postgresUniqueViolationCode {
+			return "", false
+		}
+		return pqErr.Get('n'), true
+	}
+	var stateErr interface{ SQLState() string }
+	if errors.As(err, &stateErr) && stateErr.SQLState() != postgresUniqueViolationCode {
+		return "", false
+	}
+	m := postgresUniqueRe.FindStringSubmatch(err.Error())
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// ValuesSQL builds a typed VALUES list usable as a table in FROM or JOIN:
+// (VALUES ($1::bigint, $2::text), ($3, $4)) AS alias(col1, col2).
+// PostgreSQL infers the column types of a VALUES list from its first row and treats untyped parameters
+// as text, so the first row carries an explicit cast for every column with a non-empty type.
+func (d PostgresDialect) ValuesSQL(alias string, cols, types []string, rows [][]string) string {
+	values := make([]string, len(rows))
+	for i, placeholders := range rows {
+		if i == 0 {
+			cast := make([]string, len(placeholders))
+			for j, p := range placeholders {
+				cast[j] = p
+				if j < len(types) && types[j] != "" {
+					cast[j] = p + "::" + types[j]
+				}
+			}
+			placeholders = cast
+		}
+		values[i] = "(" + strings.Join(placeholders, ", ") + ")"
+	}
+	return fmt.Sprintf("(VALUES %s) AS %s(%s)", strings.Join(values, ", "), alias, strings.Join(cols, ", "))
+}
+
+// ValuesType returns the PostgreSQL type a parameter of Go type t is cast to in a VALUES list.
+func (d PostgresDialect) ValuesType(t reflect.Type) (string, bool) {
+	return postgresTypeFor(t)
+}
+
+// postgresTypeFor returns the PostgreSQL type a parameter of Go type t is cast to in a VALUES list,
+// or false if it cannot be inferred.
+func postgresTypeFor(t reflect.Type) (string, bool) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == reflect.TypeFor[time.Time]() {
+		return "timestamptz", true
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean", true
+	case reflect.Int8, reflect.Int16, reflect.Uint8:
+		return "smallint", true
+	case reflect.Int32, reflect.Uint16:
+		return "integer", true
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return "bigint", true
+	case reflect.Float32:
+		return "real", true
+	case reflect.Float64:
+		return "double precision", true
+	case reflect.String:
+		return "text", true
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "bytea", true
+		}
+	}
+	return "", false
+}
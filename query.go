@@ -0,0 +1,149 @@
+package crud
+
+import (
+	"context"
+	"slices"
+)
+
+// Query is a fluent wrapper around the option-based API, for callers who prefer chaining:
+//
+//	users, err := userRepo.Query().
+//	    Where("role", "admin").
+//	    WhereGt("age", 21).
+//	    OrderBy("username", crud.SortAsc).
+//	    Limit(10).
+//	    List(ctx)
+//
+// Each method appends the corresponding Option[T] and the terminal methods pass the collected options to
+// the repository, so a chain behaves exactly like the equivalent variadic call. Queries are immutable:
+// every method returns a new Query, so a partially built query can be reused as a base for several others.
+type Query[T any] struct {
+	repo RepositoryInterface[T]
+	opts []Option[T]
+}
+
+// Query starts a fluent query on the repository.
+func (r *Repository[T]) Query() *Query[T] {
+	return &Query[T]{repo: r}
+}
+
+// With appends arbitrary options, e.g. scopes or options that have no fluent counterpart.
+func (q *Query[T]) With(opts ...Option[T]) *Query[T] {
+	// Clip forces append to copy, so that queries derived from the same base do not share options.
+	return &Query[T]{repo: q.repo, opts: append(slices.Clip(q.opts), opts...)}
+}
+
+// Options returns the collected options, e.g. to pass them to a method the builder does not wrap.
+func (q *Query[T]) Options() []Option[T] {
+	return slices.Clone(q.opts)
+}
+
+// Where adds a condition; it accepts the same arguments as the Where option.
+func (q *Query[T]) Where(args ...any) *Query[T] {
+	return q.With(Where[T](args...))
+}
+
+// WhereGt adds a column > value condition.
+func (q *Query[T]) WhereGt(column string, value any) *Query[T] {
+	return q.With(Where[T](column, ">", value))
+}
+
+// WhereGte adds a column >= value condition.
+func (q *Query[T]) WhereGte(column string, value any) *Query[T] {
+	return q.With(Where[T](column, ">=", value))
+}
+
+// WhereLt adds a column < value condition.
+func (q *Query[T]) WhereLt(column string, value any) *Query[T] {
+	return q.With(Where[T](column, "<", value))
+}
+
+// WhereLte adds a column <= value condition.
+func (q *Query[T]) WhereLte(column string, value any) *Query[T] {
+	return q.With(Where[T](column, "<=", value))
+}
+
+// WhereIn adds a column IN (...) condition.
+func (q *Query[T]) WhereIn(column string, values ...any) *Query[T] {
+	return q.With(WhereIn[T](column, values...))
+}
+
+// WhereNotIn adds a column NOT IN (...) condition.
+func (q *Query[T]) WhereNotIn(column string, values ...any) *Query[T] {
+	return q.With(WhereNotIn[T](column, values...))
+}
+
+// WhereLike adds a column LIKE value condition.
+func (q *Query[T]) WhereLike(column string, value any) *Query[T] {
+	return q.With(WhereLike[T](column, value))
+}
+
+// WhereNull adds a column IS NULL condition.
+func (q *Query[T]) WhereNull(column string) *Query[T] {
+	return q.With(WhereNull[T](column))
+}
+
+// WhereNotNull adds a column IS NOT NULL condition.
+func (q *Query[T]) WhereNotNull(column string) *Query[T] {
+	return q.With(WhereNotNull[T](column))
+}
+
+// OrderBy adds a sort column.
+func (q *Query[T]) OrderBy(column string, direction SortDirection) *Query[T] {
+	return q.With(OrderBy[T](column, direction))
+}
+
+// Limit sets the maximum number of rows returned.
+func (q *Query[T]) Limit(limit int) *Query[T] {
+	return q.With(Limit[T](limit))
+}
+
+// Offset sets the number of rows to skip.
+func (q *Query[T]) Offset(offset int) *Query[T] {
+	return q.With(Offset[T](offset))
+}
+
+// Columns limits the selected columns.
+func (q *Query[T]) Columns(cols ...string) *Query[T] {
+	return q.With(Columns[T](cols...))
+}
+
+// Distinct selects distinct rows only.
+func (q *Query[T]) Distinct() *Query[T] {
+	return q.With(Distinct[T]())
+}
+
+// WithRelation eager-loads a relation.
+func (q *Query[T]) WithRelation(mapper Relation[T]) *Query[T] {
+	return q.With(WithRelation[T](mapper))
+}
+
+// List executes the query and returns the matching records.
+func (q *Query[T]) List(ctx context.Context) ([]T, error) {
+	return q.repo.List(ctx, q.opts...)
+}
+
+// First executes the query and returns the first matching record, or ErrNotFound.
+func (q *Query[T]) First(ctx context.Context) (T, error) {
+	return q.repo.First(ctx, q.opts...)
+}
+
+// Count returns the number of matching records.
+func (q *Query[T]) Count(ctx context.Context) (int64, error) {
+	return q.repo.Count(ctx, q.opts...)
+}
+
+// Exists reports whether any record matches.
+func (q *Query[T]) Exists(ctx context.Context) (bool, error) {
+	return q.repo.Exists(ctx, q.opts...)
+}
+
+// Paginate returns one page of the matching records.
+func (q *Query[T]) Paginate(ctx context.Context, page, perPage int) (PageResult[T], error) {
+	return q.repo.Paginate(ctx, page, perPage, q.opts...)
+}
+
+// ToSQL returns the SELECT statement and arguments List would execute.
+func (q *Query[T]) ToSQL() (string, []any, error) {
+	return q.repo.ToSQL(q.opts...)
+}
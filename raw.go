@@ -0,0 +1,137 @@
+package crud
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// RawInto runs a fully custom query through the repository's connection (or its transaction) and scans each
+// row into an R, matching the returned column names to R's `db` tags. Use it when the columns do not match T,
+// e.g. for joins or aggregates. The query and its placeholders are sent as written, so they must use the
+// dialect's native placeholder syntax. Every returned column must have a matching tag.
+func RawInto[T any, R any](ctx context.Context, repo RepositoryInterface[T], query string, args []any) ([]R, error) {
+	r, err := baseOf(repo)
+	if err != nil {
+		return nil, err
+	}
+	e, err := r.getExecutor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	rows, err := e.QueryContext(qctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanInto[R](rows)
+}
+
+// ListAs runs the List query described by opts with selectExprs appended to the selected columns and scans
+// each row into an R by column name, like RawInto. R typically embeds T and adds fields for the extra
+// columns, so that computed or joined values travel with the entity:
+//
+//	type UserWithPosts struct {
+//		User
+//		PostCount int `db:"post_count"`
+//	}
+//	users, err := ListAs[User, UserWithPosts](ctx, userRepo,
+//		[]string{"(SELECT COUNT(*) FROM posts WHERE posts.user_id = users.id) AS post_count"})
+//
+// The expressions are inserted verbatim, so they must not contain untrusted input. Eager loading with With is
+// not supported, and column transformers and enum validation are not applied, since R is scanned directly.
+func ListAs[T any, R any](ctx context.Context, repo RepositoryInterface[T], selectExprs []string, opts ...Option[T]) ([]R, error) {
+	r, err := baseOf(repo)
+	if err != nil {
+		return nil, err
+	}
+	qb, err := r.applyOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(qb.relations) > 0 {
+		return nil, fmt.Errorf("ListAs does not support eager loading relations")
+	}
+	if err := qb.checkExpr(selectExprs...); err != nil {
+		return nil, err
+	}
+
+	query := r.buildSelect(qb, selectExprs...)
+	restore, err := r.applyTxSettings(ctx, qb)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+	e, err := r.getReadExecutor(ctx, qb)
+	if err != nil {
+		return nil, err
+	}
+	qctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	rows, err := e.QueryContext(qctx, query, qb.args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanInto[R](rows)
+}
+
+// scanInto scans all remaining rows into values of the struct type R by column name and closes the rows.
+func scanInto[R any](rows *sql.Rows) ([]R, error) {
+	defer rows.Close()
+
+	resultType := reflect.TypeFor[R]()
+	if resultType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("generic type R must be a struct, but got %s", resultType.Kind())
+	}
+	resultFields, err := parseFields(resultType, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	// Drivers may return column names in another case than the tags (e.g., PostgreSQL folds unquoted
+	// identifiers to lower case), so names also match case-insensitively, preferring an exact match.
+	resultIndex := make(map[string][]int, len(resultFields))
+	foldedIndex := make(map[string][]int, len(resultFields))
+	for _, f := range resultFields {
+		resultIndex[f.columnName] = f.index
+		if _, exists := foldedIndex[strings.ToLower(f.columnName)]; !exists {
+			foldedIndex[strings.ToLower(f.columnName)] = f.index
+		}
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	indexes := make([][]int, len(columns))
+	for i, col := range columns {
+		index, ok := resultIndex[col]
+		if !ok {
+			index, ok = foldedIndex[strings.ToLower(col)]
+		}
+		if !ok {
+			return nil, fmt.Errorf("column '%s' has no matching db tag in %s", col, resultType)
+		}
+		indexes[i] = index
+	}
+
+	results := []R{}
+	for rows.Next() {
+		var result R
+		val := reflect.ValueOf(&result).Elem()
+		dest := make([]any, len(columns))
+		for i, index := range indexes {
+			dest[i] = val.FieldByIndex(index).Addr().Interface()
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
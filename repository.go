@@ -4,8 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math"
 	"reflect"
+	"slices"
 	"strings"
+	"time"
 )
 
 // executor defines the common methods between *sql.DB and *sql.Tx.
@@ -19,27 +22,251 @@ type Repository[T any] struct {
 	db                *sql.DB
 	tx                *sql.Tx // Transaction object
 	tableName         string
-	columns           []string       // List of database column names
-	pkColumn          string         // Database column name of the primary key
-	pkIsAutoIncrement bool           // Flag if the primary key is an auto-incrementing integer
-	scanMap           map[string]int // Map of column name to field index for scanning
+	columns           []string         // List of database column names
+	pkColumn          string           // Database column name of the primary key
+	pkIsAutoIncrement bool             // Flag if the primary key is an auto-incrementing integer
+	scanMap           map[string][]int // Map of column name to field index path for scanning
 	dialect           Dialect
 	fields            []fieldInfo // Cached information about struct fields
-}
-
-// fieldInfo caches metadata about a struct field.
-type fieldInfo struct {
-	columnName string
-	fieldIndex int
-	isPK       bool
+	config            repositoryConfig
+	timestamps        *timestampFields // Columns managed by WithTimestamps, if configured
+	versionField      int              // Position in fields of the WithVersionColumn column; -1 if disabled
+	generated         bool             // *T implements GeneratedModel, used instead of reflection
+	reflectedArgs     []int            // Positions in fields whose generated values are bound with driverArg
 }
 
 // getExecutor returns the correct executor (transaction or database connection).
-func (r *Repository[T]) getExecutor() executor {
+func (r *Repository[T]) getExecutor(ctx context.Context) (executor, error) {
 	if r.tx != nil {
-		return r.tx
+		return r.instrument(r.tx), nil
+	}
+	db, err := r.primary(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.instrument(db), nil
+}
+
+// primary returns the primary database connection for a call, resolved from ctx if the repository was
+// created with WithConnectionResolver.
+func (r *Repository[T]) primary(ctx context.Context) (*sql.DB, error) {
+	if r.config.resolver == nil {
+		return r.db, nil
+	}
+	db, err := r.config.resolver(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve database connection: %w", err)
+	}
+	if db == nil {
+		return nil, fmt.Errorf("connection resolver returned no database connection")
+	}
+	return db, nil
+}
+
+// beginTx begins a transaction on the primary connection for the call.
+func (r *Repository[T]) beginTx(ctx context.Context) (*sql.Tx, error) {
+	db, err := r.primary(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// getReadExecutor returns the executor for a read query, taking replica routing into account.
+// By default reads inside a transaction use the transaction, and other reads use the read replica
+// if one is configured. PreferPrimary and PreferReplica override this per query.
+func (r *Repository[T]) getReadExecutor(ctx context.Context, qb *queryBuilder[T]) (executor, error) {
+	switch qb.readPreference {
+	case readFromPrimary:
+		return r.getExecutor(ctx)
+	case readFromReplica:
+		if r.config.replica != nil {
+			return r.instrument(r.config.replica), nil
+		}
+		return r.getExecutor(ctx)
+	}
+	if r.tx == nil && r.config.replica != nil {
+		return r.instrument(r.config.replica), nil
+	}
+	return r.getExecutor(ctx)
+}
+
+// newQueryBuilder returns an empty queryBuilder bound to the repository's dialect and field metadata.
+func (r *Repository[T]) newQueryBuilder() *queryBuilder[T] {
+	return &queryBuilder[T]{
+		dialect:    r.dialect,
+		fields:     r.fields,
+		tableName:  r.tableName,
+		softDelete: r.config.softDelete,
+		validator:  r.config.validator,
+	}
+}
+
+// applyOptions creates a queryBuilder and applies the given options to it.
+func (r *Repository[T]) applyOptions(opts []Option[T]) (*queryBuilder[T], error) {
+	qb := r.newQueryBuilder()
+	for _, opt := range opts {
+		if err := opt.apply(qb); err != nil {
+			return nil, err
+		}
+	}
+	return qb, nil
+}
+
+// buildSelect generates the SELECT statement for a List-style query from the given queryBuilder.
+// Any extraCols are appended to the select list after the mapped columns. Without an OrderBy option, the
+// ordering configured with WithDefaultOrderBy applies.
+func (r *Repository[T]) buildSelect(qb *queryBuilder[T], extraCols ...string) string {
+	selectCols := append(r.selectList(qb), extraCols...)
+
+	lockClause := qb.lockClause
+	if qb.fetchClause != "" {
+		// FETCH must follow OFFSET and precede the locking clause, so the latter is appended afterwards
+		lockClause = ""
+	}
+
+	orderBy := strings.Join(qb.orderByClauses, ", ")
+	if orderBy == "" && r.config.orderBy != nil {
+		orderBy = fmt.Sprintf("%s %s", r.qualify(r.config.orderBy.column), r.config.orderBy.direction)
+	}
+
+	query := r.dialect.SelectSQL(
+		qb.from(),
+		selectCols,
+		strings.Join(qb.joinClauses, " "),
+		qb.whereSQL(),
+		orderBy,
+		lockClause,
+		qb.limit,
+		qb.offset,
+	)
+	if qb.fetchClause != "" {
+		query += qb.limitSQL()
+		if qb.lockClause != "" {
+			query += " " + qb.lockClause
+		}
+	}
+	return query
+}
+
+// quote quotes name if it is a reserved word (see quoteIdent).
+func (r *Repository[T]) quote(name string) string {
+	return quoteIdent(r.dialect, name)
+}
+
+// qualify returns the column qualified with the table name, quoted as needed.
+func (r *Repository[T]) qualify(column string) string {
+	return quoteIdent(r.dialect, r.tableName+"."+column)
+}
+
+// selectList returns the selected columns of qb, qualified with the table name to avoid ambiguity in joins.
+// With Distinct, the first one carries the DISTINCT keyword, so that every dialect's SelectSQL emits
+// SELECT DISTINCT.
+func (r *Repository[T]) selectList(qb *queryBuilder[T]) []string {
+	cols := qb.selectColumns(r.columns)
+	selectCols := make([]string, len(cols))
+	for i, col := range cols {
+		selectCols[i] = qb.selectExpr(col, r.qualify(col))
+	}
+	if qb.distinct {
+		selectCols[0] = "DISTINCT " + selectCols[0]
+	}
+	return selectCols
+}
+
+// applyTxSettings runs the statements requested with WithLockTimeout and WithSessionSetting on the
+// repository's transaction before the query. The returned restore function resets settings that would
+// outlive the transaction (see LockTimeoutResetter); callers defer it once the query has been read.
+func (r *Repository[T]) applyTxSettings(ctx context.Context, qb *queryBuilder[T]) (restore func(), err error) {
+	restore = func() {}
+	if (qb.lockTimeout > 0 || len(qb.sessionSettings) > 0) && r.tx == nil {
+		if qb.lockTimeout > 0 {
+			return restore, fmt.Errorf("WithLockTimeout: %w", ErrTxRequired)
+		}
+		return restore, fmt.Errorf("WithSessionSetting: %w", ErrTxRequired)
+	}
+	e, err := r.getExecutor(ctx)
+	if err != nil {
+		return restore, err
+	}
+
+	if qb.lockTimeout > 0 {
+		stmt, err := lockTimeoutSQL(r.dialect, qb.lockTimeout)
+		if err != nil {
+			return restore, err
+		}
+		if stmt != "" {
+			if _, err := e.ExecContext(ctx, stmt); err != nil {
+				return restore, fmt.Errorf("failed to set lock timeout: %w", err)
+			}
+			if resetter, ok := r.dialect.(LockTimeoutResetter); ok {
+				reset := resetter.ResetLockTimeoutSQL()
+				restore = func() {
+					// The reset also runs when ctx is done; a failure is reported to the query logger
+					// like any other statement, and the transaction is unusable at that point anyway.
+					_, _ = e.ExecContext(context.WithoutCancel(ctx), reset)
+				}
+			}
+		}
+	}
+
+	for _, stmt := range qb.sessionSettings {
+		if _, err := e.ExecContext(ctx, stmt); err != nil {
+			restore()
+			return func() {}, fmt.Errorf("failed to apply session setting: %w", err)
+		}
+	}
+	return restore, nil
+}
+
+// repositoryBase gives package-level helpers such as RawInto access to the metadata of a repository created
+// with NewRepository. It is kept out of RepositoryInterface so that other implementations, e.g. mocks, can
+// satisfy the public interface.
+type repositoryBase[T any] interface {
+	base() *Repository[T]
+}
+
+// base returns the repository itself; see repositoryBase.
+func (r *Repository[T]) base() *Repository[T] {
+	return r
+}
+
+// baseOf returns the repository created with NewRepository behind repo: repo itself, or the one embedded
+// (directly or through other embedded fields) in a custom repository struct.
+func baseOf[T any](repo RepositoryInterface[T]) (*Repository[T], error) {
+	if b, ok := repo.(repositoryBase[T]); ok {
+		return b.base(), nil
+	}
+	v := reflect.ValueOf(repo)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct {
+		for i := 0; i < v.NumField(); i++ {
+			if !v.Type().Field(i).Anonymous || !v.Field(i).CanInterface() {
+				continue
+			}
+			if embedded, ok := v.Field(i).Interface().(RepositoryInterface[T]); ok && embedded != nil {
+				if r, err := baseOf(embedded); err == nil {
+					return r, nil
+				}
+			}
+		}
+	}
+	return nil, fmt.Errorf("%T does not embed a repository created with NewRepository", repo)
+}
+
+// LastQueries returns the statements captured by the recorder configured with WithQueryRecorder,
+// oldest first. It returns nil if no recorder is configured.
+func (r *Repository[T]) LastQueries() []RecordedQuery {
+	if r.config.recorder == nil {
+		return nil
 	}
-	return r.db
+	return r.config.recorder.snapshot()
 }
 
 // WithTx returns a new repository instance that will run queries within the given transaction.
@@ -58,6 +285,14 @@ func (r *Repository[T]) OrderBy(column string, direction SortDirection) Option[T
 	return OrderBy[T](column, direction)
 }
 
+func (r *Repository[T]) OrderByCollate(column string, collation string, direction SortDirection) Option[T] {
+	return OrderByCollate[T](column, collation, direction)
+}
+
+func (r *Repository[T]) WithPartition(partitionName string) Option[T] {
+	return WithPartition[T](partitionName)
+}
+
 func (r *Repository[T]) Limit(limit int) Option[T] {
 	return Limit[T](limit)
 }
@@ -66,33 +301,154 @@ func (r *Repository[T]) Offset(offset int) Option[T] {
 	return Offset[T](offset)
 }
 
+func (r *Repository[T]) WithPage(page, size int) Option[T] {
+	return WithPage[T](page, size)
+}
+
+func (r *Repository[T]) LimitWithTies(n int) Option[T] {
+	return LimitWithTies[T](n)
+}
+
+func (r *Repository[T]) Columns(cols ...string) Option[T] {
+	return Columns[T](cols...)
+}
+
+func (r *Repository[T]) SelectAs(expr, alias string) Option[T] {
+	return SelectAs[T](expr, alias)
+}
+
+func (r *Repository[T]) Distinct() Option[T] {
+	return Distinct[T]()
+}
+
 func (r *Repository[T]) Join(joinClause string) Option[T] {
 	return Join[T](joinClause)
 }
 
+func (r *Repository[T]) InnerJoin(table, on string) Option[T] {
+	return InnerJoin[T](table, on)
+}
+
+func (r *Repository[T]) LeftJoin(table, on string) Option[T] {
+	return LeftJoin[T](table, on)
+}
+
+func (r *Repository[T]) RightJoin(table, on string) Option[T] {
+	return RightJoin[T](table, on)
+}
+
+func (r *Repository[T]) FullJoin(table, on string) Option[T] {
+	return FullJoin[T](table, on)
+}
+
 func (r *Repository[T]) Lock(clause string) Option[T] {
 	return Lock[T](clause)
 }
 
+func (r *Repository[T]) LockForUpdate() Option[T] {
+	return LockForUpdate[T]()
+}
+
+func (r *Repository[T]) LockForShare() Option[T] {
+	return LockForShare[T]()
+}
+
+func (r *Repository[T]) SkipLocked() Option[T] {
+	return SkipLocked[T]()
+}
+
+func (r *Repository[T]) WithLockTimeout(d time.Duration) Option[T] {
+	return WithLockTimeout[T](d)
+}
+
+func (r *Repository[T]) WithSessionSetting(setting, value string) Option[T] {
+	return WithSessionSetting[T](setting, value)
+}
+
 func (r *Repository[T]) WhereIn(column string, values ...any) Option[T] {
 	return WhereIn[T](column, values...)
 }
 
+func (r *Repository[T]) WhereNotIn(column string, values ...any) Option[T] {
+	return WhereNotIn[T](column, values...)
+}
+
+func (r *Repository[T]) WhereInOrAll(column string, values ...any) Option[T] {
+	return WhereInOrAll[T](column, values...)
+}
+
+func (r *Repository[T]) WhereInLarge(column string, values []any) Option[T] {
+	return WhereInLarge[T](column, values)
+}
+
 func (r *Repository[T]) WhereLike(column string, value any) Option[T] {
 	return WhereLike[T](column, value)
 }
 
+func (r *Repository[T]) WhereJSONContains(column string, fragment any) Option[T] {
+	return WhereJSONContains[T](column, fragment)
+}
+
+func (r *Repository[T]) WhereTimeBetween(column string, from, to time.Time) Option[T] {
+	return WhereTimeBetween[T](column, from, to)
+}
+
+func (r *Repository[T]) WhereColumnOpNow(column, operator string) Option[T] {
+	return WhereColumnOpNow[T](column, operator)
+}
+
+func (r *Repository[T]) WhereNull(column string) Option[T] {
+	return WhereNull[T](column)
+}
+
+func (r *Repository[T]) WhereNotNull(column string) Option[T] {
+	return WhereNotNull[T](column)
+}
+
 func (r *Repository[T]) WhereSubquery(column, operator, subquery string, args ...any) Option[T] {
 	return WhereSubquery[T](column, operator, subquery, args...)
 }
 
+func (r *Repository[T]) WhereMatch(example T) Option[T] {
+	return WhereMatch[T](example)
+}
+
+func (r *Repository[T]) WhereNot(opts ...Option[T]) Option[T] {
+	return WhereNot[T](opts...)
+}
+
+func (r *Repository[T]) Or(opts ...Option[T]) Option[T] {
+	return Or[T](opts...)
+}
+
+func (r *Repository[T]) And(opts ...Option[T]) Option[T] {
+	return And[T](opts...)
+}
+
+func (r *Repository[T]) PreferPrimary() Option[T] {
+	return PreferPrimary[T]()
+}
+
+func (r *Repository[T]) WithTrashed() Option[T] {
+	return WithTrashed[T]()
+}
+
+func (r *Repository[T]) PreferReplica() Option[T] {
+	return PreferReplica[T]()
+}
+
+func (r *Repository[T]) ApplyScope(scope Scope[T]) Option[T] {
+	return ApplyScope[T](scope)
+}
+
 func (r *Repository[T]) WithRelation(mapper Relation[T]) Option[T] {
 	return WithRelation[T](mapper)
 }
 
 // NewRepository creates a new generic repository for the given type T.
 // It analyzes the struct T to map its fields to database columns using reflection.
-func NewRepository[T any](db *sql.DB, tableName string, dialect Dialect) (RepositoryInterface[T], error) {
+// Optional RepositoryOption values configure additional behavior such as read-replica routing.
+func NewRepository[T any](db *sql.DB, tableName string, dialect Dialect, opts ...RepositoryOption) (RepositoryInterface[T], error) {
 	var instance T
 	typeOfT := reflect.TypeOf(instance)
 	if typeOfT.Kind() != reflect.Struct {
@@ -102,45 +458,63 @@ func NewRepository[T any](db *sql.DB, tableName string, dialect Dialect) (Reposi
 	repo := &Repository[T]{
 		db:        db,
 		tableName: tableName,
-		scanMap:   make(map[string]int),
+		scanMap:   make(map[string][]int),
 		dialect:   dialect,
 		fields:    make([]fieldInfo, 0),
 	}
+	for _, opt := range opts {
+		opt(&repo.config)
+	}
+	if schema := repo.config.schema; schema != "" {
+		if strings.Contains(tableName, ".") {
+			return nil, fmt.Errorf("table name '%s' is already schema-qualified; WithSchema(%q) cannot be applied", tableName, schema)
+		}
+		repo.tableName = schema + "." + tableName
+	}
 
-	for i := 0; i < typeOfT.NumField(); i++ {
-		field := typeOfT.Field(i)
-		tag := field.Tag.Get("db")
+	fields, err := parseFields(typeOfT, "", nil)
+	if err != nil {
+		return nil, err
+	}
 
-		if tag == "" || tag == "-" {
-			continue
+	for _, field := range fields {
+		if _, exists := repo.scanMap[field.columnName]; exists {
+			return nil, fmt.Errorf("duplicate column '%s' in struct %s", field.columnName, typeOfT.Name())
 		}
 
-		tagParts := strings.Split(tag, ",")
-		columnName := tagParts[0]
+		if field.transform != "" {
+			if field.isPK {
+				return nil, fmt.Errorf("primary key column '%s' cannot use a transformer", field.columnName)
+			}
+			transformer, ok := lookupTransformer(field.transform)
+			if !ok {
+				return nil, fmt.Errorf("unknown transformer '%s' for column '%s'", field.transform, field.columnName)
+			}
+			field.transformer = transformer
+		}
 
-		isPK := false
-		for _, part := range tagParts[1:] {
-			if part == "pk" {
-				isPK = true
-				if repo.pkColumn != "" {
-					return nil, fmt.Errorf("multiple primary key fields defined in %s", typeOfT.Name())
-				}
-				repo.pkColumn = columnName
-
-				// Check if the PK is an integer type, assume auto-increment
-				switch field.Type.Kind() {
-				case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-					reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-					repo.pkIsAutoIncrement = true
-				default:
-					repo.pkIsAutoIncrement = false
-				}
+		field.enumValid = lookupEnumValidator(field.fieldType)
+
+		if field.isPK {
+			if repo.pkColumn != "" {
+				return nil, fmt.Errorf("multiple primary key fields defined in %s", typeOfT.Name())
+			}
+			if field.readOnly {
+				return nil, fmt.Errorf("primary key column '%s' cannot be readonly", field.columnName)
+			}
+			repo.pkColumn = field.columnName
+
+			// Integer PKs are assumed to be auto-increment. Other generated keys (auto modifier) can only be
+			// read back with RETURNING.
+			repo.pkIsAutoIncrement = isIntegerKind(field.fieldType.Kind()) || field.auto
+			if repo.pkIsAutoIncrement && !isIntegerKind(field.fieldType.Kind()) && !supportsReturning(dialect) {
+				return nil, fmt.Errorf("generated primary key column '%s' must be an integer on this dialect", field.columnName)
 			}
 		}
 
-		repo.columns = append(repo.columns, columnName)
-		repo.scanMap[columnName] = i
-		repo.fields = append(repo.fields, fieldInfo{columnName: columnName, fieldIndex: i, isPK: isPK})
+		repo.columns = append(repo.columns, field.columnName)
+		repo.scanMap[field.columnName] = field.index
+		repo.fields = append(repo.fields, field)
 	}
 
 	if len(repo.columns) == 0 {
@@ -150,49 +524,201 @@ func NewRepository[T any](db *sql.DB, tableName string, dialect Dialect) (Reposi
 		return nil, fmt.Errorf("no primary key field defined with ',pk' tag in struct %s", typeOfT.Name())
 	}
 
+	if repo.generated, err = repo.useGeneratedModel(); err != nil {
+		return nil, err
+	}
+	if repo.generated {
+		repo.reflectedArgs = reflectedArgFields(repo.fields)
+	}
+
+	repo.versionField = -1
+	if repo.config.version != "" {
+		pos, err := resolveVersionField(repo.fields, repo.config.version)
+		if err != nil {
+			return nil, err
+		}
+		repo.versionField = pos
+	}
+
+	if order := repo.config.orderBy; order != nil {
+		if !slices.Contains(repo.columns, order.column) {
+			return nil, fmt.Errorf("unknown default order column '%s'", order.column)
+		}
+		if order.direction != SortAsc && order.direction != SortDesc {
+			return nil, fmt.Errorf("invalid default order direction '%s'", order.direction)
+		}
+	}
+
+	if repo.config.resolver != nil && repo.config.replica != nil {
+		return nil, fmt.Errorf("WithConnectionResolver cannot be combined with WithReadReplica")
+	}
+
+	if repo.config.timestamps != nil {
+		ts, err := resolveTimestampFields(repo.fields, repo.config.timestamps.created, repo.config.timestamps.updated)
+		if err != nil {
+			return nil, err
+		}
+		repo.timestamps = &ts
+	}
+
+	if repo.config.notifier != nil {
+		query, err := notifySQL(dialect)
+		if err != nil {
+			return nil, err
+		}
+		// Copy the notifier so repositories sharing an option value do not share state.
+		repo.config.notifier = &changeNotifier{channel: repo.config.notifier.channel, query: query}
+	}
+
 	return repo, nil
 }
 
-// Create inserts a new record into the database based on the provided item.
-// It returns the newly created item, including any fields auto-generated by the database (like ID or timestamps).
-func (r *Repository[T]) Create(ctx context.Context, item T) (T, error) {
-	colsToInsert := make([]string, 0, len(r.fields))
-	valsToInsert := make([]any, 0, len(r.fields))
-	placeholders := make([]string, 0, len(r.fields))
+// BuildInsert returns the INSERT statement and arguments that Create would execute for the given item,
+// without running it. Columns appear in struct field declaration order, so the generated SQL is
+// deterministic and can be asserted in tests. If the dialect supports RETURNING (see ReturningInserter), the
+// statement includes the RETURNING clause.
+func (r *Repository[T]) BuildInsert(item T) (string, []any, error) {
+	return r.buildInsert(item)
+}
 
-	valOfItem := reflect.ValueOf(item)
+// buildInsert generates the INSERT statement and its arguments for the given item.
+// Columns are emitted in struct field declaration order.
+func (r *Repository[T]) buildInsert(item T) (string, []any, error) {
+	colsToInsert := r.insertColumns()
+	valsToInsert, err := r.insertValues(item)
+	if err != nil {
+		return "", nil, err
+	}
+	placeholders := make([]string, len(colsToInsert))
+	for i := range placeholders {
+		placeholders[i] = r.dialect.Placeholder(i + 1)
+	}
+
+	sqlQuery := r.dialect.InsertSQL(r.quote(r.tableName), quoteIdents(r.dialect, colsToInsert), placeholders)
+
+	// RETURNING gets the final state of the row where the dialect supports it; otherwise a dialect without
+	// LastInsertId selects the generated key in the same batch.
+	if supportsReturning(r.dialect) {
+		sqlQuery = insertReturningSQL(r.dialect, sqlQuery, quoteIdents(r.dialect, r.columns))
+	} else if idSQL := lastInsertIDSQL(r.dialect); idSQL != "" && r.pkIsAutoIncrement {
+		sqlQuery += idSQL
+	}
+
+	return sqlQuery, valsToInsert, nil
+}
 
+// insertColumns returns the columns written by an INSERT, in struct field declaration order.
+// An auto-incrementing primary key and readonly or auto columns are left to the database.
+func (r *Repository[T]) insertColumns() []string {
+	cols := make([]string, 0, len(r.fields))
 	for _, fieldInfo := range r.fields {
-		// If the PK is auto-incrementing, don't include it in the insert statement's columns.
-		if fieldInfo.isPK && r.pkIsAutoIncrement {
+		if r.generatedOnInsert(fieldInfo) {
 			continue
 		}
+		cols = append(cols, fieldInfo.columnName)
+	}
+	return cols
+}
 
-		colsToInsert = append(colsToInsert, fieldInfo.columnName)
-		valsToInsert = append(valsToInsert, valOfItem.Field(fieldInfo.fieldIndex).Interface())
-		placeholders = append(placeholders, r.dialect.Placeholder(len(placeholders)+1))
+// insertValues returns the values of the item for the columns returned by insertColumns.
+func (r *Repository[T]) insertValues(item T) ([]any, error) {
+	values, err := r.fieldValues(&item)
+	if err != nil {
+		return nil, err
 	}
+	vals := make([]any, 0, len(r.fields))
+	for i, fieldInfo := range r.fields {
+		if r.generatedOnInsert(fieldInfo) {
+			continue
+		}
+		vals = append(vals, values[i])
+	}
+	return vals, nil
+}
 
-	sqlQuery := r.dialect.InsertSQL(r.tableName, colsToInsert, placeholders)
-	e := r.getExecutor()
+// generatedOnInsert reports whether the database provides the value of f on INSERT.
+func (r *Repository[T]) generatedOnInsert(f fieldInfo) bool {
+	return f.readOnly || f.auto || (f.isPK && r.pkIsAutoIncrement)
+}
 
-	// Unified path for PostgreSQL: always use RETURNING to get the final state of the row.
-	if _, isPg := r.dialect.(PostgresDialect); isPg {
-		sqlQuery += " RETURNING " + strings.Join(r.columns, ", ")
-		row := e.QueryRowContext(ctx, sqlQuery, valsToInsert...)
-		return r.scanRow(row)
+// hasGeneratedColumns reports whether a column other than the primary key is filled in by the database.
+func (r *Repository[T]) hasGeneratedColumns() bool {
+	return slices.ContainsFunc(r.fields, func(f fieldInfo) bool { return !f.isPK && (f.readOnly || f.auto) })
+}
+
+// Create inserts a new record into the database based on the provided item.
+// It returns the newly created item, including any fields auto-generated by the database (like ID or timestamps).
+// If the dialect supports RETURNING (PostgreSQL, and SQLite or MariaDB with the dialect's Returning field),
+// every mapped column, including the primary key, is read back with it, so keys and defaults generated by
+// the database are populated whatever their type. Other dialects rely on LastInsertId, which only covers
+// integer auto-increment keys, and select the row again to populate the other generated columns. Composite
+// primary keys are not supported; NewRepository rejects types with more than one pk field.
+// If the item implements BeforeCreateHook or AfterCreateHook, the hooks run around the insert.
+func (r *Repository[T]) Create(ctx context.Context, item T) (T, error) {
+	if err := callHook(&item, "BeforeCreate", func(h BeforeCreateHook) error { return h.BeforeCreate(ctx) }); err != nil {
+		var zero T
+		return zero, err
+	}
+	created, err := r.create(ctx, item)
+	if err != nil {
+		return created, err
+	}
+	if err := callHook(&created, "AfterCreate", func(h AfterCreateHook) error { return h.AfterCreate(ctx) }); err != nil {
+		return created, err
+	}
+	return created, nil
+}
+
+// create performs the insert of Create, without the lifecycle hooks.
+func (r *Repository[T]) create(ctx context.Context, item T) (T, error) {
+	r.applyDefaults(&item)
+	r.stampTimes(&item, true)
+	sqlQuery, valsToInsert, err := r.buildInsert(item)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	e, err := r.getExecutor(ctx)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	// RETURNING gets the final state of the row in the same round trip.
+	if supportsReturning(r.dialect) {
+		qctx, cancel := r.queryContext(ctx)
+		defer cancel()
+		row := e.QueryRowContext(qctx, sqlQuery, valsToInsert...)
+		created, err := r.scanRow(row)
+		return r.afterWrite(ctx, "insert")(created, duplicateError(r.dialect, err))
+	}
+
+	// The statement selects the generated ID itself when the driver does not implement LastInsertId.
+	if lastInsertIDSQL(r.dialect) != "" && r.pkIsAutoIncrement {
+		var lastID int64
+		qctx, cancel := r.queryContext(ctx)
+		defer cancel()
+		if err := e.QueryRowContext(qctx, sqlQuery, valsToInsert...).Scan(&lastID); err != nil {
+			var zero T
+			return zero, fmt.Errorf("insert failed: %w", duplicateError(r.dialect, err))
+		}
+		return r.afterWrite(ctx, "insert")(r.GetByID(ctx, lastID, PreferPrimary[T]()))
 	}
 
 	// Path for other dialects (MySQL, SQLite, etc.)
 	res, execErr := e.ExecContext(ctx, sqlQuery, valsToInsert...)
 	if execErr != nil {
 		var zero T
-		return zero, fmt.Errorf("insert failed: %w", execErr)
+		return zero, fmt.Errorf("insert failed: %w", duplicateError(r.dialect, execErr))
 	}
 
-	// For non-auto-increment PKs, we're done. Return the original item.
+	// For non-auto-increment PKs, we're done. Return the original item, unless the database filled in
+	// readonly or auto columns that must be read back.
 	if !r.pkIsAutoIncrement {
-		return item, nil
+		if r.hasGeneratedColumns() {
+			return r.afterWrite(ctx, "insert")(r.GetByID(ctx, r.pkValues([]T{item})[0], PreferPrimary[T]()))
+		}
+		return r.afterWrite(ctx, "insert")(item, nil)
 	}
 
 	// For auto-incrementing PKs, fetch the last inserted ID.
@@ -202,49 +728,93 @@ func (r *Repository[T]) Create(ctx context.Context, item T) (T, error) {
 		return zero, fmt.Errorf("insert successful, but failed to retrieve last insert ID: %w", idErr)
 	}
 
-	return r.GetByID(ctx, lastID)
+	// Read from the primary so the new row is visible even when a replica is configured.
+	return r.afterWrite(ctx, "insert")(r.GetByID(ctx, lastID, PreferPrimary[T]()))
+}
+
+// afterWrite returns a function that passes through the result of a single-record write, publishing a
+// change notification for the record when the write succeeded.
+func (r *Repository[T]) afterWrite(ctx context.Context, op string) func(T, error) (T, error) {
+	return func(item T, err error) (T, error) {
+		if err != nil {
+			return item, err
+		}
+		if err := r.notifyChange(ctx, op, r.pkValues([]T{item})...); err != nil {
+			return item, err
+		}
+		return item, nil
+	}
 }
 
 // CreateOrUpdate inserts a new record or updates it if it already exists.
+// Columns tagged insertonly, and the created column of WithTimestamps, keep their stored value on update.
+// Columns tagged readonly or auto are left to the database.
+// It is rejected on a repository created with WithVersionColumn, since an upsert cannot check the version.
 func (r *Repository[T]) CreateOrUpdate(ctx context.Context, item T) (T, error) {
+	if r.versionField >= 0 {
+		var zero T
+		return zero, fmt.Errorf("CreateOrUpdate cannot check the version column '%s'; use Create or Update", r.config.version)
+	}
+	r.stampTimes(&item, true)
 	var pkValue any
-	var pkIndex int = -1
+	pkFound := false
 	vals := make([]any, 0, len(r.fields))
+	updateCols := make([]string, 0, len(r.fields))
 
-	valOfItem := reflect.ValueOf(item)
+	values, err := r.fieldValues(&item)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
 
-	for _, fieldInfo := range r.fields {
-		vals = append(vals, valOfItem.Field(fieldInfo.fieldIndex).Interface())
+	cols := make([]string, 0, len(r.fields))
+	for i, fieldInfo := range r.fields {
+		if !fieldInfo.isPK && (fieldInfo.readOnly || fieldInfo.auto) {
+			continue
+		}
+		cols = append(cols, fieldInfo.columnName)
+		vals = append(vals, values[i])
 		if fieldInfo.isPK {
-			pkValue = valOfItem.Field(fieldInfo.fieldIndex).Interface()
-			pkIndex = fieldInfo.fieldIndex
+			pkValue = values[i] // Primary keys cannot have transformers, so this is the raw value
+			pkFound = true
+		} else if !r.isInsertOnly(i) {
+			updateCols = append(updateCols, fieldInfo.columnName)
 		}
 	}
 
-	if pkIndex == -1 {
+	if !pkFound {
 		var zero T
 		return zero, fmt.Errorf("no primary key field found for upsert")
 	}
 
-	sqlQuery := r.dialect.UpsertSQL(r.tableName, r.pkColumn, r.columns)
-	e := r.getExecutor()
+	sqlQuery, err := upsertSQL(
+		r.dialect, r.quote(r.tableName), r.quote(r.pkColumn), quoteIdents(r.dialect, cols), quoteIdents(r.dialect, updateCols),
+	)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	e, err := r.getExecutor(ctx)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
 
-	_, err := e.ExecContext(ctx, sqlQuery, vals...)
+	_, err = e.ExecContext(ctx, sqlQuery, vals...)
 	if err != nil {
 		var zero T
-		return zero, fmt.Errorf("upsert failed: %w", err)
+		return zero, fmt.Errorf("upsert failed: %w", duplicateError(r.dialect, err))
 	}
 
 	// After upsert, fetch the final state of the item to ensure we have the correct data.
-	return r.GetByID(ctx, pkValue)
+	return r.afterWrite(ctx, "upsert")(r.GetByID(ctx, pkValue, PreferPrimary[T]()))
 }
 
 // GetByID retrieves a single record from the database by its primary key.
-// It returns sql.ErrNoRows if no record is found.
+// It returns ErrNotFound (sql.ErrNoRows) if no record is found; any other failure, including a
+// context timeout or cancellation, is returned as a different error.
 func (r *Repository[T]) GetByID(ctx context.Context, id any, opts ...Option[T]) (T, error) {
-	qb := &queryBuilder[T]{
-		dialect: r.dialect,
-	}
+	qb := r.newQueryBuilder()
 	// Apply provided options (e.g., WithLock)
 	for _, opt := range opts {
 		if err := opt.apply(qb); err != nil {
@@ -254,15 +824,39 @@ func (r *Repository[T]) GetByID(ctx context.Context, id any, opts ...Option[T])
 	}
 
 	// Add the primary key filter
-	qb.whereClauses = append(qb.whereClauses, fmt.Sprintf("%s = %s", r.pkColumn, r.dialect.Placeholder(len(qb.args)+1)))
+	qb.whereClauses = append(qb.whereClauses, fmt.Sprintf("%s = %s", r.quote(r.pkColumn), r.dialect.Placeholder(len(qb.args)+1)))
 	qb.args = append(qb.args, id)
 
+	cols := qb.selectColumns(r.columns)
+	positions, err := r.fieldPositions(cols)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	selectCols := make([]string, len(cols))
+	for i, col := range cols {
+		selectCols[i] = qb.selectExpr(col, r.quote(col))
+	}
 	sql := r.dialect.SelectSQL(
-		r.tableName, r.columns, "", strings.Join(qb.whereClauses, " AND "), "", qb.lockClause, 0, 0,
+		qb.from(), selectCols, "", qb.whereSQL(), "", qb.lockClause, 0, 0,
 	)
 
-	row := r.getExecutor().QueryRowContext(ctx, sql, qb.args...)
-	item, err := r.scanRow(row)
+	restore, err := r.applyTxSettings(ctx, qb)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	defer restore()
+
+	e, err := r.getReadExecutor(ctx, qb)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	qctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	row := e.QueryRowContext(qctx, sql, qb.args...)
+	item, err := r.scanFields(row, positions)
 	if err != nil {
 		return item, err
 	}
@@ -280,65 +874,326 @@ func (r *Repository[T]) GetByID(ctx context.Context, id any, opts ...Option[T])
 	return item, nil
 }
 
-// Update modifies an existing record in the database based on the provided item.
-// The primary key from the item is used in the WHERE clause.
-// It returns the updated item, reflecting any changes made by the database.
-func (r *Repository[T]) Update(ctx context.Context, item T) (T, error) {
+// GetForUpdateIf selects the record with the given primary key FOR UPDATE, locking it until the end of
+// the transaction, and reports whether cond holds for the locked row. Callers should only modify the row
+// when the returned bool is true. It must be called on a repository created with WithTx; otherwise it
+// returns ErrTxRequired without querying the database.
+func (r *Repository[T]) GetForUpdateIf(ctx context.Context, id any, cond func(T) bool) (T, bool, error) {
+	var zero T
+	if r.tx == nil {
+		return zero, false, fmt.Errorf("GetForUpdateIf: %w", ErrTxRequired)
+	}
+
+	item, err := r.GetByID(ctx, id, LockForUpdate[T]())
+	if err != nil {
+		return zero, false, err
+	}
+	return item, cond(item), nil
+}
+
+// GetByIDsOrdered retrieves the records with the given primary keys in a single query.
+// The found records are returned in the order of their first occurrence in ids, and the ids that
+// matched no record are returned as the second result, as they were passed (both without duplicates).
+// Keys are compared after dereferencing pointers and converting numeric ids to the primary key's type,
+// so int, int64, *int64 and integral float64 ids are interchangeable.
+func (r *Repository[T]) GetByIDsOrdered(ctx context.Context, ids []any) ([]T, []any, error) {
+	if len(ids) == 0 {
+		return []T{}, nil, nil
+	}
+
+	pkField := r.fields[r.pkFieldPos()]
+	pkType := pkField.fieldType
+
+	// Normalize and de-duplicate the requested keys, remembering their order and the caller's values.
+	original := make(map[any]any, len(ids))
+	uniqueIDs := make([]any, 0, len(ids))
+	for _, id := range ids {
+		key := normalizeKey(id, pkType)
+		if !reflect.ValueOf(key).Comparable() {
+			return nil, nil, fmt.Errorf("primary key value of type %T cannot be used as a lookup key", id)
+		}
+		if _, dup := original[key]; dup {
+			continue
+		}
+		original[key] = id
+		uniqueIDs = append(uniqueIDs, key)
+	}
+
+	items, err := r.List(ctx, WhereIn[T](r.pkColumn, uniqueIDs...))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byKey := make(map[any]T, len(items))
+	for _, item := range items {
+		byKey[normalizeKey(reflect.ValueOf(item).FieldByIndex(pkField.index).Interface(), pkType)] = item
+	}
+
+	found := make([]T, 0, len(items))
+	var missing []any
+	for _, key := range uniqueIDs {
+		if item, ok := byKey[key]; ok {
+			found = append(found, item)
+		} else {
+			missing = append(missing, original[key])
+		}
+	}
+	return found, missing, nil
+}
+
+// notDeletedSQL returns the condition that keeps the updates of a repository created with WithSoftDelete
+// away from soft-deleted records, or "" for other repositories.
+func (r *Repository[T]) notDeletedSQL() string {
+	if r.config.softDelete == "" {
+		return ""
+	}
+	return fmt.Sprintf(" AND %s IS NULL", r.qualify(r.config.softDelete))
+}
+
+// pkFieldPos returns the position of the primary key in r.fields.
+func (r *Repository[T]) pkFieldPos() int {
+	for i, f := range r.fields {
+		if f.isPK {
+			return i
+		}
+	}
+	return -1
+}
+
+// normalizeKey dereferences pointer keys and converts a key to the given type, or to its element type for
+// pointer types, when both are numeric or both are strings; floats are only converted to integer types when
+// they are integral,
+// so that keys supplied as, e.g., int64 can be matched against an int primary key field.
+func normalizeKey(key any, t reflect.Type) any {
+	v := reflect.ValueOf(key)
+	if !v.IsValid() {
+		return key
+	}
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return key
+		}
+		v = v.Elem()
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if v.Type() == t {
+		return v.Interface()
+	}
+	if isFloatKind(v.Kind()) && !isFloatKind(t.Kind()) && v.Float() != math.Trunc(v.Float()) {
+		return v.Interface()
+	}
+	if (isNumericKind(v.Kind()) && isNumericKind(t.Kind())) || (v.Kind() == reflect.String && t.Kind() == reflect.String) {
+		return v.Convert(t).Interface()
+	}
+	return v.Interface()
+}
+
+// isFloatKind reports whether k is a floating-point kind.
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+// isNumericKind reports whether k is an integer or floating-point kind.
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// Update modifies an existing record in the database based on the provided item.
+// The primary key from the item is used in the WHERE clause.
+// It returns the updated item, reflecting any changes made by the database.
+// On a repository created with WithVersionColumn, the update only applies if the stored version equals
+// the item's; the stored version is incremented and a version mismatch returns ErrStaleObject.
+// Soft-deleted records of a WithSoftDelete repository are not updated.
+// If no row is affected it returns sql.ErrNoRows, unless the repository was created with WithAllowNoOpUpdate.
+// If the item implements BeforeUpdateHook or AfterUpdateHook, the hooks run around the update.
+func (r *Repository[T]) Update(ctx context.Context, item T) (T, error) {
+	if err := callHook(&item, "BeforeUpdate", func(h BeforeUpdateHook) error { return h.BeforeUpdate(ctx) }); err != nil {
+		var zero T
+		return zero, err
+	}
+	updated, err := r.update(ctx, item)
+	if err != nil {
+		return updated, err
+	}
+	if err := callHook(&updated, "AfterUpdate", func(h AfterUpdateHook) error { return h.AfterUpdate(ctx) }); err != nil {
+		return updated, err
+	}
+	return updated, nil
+}
+
+// UpdateWithResult works like Update but returns the number of rows the statement affected instead of the
+// item, so callers can detect no-op updates. No matching row is reported as 0 rather than an error, except
+// on a repository created with WithVersionColumn, where it is ErrStaleObject (or ErrNotFound) as in Update.
+// The count follows the driver: PostgreSQL and SQLite count every matched row, while MySQL only counts
+// rows whose values actually changed (unless the DSN enables clientFoundRows). The AfterUpdateHook only
+// runs when a row was affected.
+func (r *Repository[T]) UpdateWithResult(ctx context.Context, item T) (int64, error) {
+	if err := callHook(&item, "BeforeUpdate", func(h BeforeUpdateHook) error { return h.BeforeUpdate(ctx) }); err != nil {
+		return 0, err
+	}
+	updated, rowsAffected, err := r.updateStatement(ctx, item)
+	if err != nil || rowsAffected == 0 {
+		return rowsAffected, err
+	}
+	if _, err := r.afterWrite(ctx, "update")(updated, nil); err != nil {
+		return rowsAffected, err
+	}
+	if err := callHook(&updated, "AfterUpdate", func(h AfterUpdateHook) error { return h.AfterUpdate(ctx) }); err != nil {
+		return rowsAffected, err
+	}
+	return rowsAffected, nil
+}
+
+// update performs the statement of Update, without the lifecycle hooks.
+func (r *Repository[T]) update(ctx context.Context, item T) (T, error) {
+	updated, rowsAffected, err := r.updateStatement(ctx, item)
+	if err != nil {
+		return updated, err
+	}
+	if rowsAffected == 0 {
+		if r.config.allowNoOp {
+			return updated, nil
+		}
+		var zero T
+		return zero, sql.ErrNoRows // No row was updated
+	}
+	return r.afterWrite(ctx, "update")(updated, nil)
+}
+
+// updateStatement runs the UPDATE of item and returns it as stored along with the number of affected rows.
+// With a version column, affecting no rows is reported as an error by staleOrMissing.
+func (r *Repository[T]) updateStatement(ctx context.Context, item T) (T, int64, error) {
+	r.stampTimes(&item, false)
 	var setClauses strings.Builder
 	vals := make([]any, 0, len(r.fields))
-	var pkValue any
-
-	valOfItem := reflect.ValueOf(item)
+	var pkValue, versionValue any
 
-	for _, fieldInfo := range r.fields {
-		fieldValue := valOfItem.Field(fieldInfo.fieldIndex).Interface()
+	values, err := r.fieldValues(&item)
+	if err != nil {
+		var zero T
+		return zero, 0, err
+	}
 
+	for i, fieldInfo := range r.fields {
+		fieldValue := values[i]
 		if fieldInfo.isPK {
 			pkValue = fieldValue
 			continue
 		}
+		if fieldInfo.readOnly {
+			continue
+		}
 
 		if setClauses.Len() > 0 {
 			setClauses.WriteString(", ")
 		}
-		setClauses.WriteString(fmt.Sprintf("%s = %s", fieldInfo.columnName, r.dialect.Placeholder(len(vals)+1)))
+		if i == r.versionField {
+			versionValue = fieldValue
+			col := r.quote(fieldInfo.columnName)
+			setClauses.WriteString(fmt.Sprintf("%s = %s + 1", col, col))
+			continue
+		}
+		setClauses.WriteString(fmt.Sprintf("%s = %s", r.quote(fieldInfo.columnName), r.dialect.Placeholder(len(vals)+1)))
 		vals = append(vals, fieldValue)
 	}
 
 	if pkValue == nil || (reflect.ValueOf(pkValue).Kind() == reflect.Pointer && reflect.ValueOf(pkValue).IsNil()) {
 		var zero T
-		return zero, fmt.Errorf("primary key value not found in item to update")
+		return zero, 0, fmt.Errorf("primary key value not found in item to update")
 	}
 	vals = append(vals, pkValue)
 
-	sqlQuery := r.dialect.UpdateSQL(r.tableName, setClauses.String(), r.pkColumn, r.dialect.Placeholder(len(vals)))
+	sqlQuery := r.dialect.UpdateSQL(r.quote(r.tableName), setClauses.String(), r.quote(r.pkColumn), r.dialect.Placeholder(len(vals)))
+	if r.versionField >= 0 {
+		vals = append(vals, versionValue)
+		sqlQuery += fmt.Sprintf(" AND %s = %s", r.quote(r.config.version), r.dialect.Placeholder(len(vals)))
+	}
+	sqlQuery += r.notDeletedSQL()
 
-	res, execErr := r.getExecutor().ExecContext(ctx, sqlQuery, vals...)
+	e, err := r.getExecutor(ctx)
+	if err != nil {
+		var zero T
+		return zero, 0, err
+	}
+	res, execErr := e.ExecContext(ctx, sqlQuery, vals...)
 	if execErr != nil {
 		var zero T
-		return zero, fmt.Errorf("update failed: %w", execErr)
+		return zero, 0, fmt.Errorf("update failed: %w", duplicateError(r.dialect, execErr))
 	}
 
 	rowsAffected, idErr := res.RowsAffected()
 	if idErr != nil {
 		var zero T
-		return zero, fmt.Errorf("update successful, but failed to retrieve rows affected: %w", idErr)
+		return zero, 0, fmt.Errorf("update successful, but failed to retrieve rows affected: %w", idErr)
 	}
 
-	if rowsAffected == 0 {
+	if rowsAffected == 0 && r.versionField >= 0 {
 		var zero T
-		return zero, sql.ErrNoRows // No row was updated
+		return zero, 0, r.staleOrMissing(ctx, pkValue)
 	}
 
-	return item, nil
+	if r.versionField >= 0 {
+		r.bumpVersion(&item)
+	}
+	return item, rowsAffected, nil
 }
 
 // Delete removes a record from the database by its primary key.
 // It returns an error if the operation fails or if no rows were affected.
+// On a repository created with WithSoftDelete, the record is marked as deleted instead;
+// deleting an already soft-deleted record returns sql.ErrNoRows.
+// If T implements BeforeDeleteHook or AfterDeleteHook, the record is loaded and the hooks run around the delete.
 func (r *Repository[T]) Delete(ctx context.Context, id any) error {
-	sqlQuery := r.dialect.DeleteSQL(r.tableName, r.pkColumn, r.dialect.Placeholder(1))
+	if r.config.softDelete == "" {
+		return r.ForceDelete(ctx, id)
+	}
+
+	softDelete := r.quote(r.config.softDelete)
+	sqlQuery := fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s = %s AND %s IS NULL",
+		r.quote(r.tableName), softDelete, r.dialect.Placeholder(1), r.quote(r.pkColumn), r.dialect.Placeholder(2), softDelete)
+	return r.execDelete(ctx, sqlQuery, id, false, time.Now().UTC(), id)
+}
+
+// ForceDelete physically removes a record by its primary key, bypassing soft deletes.
+// It returns an error if the operation fails or if no rows were affected.
+func (r *Repository[T]) ForceDelete(ctx context.Context, id any) error {
+	sqlQuery := r.dialect.DeleteSQL(r.quote(r.tableName), r.quote(r.pkColumn), r.dialect.Placeholder(1))
+	return r.execDelete(ctx, sqlQuery, id, true, id)
+}
+
+// execDelete runs a single-record delete statement and publishes the change notification for id.
+// The delete hooks of T, if any, run around the statement on the record loaded beforehand; soft-deleted
+// records are only loaded if withTrashed is set, as for ForceDelete.
+func (r *Repository[T]) execDelete(ctx context.Context, sqlQuery string, id any, withTrashed bool, args ...any) error {
+	var item T
+	hooks := r.hasDeleteHooks()
+	if hooks {
+		opts := []Option[T]{PreferPrimary[T]()}
+		if withTrashed {
+			opts = append(opts, WithTrashed[T]())
+		}
+		var err error
+		if item, err = r.GetByID(ctx, id, opts...); err != nil {
+			return err
+		}
+		if err := callHook(&item, "BeforeDelete", func(h BeforeDeleteHook) error { return h.BeforeDelete(ctx) }); err != nil {
+			return err
+		}
+	}
 
-	res, err := r.getExecutor().ExecContext(ctx, sqlQuery, id)
+	e, err := r.getExecutor(ctx)
+	if err != nil {
+		return err
+	}
+	res, err := e.ExecContext(ctx, sqlQuery, args...)
 	if err != nil {
 		return err
 	}
@@ -352,69 +1207,384 @@ func (r *Repository[T]) Delete(ctx context.Context, id any) error {
 		return sql.ErrNoRows // No row was deleted
 	}
 
+	if err := r.notifyChange(ctx, "delete", id); err != nil {
+		return err
+	}
+	if hooks {
+		return callHook(&item, "AfterDelete", func(h AfterDeleteHook) error { return h.AfterDelete(ctx) })
+	}
 	return nil
 }
 
 // List retrieves a slice of records based on the provided options.
 func (r *Repository[T]) List(ctx context.Context, opts ...Option[T]) ([]T, error) {
-	qb := &queryBuilder[T]{
-		dialect: r.dialect,
+	qb, err := r.applyOptions(opts)
+	if err != nil {
+		return nil, err
 	}
-	for _, opt := range opts {
-		if err := opt.apply(qb); err != nil {
-			return nil, err
+
+	return r.list(ctx, qb)
+}
+
+// First returns the first record matching opts, running the List query with LIMIT 1 (overriding any
+// Limit in opts). Use OrderBy to make "first" deterministic. It returns ErrNotFound
+// (sql.ErrNoRows) if no record matches.
+func (r *Repository[T]) First(ctx context.Context, opts ...Option[T]) (T, error) {
+	var zero T
+	qb, err := r.applyOptions(append(opts[:len(opts):len(opts)], Limit[T](1)))
+	if err != nil {
+		return zero, err
+	}
+
+	items, err := r.list(ctx, qb)
+	if err != nil {
+		return zero, err
+	}
+	if len(items) == 0 {
+		return zero, ErrNotFound
+	}
+	return items[0], nil
+}
+
+// GetBy retrieves a single record by the value of a mapped column other than the primary key, typically a
+// unique one such as a username or email. The column is validated against the struct's db tags, since it is
+// inserted into the SQL text unparameterized. Any opts are applied as well and the query is limited to one row.
+// It returns ErrNotFound (sql.ErrNoRows) if no record matches.
+func (r *Repository[T]) GetBy(ctx context.Context, column string, value any, opts ...Option[T]) (T, error) {
+	if _, ok := r.scanMap[column]; !ok {
+		var zero T
+		return zero, fmt.Errorf("unknown column '%s' for table %s in GetBy", column, r.tableName)
+	}
+	where := simpleWhereOption[T]{column: r.tableName + "." + column, value: value}
+	return r.First(ctx, append(opts[:len(opts):len(opts)], where)...)
+}
+
+// Pluck returns the values of a single mapped column for the records matching opts, in query order.
+// The values have the Go type of the corresponding struct field.
+func (r *Repository[T]) Pluck(ctx context.Context, column string, opts ...Option[T]) ([]any, error) {
+	var field *fieldInfo
+	for i := range r.fields {
+		if r.fields[i].columnName == column {
+			field = &r.fields[i]
+			break
 		}
 	}
+	if field == nil {
+		return nil, fmt.Errorf("unknown column '%s' for table %s in Pluck", column, r.tableName)
+	}
 
-	// Always qualify column names with the table name to avoid ambiguity in joins
-	selectCols := make([]string, len(r.columns))
-	for i, col := range r.columns {
-		selectCols[i] = r.tableName + "." + col
+	qb, err := r.applyOptions(opts)
+	if err != nil {
+		return nil, err
 	}
 
-	sql := r.dialect.SelectSQL(
-		r.tableName,
-		selectCols,
+	query := r.dialect.SelectSQL(
+		qb.from(),
+		[]string{r.qualify(column)},
 		strings.Join(qb.joinClauses, " "),
-		strings.Join(qb.whereClauses, " AND "),
+		qb.whereSQL(),
 		strings.Join(qb.orderByClauses, ", "),
 		qb.lockClause,
 		qb.limit,
 		qb.offset,
 	)
-
-	rows, err := r.getExecutor().QueryContext(ctx, sql, qb.args...)
+	restore, err := r.applyTxSettings(ctx, qb)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+	e, err := r.getReadExecutor(ctx, qb)
+	if err != nil {
+		return nil, err
+	}
+	qctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	rows, err := e.QueryContext(qctx, query, qb.args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var results []T
+	values := []any{}
 	for rows.Next() {
-		instance, err := r.scanRow(rows)
-		if err != nil {
+		dest := reflect.New(field.fieldType)
+		if field.transformer != nil {
+			var raw any
+			if err := rows.Scan(&raw); err != nil {
+				return nil, err
+			}
+			if err := decodeInto(dest.Elem(), *field, raw); err != nil {
+				return nil, err
+			}
+		} else if err := rows.Scan(dest.Interface()); err != nil {
 			return nil, err
 		}
-		results = append(results, instance)
+		values = append(values, dest.Elem().Interface())
 	}
-
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
+	return values, nil
+}
 
-	// Handle eager loading if there are relations
+// ListInto works like List but appends the records to *dest, reusing its capacity.
+// Pre-sizing the slice (e.g., make([]T, 0, n)) avoids repeated growth for large results,
+// and passing a slice truncated to length zero allows reusing a buffer across calls.
+// On error, *dest is left unchanged.
+func (r *Repository[T]) ListInto(ctx context.Context, dest *[]T, opts ...Option[T]) error {
+	if dest == nil {
+		return fmt.Errorf("ListInto requires a non-nil destination slice")
+	}
+	qb, err := r.applyOptions(opts)
+	if err != nil {
+		return err
+	}
+	results, err := r.listInto(ctx, qb, *dest)
+	if err != nil {
+		return err
+	}
+	*dest = results
+	return nil
+}
+
+// list runs the SELECT described by qb, scans the results and loads the requested relations.
+func (r *Repository[T]) list(ctx context.Context, qb *queryBuilder[T]) ([]T, error) {
+	return r.listInto(ctx, qb, nil)
+}
+
+// listInto is like list but appends the results to dest.
+func (r *Repository[T]) listInto(ctx context.Context, qb *queryBuilder[T], dest []T) ([]T, error) {
+	sql := r.buildSelect(qb)
+
+	restore, err := r.applyTxSettings(ctx, qb)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+
+	e, err := r.getReadExecutor(ctx, qb)
+	if err != nil {
+		return nil, err
+	}
+	qctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	rows, err := e.QueryContext(qctx, sql, qb.args...)
+	if err != nil {
+		return nil, err
+	}
+	results, err := r.scanRowsInto(rows, dest, r.config.maxRows)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the newly appended records need their relations loaded
+	if err := r.loadRelations(ctx, qb, results[len(dest):]); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// loadRelations eager-loads the relations requested in qb for the given results, in place.
+func (r *Repository[T]) loadRelations(ctx context.Context, qb *queryBuilder[T], results []T) error {
 	if len(qb.relations) > 0 {
 		// We need a slice of pointers to pass to handleRelations
 		parentPtrs := make([]*T, len(results))
 		for i := range results {
 			parentPtrs[i] = &results[i]
 		}
-		if err := r.handleRelations(ctx, qb, parentPtrs); err != nil {
+		return r.handleRelations(ctx, qb, parentPtrs)
+	}
+	return nil
+}
+
+// DeleteWhere removes all records matching the WHERE conditions of opts and returns the number of deleted rows.
+// At least one WHERE condition is required to avoid accidentally emptying the table; joins are rejected.
+// On a repository created with WithSoftDelete, the records are marked as deleted instead and already
+// soft-deleted records are not counted. Delete hooks are not run.
+func (r *Repository[T]) DeleteWhere(ctx context.Context, opts ...Option[T]) (int64, error) {
+	qb := r.newQueryBuilder()
+	if r.config.softDelete != "" {
+		// The SET value precedes the WHERE conditions in the statement
+		qb.args = append(qb.args, time.Now().UTC())
+	}
+	for _, opt := range opts {
+		if err := opt.apply(qb); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(qb.whereClauses) == 0 {
+		return 0, fmt.Errorf("DeleteWhere requires at least one WHERE condition")
+	}
+	if len(qb.joinClauses) > 0 {
+		return 0, fmt.Errorf("DeleteWhere does not support joins; use a subquery condition instead")
+	}
+	whereClause := strings.Join(qb.whereClauses, " AND ")
+
+	sqlQuery := fmt.Sprintf("DELETE FROM %s WHERE %s", r.quote(r.tableName), whereClause)
+	if r.config.softDelete != "" {
+		softDelete := r.quote(r.config.softDelete)
+		sqlQuery = fmt.Sprintf("UPDATE %s SET %s = %s WHERE (%s) AND %s IS NULL",
+			r.quote(r.tableName), softDelete, r.dialect.Placeholder(1), whereClause, softDelete)
+	}
+
+	e, err := r.getExecutor(ctx)
+	if err != nil {
+		return 0, err
+	}
+	res, err := e.ExecContext(ctx, sqlQuery, qb.args...)
+	if err != nil {
+		return 0, fmt.Errorf("delete failed: %w", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("delete successful, but failed to retrieve rows affected: %w", err)
+	}
+
+	if rowsAffected > 0 {
+		if err := r.notifyChange(ctx, "delete"); err != nil {
+			return rowsAffected, err
+		}
+	}
+	return rowsAffected, nil
+}
+
+// DeleteWhereReturning removes all records matching the provided options and returns them, e.g. for an audit log.
+// At least one WHERE condition is required to avoid accidentally deleting the whole table; joins, ordering,
+// limits and column selection are rejected.
+// If the dialect supports it (PostgreSQL, and SQLite or MariaDB with the dialect's Returning field; see
+// ReturningDeleter) the rows are returned natively via DELETE ... RETURNING. Other dialects emulate it by
+// selecting the matching rows and deleting them within a single transaction (the repository's own
+// transaction if it was created with WithTx).
+// On a repository created with WithSoftDelete, the records are marked as deleted instead, always with the
+// emulation, and are returned as they were before; already soft-deleted records are ignored, even with
+// WithTrashed. Delete hooks are not run.
+func (r *Repository[T]) DeleteWhereReturning(ctx context.Context, opts ...Option[T]) ([]T, error) {
+	qb, err := r.deleteWhereBuilder(opts)
+	if err != nil {
+		return nil, err
+	}
+	whereClause := qb.whereSQL()
+	deleteSQL := fmt.Sprintf("DELETE FROM %s WHERE %s", r.quote(r.tableName), whereClause)
+	deleteArgs := qb.args
+
+	if r.config.softDelete != "" {
+		// The SET value precedes the WHERE conditions in the statement, so their placeholders are renumbered.
+		uqb, err := r.deleteWhereBuilder(opts, time.Now().UTC())
+		if err != nil {
+			return nil, err
+		}
+		deleteSQL = fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s",
+			r.quote(r.tableName), r.quote(r.config.softDelete), r.dialect.Placeholder(1), uqb.whereSQL())
+		deleteArgs = uqb.args
+	} else if supportsDeleteReturning(r.dialect) {
+		deleteSQL = deleteReturningSQL(r.dialect, deleteSQL, quoteIdents(r.dialect, r.columns))
+		e, err := r.getExecutor(ctx)
+		if err != nil {
+			return nil, err
+		}
+		qctx, cancel := r.queryContext(ctx)
+		defer cancel()
+		rows, err := e.QueryContext(qctx, deleteSQL, qb.args...)
+		if err != nil {
+			return nil, fmt.Errorf("delete failed: %w", err)
+		}
+		deleted, err := r.scanRows(rows)
+		if err != nil {
 			return nil, err
 		}
+		if len(deleted) > 0 {
+			if err := r.notifyChange(ctx, "delete", r.pkValues(deleted)...); err != nil {
+				return deleted, err
+			}
+		}
+		return deleted, nil
 	}
 
-	return results, nil
+	var deleted []T
+	if r.tx != nil {
+		deleted, err = r.selectThenDelete(ctx, r.tx, whereClause, qb.args, deleteSQL, deleteArgs)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		tx, err := r.beginTx(ctx)
+		if err != nil {
+			return nil, err
+		}
+		deleted, err = r.selectThenDelete(ctx, tx, whereClause, qb.args, deleteSQL, deleteArgs)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+	}
+	if len(deleted) > 0 {
+		if err := r.notifyChange(ctx, "delete", r.pkValues(deleted)...); err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+// deleteWhereBuilder applies the options of DeleteWhereReturning after the given leading arguments and
+// rejects those that a DELETE statement cannot honor.
+func (r *Repository[T]) deleteWhereBuilder(opts []Option[T], leadingArgs ...any) (*queryBuilder[T], error) {
+	qb := r.newQueryBuilder()
+	qb.args = append(qb.args, leadingArgs...)
+	for _, opt := range opts {
+		if err := opt.apply(qb); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(qb.whereClauses) == 0 {
+		return nil, fmt.Errorf("DeleteWhereReturning requires at least one WHERE condition")
+	}
+	if len(qb.joinClauses) > 0 {
+		return nil, fmt.Errorf("DeleteWhereReturning does not support joins; use a subquery condition instead")
+	}
+	if len(qb.orderByClauses) > 0 || qb.limit > 0 || qb.offset > 0 || qb.fetchClause != "" {
+		return nil, fmt.Errorf("DeleteWhereReturning does not support ordering or limits; it deletes every matching record")
+	}
+	if len(qb.columns) > 0 || len(qb.aliases) > 0 {
+		return nil, fmt.Errorf("DeleteWhereReturning does not support column selection; it returns every mapped column")
+	}
+	// Soft-deleted records are never deleted again
+	qb.withTrashed = false
+	return qb, nil
+}
+
+// selectThenDelete emulates DELETE ... RETURNING by selecting the matching rows before deleting them.
+// It must be called within a transaction so that both statements see the same rows.
+func (r *Repository[T]) selectThenDelete(
+	ctx context.Context, tx *sql.Tx, whereClause string, args []any, deleteSQL string, deleteArgs []any,
+) ([]T, error) {
+	// Lock the rows so they cannot change between the SELECT and the DELETE.
+	lockClause, err := rowLockSQL(r.dialect, LockModeUpdate, false)
+	if err != nil {
+		return nil, err
+	}
+	selectSQL := r.dialect.SelectSQL(r.quote(r.tableName), quoteIdents(r.dialect, r.columns), "", whereClause, "", lockClause, 0, 0)
+
+	e := r.instrument(tx)
+	qctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	rows, err := e.QueryContext(qctx, selectSQL, args...)
+	if err != nil {
+		return nil, err
+	}
+	deleted, err := r.scanRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := e.ExecContext(ctx, deleteSQL, deleteArgs...); err != nil {
+		return nil, fmt.Errorf("delete failed: %w", err)
+	}
+	return deleted, nil
 }
 
 // handleRelations processes the eager loading for the fetched parent entities.
@@ -431,23 +1601,149 @@ func (r *Repository[T]) handleRelations(ctx context.Context, qb *queryBuilder[T]
 	return nil
 }
 
+// scanRows scans all remaining rows of a result set and closes it.
+// Any extra destinations receive the values of additional columns following the mapped ones.
+func (r *Repository[T]) scanRows(rows *sql.Rows, extra ...any) ([]T, error) {
+	return r.scanRowsInto(rows, nil, 0, extra...)
+}
+
+// scanRowsInto is like scanRows but appends the scanned records to results.
+// If maxRows is positive, it stops with ErrTooManyRows as soon as more than maxRows rows are read.
+func (r *Repository[T]) scanRowsInto(rows *sql.Rows, results []T, maxRows int, extra ...any) ([]T, error) {
+	defer rows.Close()
+
+	// Map the returned columns to fields by name, since a Columns option may have selected only some of them
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) < len(extra) {
+		return nil, fmt.Errorf("query returned %d columns, expected at least %d", len(columns), len(extra))
+	}
+	positions, err := r.fieldPositions(columns[:len(columns)-len(extra)])
+	if err != nil {
+		return nil, err
+	}
+
+	scanned := 0
+	for rows.Next() {
+		if scanned++; maxRows > 0 && scanned > maxRows {
+			return nil, fmt.Errorf("%w: more than %d rows matched in %s", ErrTooManyRows, maxRows, r.tableName)
+		}
+		instance, err := r.scanFields(rows, positions, extra...)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, instance)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// fieldPositions returns the positions in r.fields of the given columns, or nil if they are exactly the
+// mapped columns in order, which scanFields handles without the per-column lookup. Names are matched
+// case-insensitively since databases fold unquoted identifiers (PostgreSQL to lower case, others to
+// upper case), but an exact match is preferred.
+func (r *Repository[T]) fieldPositions(columns []string) ([]int, error) {
+	if slices.Equal(columns, r.columns) {
+		return nil, nil
+	}
+	positions := make([]int, len(columns))
+	for i, col := range columns {
+		pos := slices.Index(r.columns, col)
+		if pos < 0 {
+			pos = slices.IndexFunc(r.columns, func(c string) bool { return strings.EqualFold(c, col) })
+		}
+		if pos < 0 {
+			return nil, fmt.Errorf("column '%s' has no matching db tag in %s", col, reflect.TypeFor[T]())
+		}
+		positions[i] = pos
+	}
+	return positions, nil
+}
+
+// scanFields scans a single row whose columns are the fields at the given positions (see fieldPositions);
+// fields of other columns are left at their zero values. Nil positions mean all mapped columns.
+func (r *Repository[T]) scanFields(scannable interface{ Scan(...any) error }, positions []int, extra ...any) (T, error) {
+	if positions == nil {
+		return r.scanRow(scannable, extra...)
+	}
+
+	var instance T
+	val := reflect.ValueOf(&instance).Elem()
+	scanDest := make([]any, len(positions), len(positions)+len(extra))
+	for i, pos := range positions {
+		if r.fields[pos].transformer != nil {
+			scanDest[i] = new(any)
+			continue
+		}
+		scanDest[i] = val.FieldByIndex(r.fields[pos].index).Addr().Interface()
+	}
+	scanDest = append(scanDest, extra...)
+
+	if err := scannable.Scan(scanDest...); err != nil {
+		return instance, err
+	}
+
+	for i, pos := range positions {
+		f := r.fields[pos]
+		if f.transformer != nil {
+			if err := decodeField(val, f, *scanDest[i].(*any)); err != nil {
+				return instance, err
+			}
+		}
+		if f.enumValid != nil {
+			if err := validateEnumField(val, f); err != nil {
+				return instance, err
+			}
+		}
+	}
+	return instance, nil
+}
+
 // scanRow scans a single row from *sql.Row or *sql.Rows.
-func (r *Repository[T]) scanRow(scannable interface{ Scan(...any) error }) (T, error) {
+// Any extra destinations receive the values of additional columns following the mapped ones.
+func (r *Repository[T]) scanRow(scannable interface{ Scan(...any) error }, extra ...any) (T, error) {
 	var instance T
+	if r.generated {
+		return r.scanGenerated(scannable, extra)
+	}
 	val := reflect.ValueOf(&instance).Elem()
-	scanDest := make([]any, len(r.columns))
+	scanDest := make([]any, len(r.columns), len(r.columns)+len(extra))
 
 	for i, colName := range r.columns {
-		fieldIndex, ok := r.scanMap[colName]
+		index, ok := r.scanMap[colName]
 		if !ok {
 			return instance, fmt.Errorf("column '%s' not found in scan map for type %T", colName, instance)
 		}
-		scanDest[i] = val.Field(fieldIndex).Addr().Interface()
+		if r.fields[i].transformer != nil {
+			// Transformed columns are scanned raw and decoded afterwards
+			scanDest[i] = new(any)
+			continue
+		}
+		scanDest[i] = val.FieldByIndex(index).Addr().Interface()
 	}
+	scanDest = append(scanDest, extra...)
 
 	if err := scannable.Scan(scanDest...); err != nil {
 		return instance, err
 	}
 
+	for i, f := range r.fields {
+		if f.transformer != nil {
+			if err := decodeField(val, f, *scanDest[i].(*any)); err != nil {
+				return instance, err
+			}
+		}
+		if f.enumValid != nil {
+			if err := validateEnumField(val, f); err != nil {
+				return instance, err
+			}
+		}
+	}
+
 	return instance, nil
 }
@@ -0,0 +1,173 @@
+package crud
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// RepositoryOption configures a Repository at construction time (see NewRepository).
+type RepositoryOption func(*repositoryConfig)
+
+// repositoryConfig holds the construction-time settings of a Repository.
+type repositoryConfig struct {
+	schema     string                                     // Schema the table lives in (see WithSchema); empty for the default
+	resolver   func(ctx context.Context) (*sql.DB, error) // Optional per-call primary connection (see WithConnectionResolver)
+	replica    *sql.DB                                    // Optional read replica used for reads outside of transactions
+	recorder   *queryRecorder                             // Optional recorder of executed statements
+	logger     QueryLogger                                // Optional logger of executed statements with their timing
+	timeout    time.Duration                              // Default deadline of each statement (see WithDefaultTimeout); 0 if disabled
+	orderBy    *defaultOrder                              // Ordering of listing queries without OrderBy; nil if unset
+	maxRows    int                                        // Maximum number of rows a listing query may return; 0 means unlimited
+	notifier   *changeNotifier                            // Optional publisher of change notifications after writes
+	softDelete string                                     // Soft-delete timestamp column; empty if disabled
+	timestamps *timestampColumns                          // Columns managed by WithTimestamps; nil if disabled
+	version    string                                     // Optimistic locking version column; empty if disabled
+	allowNoOp  bool                                       // Whether updates affecting no rows succeed (see WithAllowNoOpUpdate)
+	validator  func(expr string) error                    // Optional check of raw SQL fragments (see WithIdentifierValidator)
+}
+
+// defaultOrder is the ordering configured with WithDefaultOrderBy.
+type defaultOrder struct {
+	column    string
+	direction SortDirection
+}
+
+// timestampColumns names the columns configured with WithTimestamps.
+type timestampColumns struct {
+	created string
+	updated string
+}
+
+// WithSchema places the repository's table in the named schema (a database on MySQL), so that every statement
+// refers to it as schema.table. This is equivalent to passing the qualified name to NewRepository, which is
+// then rejected. Reserved-word parts of the name are quoted separately (see Dialect.QuoteIdentifier).
+func WithSchema(schema string) RepositoryOption {
+	return func(c *repositoryConfig) {
+		c.schema = schema
+	}
+}
+
+// WithConnectionResolver selects the primary connection for every call from its context, e.g. the pool of the
+// tenant stored in ctx in a pool-per-tenant deployment, so that one repository serves all tenants. The db
+// passed to NewRepository is then unused and may be nil. Transactions the repository begins itself (e.g. in
+// CreateMany or SyncInsertMissing) are started on the resolved connection and keep using it; a repository
+// bound with WithTx runs on its transaction without calling resolve. An error returned by resolve fails the
+// call. It cannot be combined with WithReadReplica, whose single replica would serve the reads of every
+// tenant; NewRepository returns an error if both are set.
+func WithConnectionResolver(resolve func(ctx context.Context) (*sql.DB, error)) RepositoryOption {
+	return func(c *repositoryConfig) {
+		c.resolver = resolve
+	}
+}
+
+// WithReadReplica routes read queries (GetByID, List, etc.) to the given replica connection
+// when they are not running inside a transaction. Writes always go to the primary connection.
+// Individual reads can override the routing with the PreferPrimary and PreferReplica options.
+func WithReadReplica(replica *sql.DB) RepositoryOption {
+	return func(c *repositoryConfig) {
+		c.replica = replica
+	}
+}
+
+// WithQueryRecorder keeps the last n statements executed by the repository (and by the repositories
+// derived from it with WithTx) so that tests can assert on the generated SQL via LastQueries.
+// A non-positive n disables recording.
+func WithQueryRecorder(n int) RepositoryOption {
+	return func(c *repositoryConfig) {
+		if n <= 0 {
+			c.recorder = nil
+			return
+		}
+		c.recorder = &queryRecorder{limit: n}
+	}
+}
+
+// WithLogger reports every statement executed by the repository (and by the repositories derived from it
+// with WithTx) to l, together with its arguments, duration and error.
+func WithLogger(l QueryLogger) RepositoryOption {
+	return func(c *repositoryConfig) {
+		c.logger = l
+	}
+}
+
+// WithDefaultTimeout bounds every statement the repository executes (including those of repositories
+// derived from it with WithTx) by d, so that queries get a deadline even when callers pass
+// context.Background(). A context that already has an earlier deadline is used as is. For queries, the
+// deadline also covers reading the returned rows. Expired statements fail with context.DeadlineExceeded.
+// A non-positive d disables the default.
+func WithDefaultTimeout(d time.Duration) RepositoryOption {
+	return func(c *repositoryConfig) {
+		c.timeout = max(d, 0)
+	}
+}
+
+// WithDefaultOrderBy orders List, First, Paginate and the other listing queries by column when they have
+// no OrderBy option, e.g. to read newest-first and keep pagination stable without repeating the ordering at
+// every call site. Any explicit ordering replaces the default rather than being combined with it. The
+// column must match a db tag of the record type and is qualified with the table name.
+func WithDefaultOrderBy(column string, direction SortDirection) RepositoryOption {
+	return func(c *repositoryConfig) {
+		c.orderBy = &defaultOrder{column: column, direction: direction}
+	}
+}
+
+// WithMaxRows makes List and the other listing methods fail with ErrTooManyRows when a query matches
+// more than n rows, instead of materializing the whole result. Unlike a LIMIT, which silently truncates,
+// this is a safety assertion that surfaces queries with missing filters. A non-positive n disables the check.
+func WithMaxRows(n int) RepositoryOption {
+	return func(c *repositoryConfig) {
+		c.maxRows = max(n, 0)
+	}
+}
+
+// WithSoftDelete enables soft deletes using the given nullable timestamp column (e.g., "deleted_at").
+// Delete sets the column to the current UTC time instead of removing the row, and every read (GetByID, List,
+// Count, etc.) and UpdateWhere exclude rows where it is not NULL unless the WithTrashed option is given.
+// ForceDelete and DeleteWhereReturning still remove rows physically.
+func WithSoftDelete(column string) RepositoryOption {
+	return func(c *repositoryConfig) {
+		c.softDelete = column
+	}
+}
+
+// WithTimestamps makes the repository maintain creation and modification times: Create, CreateMany and
+// CreateOrUpdate set both the created and updated columns to the current UTC time, while Update sets only
+// the updated one. When CreateOrUpdate hits an existing row, the stored created value is kept. The values
+// are bound like any other field. Both columns must be mapped to time.Time or *time.Time fields; an empty
+// name leaves that timestamp unmanaged, and NewRepository rejects unknown columns.
+func WithTimestamps(createdCol, updatedCol string) RepositoryOption {
+	return func(c *repositoryConfig) {
+		c.timestamps = &timestampColumns{created: createdCol, updated: updatedCol}
+	}
+}
+
+// WithVersionColumn enables optimistic locking on the given integer column. Update then only succeeds if
+// the stored version still equals the item's, increments it, and returns ErrStaleObject if the record was
+// modified in the meantime. A missing record is still reported as sql.ErrNoRows.
+func WithVersionColumn(column string) RepositoryOption {
+	return func(c *repositoryConfig) {
+		c.version = column
+	}
+}
+
+// WithAllowNoOpUpdate makes Update and UpdateFields succeed when the statement affects no rows, instead of
+// returning sql.ErrNoRows, for callers to whom a missing (or, on MySQL, unchanged) record is not an error.
+// Update then returns the item as given and UpdateMany skips it as before. Version conflicts on a repository
+// created with WithVersionColumn are still reported.
+func WithAllowNoOpUpdate() RepositoryOption {
+	return func(c *repositoryConfig) {
+		c.allowNoOp = true
+	}
+}
+
+// WithIdentifierValidator runs every column name, operator and raw SQL fragment that query options insert
+// into the SQL text verbatim (Where, WhereIn, OrderBy, Join, WhereSubquery, Lock, GroupedAggregate
+// expressions, ...) through validate before the query is built. A non-nil error rejects the option, e.g.
+// to centrally forbid semicolons and comments or to allow only whitelisted functions as a defense-in-depth
+// layer on top of parameterized values.
+func WithIdentifierValidator(validate func(expr string) error) RepositoryOption {
+	return func(c *repositoryConfig) {
+		c.validator = validate
+	}
+}
@@ -0,0 +1,96 @@
+package crud
+
+import "strings"
+
+// commonReservedWords lists the keywords, reserved by at least one supported database, that are likely to
+// be used as table or column names. Dialects without a ReservedWordChecker quote these.
+var commonReservedWords = map[string]bool{
+	"all": true, "alter": true, "analyze": true, "and": true, "any": true, "as": true, "asc": true,
+	"between": true, "by": true, "case": true, "check": true, "collate": true, "column": true,
+	"constraint": true, "create": true, "cross": true, "current_date": true, "current_time": true,
+	"current_timestamp": true, "current_user": true, "database": true, "default": true, "delete": true,
+	"desc": true, "distinct": true, "drop": true, "else": true, "end": true, "except": true, "exists": true,
+	"fetch": true, "for": true, "foreign": true, "from": true, "full": true, "grant": true, "group": true,
+	"having": true, "in": true, "index": true, "inner": true, "insert": true, "intersect": true,
+	"interval": true, "into": true, "is": true, "join": true, "key": true, "keys": true, "left": true,
+	"like": true, "limit": true, "match": true, "natural": true, "not": true, "null": true, "of": true,
+	"offset": true, "on": true, "option": true, "or": true, "order": true, "outer": true, "primary": true,
+	"range": true, "rank": true, "references": true, "right": true, "row": true, "rows": true,
+	"schema": true, "select": true, "set": true, "table": true, "then": true, "to": true, "union": true,
+	"unique": true, "update": true, "user": true, "using": true, "values": true, "when": true,
+	"where": true, "window": true, "with": true,
+}
+
+// mysqlReservedWords adds MySQL's reserved words that are plausible column names to commonReservedWords.
+var mysqlReservedWords = map[string]bool{
+	"accessible": true, "both": true, "change": true, "condition": true, "div": true, "dual": true,
+	"explain": true, "fulltext": true, "generated": true, "ignore": true, "infile": true, "int": true,
+	"integer": true, "kill": true, "leading": true, "linear": true, "lines": true, "load": true,
+	"lock": true, "long": true, "maxvalue": true, "mod": true, "optimize": true, "partition": true,
+	"purge": true, "read": true, "reads": true, "release": true, "rename": true, "repeat": true,
+	"replace": true, "require": true, "return": true, "rlike": true, "show": true, "signal": true,
+	"spatial": true, "sql": true, "starting": true, "system": true, "terminated": true, "trailing": true,
+	"trigger": true, "undo": true, "unlock": true, "unsigned": true, "usage": true, "use": true,
+	"varying": true, "virtual": true, "while": true, "write": true, "xor": true, "zerofill": true,
+}
+
+// postgresReservedWords adds PostgreSQL's reserved key words to commonReservedWords.
+var postgresReservedWords = map[string]bool{
+	"analyse": true, "array": true, "asymmetric": true, "authorization": true, "binary": true, "both": true,
+	"cast": true, "collation": true, "concurrently": true, "current_catalog": true, "current_role": true,
+	"deferrable": true, "do": true, "freeze": true, "ilike": true, "initially": true, "isnull": true,
+	"lateral": true, "leading": true, "localtime": true, "localtimestamp": true, "notnull": true,
+	"only": true, "overlaps": true, "placing": true, "returning": true, "session_user": true,
+	"similar": true, "some": true, "symmetric": true, "tablesample": true, "trailing": true,
+	"variadic": true, "verbose": true,
+}
+
+// sqlServerReservedWords adds the reserved keywords of Transact-SQL to commonReservedWords.
+var sqlServerReservedWords = map[string]bool{
+	"backup": true, "break": true, "browse": true, "bulk": true, "cascade": true, "checkpoint": true,
+	"close": true, "clustered": true, "compute": true, "contains": true, "continue": true, "convert": true,
+	"current": true, "cursor": true, "deallocate": true, "declare": true, "deny": true, "disk": true,
+	"distributed": true, "dump": true, "escape": true, "exec": true, "execute": true, "exit": true,
+	"external": true, "file": true, "fillfactor": true, "function": true, "goto": true, "holdlock": true,
+	"identity": true, "identitycol": true, "if": true, "kill": true, "lineno": true, "load": true,
+	"merge": true, "national": true, "nocheck": true, "nonclustered": true, "off": true, "offsets": true,
+	"open": true, "over": true, "percent": true, "pivot": true, "plan": true, "precision": true,
+	"print": true, "proc": true, "procedure": true, "public": true, "raiserror": true, "read": true,
+	"readtext": true, "reconfigure": true, "replication": true, "restore": true, "restrict": true,
+	"return": true, "revert": true, "revoke": true, "rollback": true, "rowcount": true, "rowguidcol": true,
+	"rule": true, "save": true, "session_user": true, "setuser": true, "shutdown": true, "some": true,
+	"statistics": true, "system_user": true, "tablesample": true, "textsize": true, "top": true,
+	"tran": true, "transaction": true, "trigger": true, "truncate": true, "unpivot": true,
+	"updatetext": true, "use": true, "varying": true, "view": true, "waitfor": true, "while": true,
+	"writetext": true,
+}
+
+// sqliteReservedWords adds the SQLite keywords that cannot be used as plain identifiers to commonReservedWords.
+var sqliteReservedWords = map[string]bool{
+	"abort": true, "autoincrement": true, "glob": true, "indexed": true, "isnull": true, "notnull": true,
+	"pragma": true, "raise": true, "regexp": true, "transaction": true, "vacuum": true,
+}
+
+// IsReserved reports whether word is reserved by MySQL and must be quoted as an identifier.
+func (d MySQLDialect) IsReserved(word string) bool {
+	w := strings.ToLower(word)
+	return commonReservedWords[w] || mysqlReservedWords[w]
+}
+
+// IsReserved reports whether word is reserved by PostgreSQL and must be quoted as an identifier.
+func (d PostgresDialect) IsReserved(word string) bool {
+	w := strings.ToLower(word)
+	return commonReservedWords[w] || postgresReservedWords[w]
+}
+
+// IsReserved reports whether word is reserved by SQL Server and must be quoted as an identifier.
+func (d SQLServerDialect) IsReserved(word string) bool {
+	w := strings.ToLower(word)
+	return commonReservedWords[w] || sqlServerReservedWords[w]
+}
+
+// IsReserved reports whether word is reserved by SQLite and must be quoted as an identifier.
+func (d SQLiteDialect) IsReserved(word string) bool {
+	w := strings.ToLower(word)
+	return commonReservedWords[w] || sqliteReservedWords[w]
+}
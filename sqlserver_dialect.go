@@ -0,0 +1,323 @@
+package crud
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// SQLServerDialect implements Dialect for Microsoft SQL Server.
+//
+// SQL Server drivers do not implement LastInsertId, so inserts into tables with an auto-increment (IDENTITY)
+// primary key select the generated ID with SCOPE_IDENTITY() in the same batch.
+type SQLServerDialect struct{}
+
+// Placeholder returns the placeholder for the given index (e.g., @p1, @p2).
+func (d SQLServerDialect) Placeholder(idx int) string {
+	return fmt.Sprintf("@p%d", idx)
+}
+
+// InsertSQL generates the INSERT statement for SQL Server.
+func (d SQLServerDialect) InsertSQL(tableName string, cols, placeholders []string) string {
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", tableName, strings.Join(cols, ", "), strings.Join(placeholders, ", "))
+}
+
+// BulkInsertSQL generates the multi-row INSERT statement for SQL Server.
+func (d SQLServerDialect) BulkInsertSQL(tableName string, cols []string, rows [][]string) string {
+	return DefaultBulkInsertSQL(tableName, cols, rows)
+}
+
+// UpdateSQL generates the UPDATE statement for SQL Server.
+func (d SQLServerDialect) UpdateSQL(tableName string, setClauses string, pkColumn string, pkPlaceholder string) string {
+	return fmt.Sprintf("UPDATE %s SET %s WHERE %s = %s", tableName, setClauses, pkColumn, pkPlaceholder)
+}
+
+// SelectSQL generates the SELECT statement for SQL Server. Pagination uses OFFSET n ROWS FETCH NEXT m ROWS ONLY,
+// which is only valid after an ORDER BY, so ORDER BY (SELECT NULL) is added when the query is not ordered.
+// Row locks are expressed as table hints after the table name (see sqlServerTableHint) instead of a
+// trailing clause.
+func (d SQLServerDialect) SelectSQL(
+	tableName string, cols []string, joins, whereClause, orderByClause, lockClause string, limit, offset int,
+) string {
+	sql := fmt.Sprintf("SELECT %s FROM %s", strings.Join(cols, ", "), tableName)
+	if hint := sqlServerTableHint(lockClause); hint != "" {
+		sql += " " + hint
+	}
+	if joins != "" {
+		sql += " " + joins
+	}
+	if whereClause != "" {
+		sql += " WHERE " + whereClause
+	}
+	return sql + d.OrderLimitSQL(orderByClause, limit, offset)
+}
+
+// OrderLimitSQL returns the ORDER BY clause followed by OFFSET n ROWS FETCH NEXT m ROWS ONLY. Since OFFSET
+// is only valid after an ORDER BY, ORDER BY (SELECT NULL) is used when a paginated query is not ordered.
+func (d SQLServerDialect) OrderLimitSQL(orderByClause string, limit, offset int) string {
+	var sql string
+	paginated := limit > 0 || offset > 0
+	if orderByClause != "" {
+		sql = " ORDER BY " + orderByClause
+	} else if paginated {
+		sql = " ORDER BY (SELECT NULL)"
+	}
+	if paginated {
+		sql += fmt.Sprintf(" OFFSET %d ROWS", max(offset, 0))
+		if limit > 0 {
+			sql += fmt.Sprintf(" FETCH NEXT %d ROWS ONLY", limit)
+		}
+	}
+	return sql
+}
+
+// sqlServerTableHint translates the common row-locking clauses into SQL Server table hints. Any other
+// non-empty clause is assumed to be a table hint already, e.g. "WITH (UPDLOCK)".
+func sqlServerTableHint(lockClause string) string {
+	switch strings.ToUpper(strings.Join(strings.Fields(lockClause), " ")) {
+	case "":
+		return ""
+	case "FOR UPDATE":
+		return "WITH (UPDLOCK, ROWLOCK)"
+	case "FOR UPDATE NOWAIT":
+		return "WITH (UPDLOCK, ROWLOCK, NOWAIT)"
+	case "FOR UPDATE SKIP LOCKED":
+		return "WITH (UPDLOCK, ROWLOCK, READPAST)"
+	case "FOR SHARE":
+		return "WITH (HOLDLOCK, ROWLOCK)"
+	}
+	return lockClause
+}
+
+// DeleteSQL generates the DELETE statement for SQL Server.
+func (d SQLServerDialect) DeleteSQL(tableName string, pkColumn string, pkPlaceholder string) string {
+	return fmt.Sprintf("DELETE FROM %s WHERE %s = %s", tableName, pkColumn, pkPlaceholder)
+}
+
+// UpsertSQL generates a MERGE statement for SQL Server that inserts the row or overwrites every column but
+// the primary key of the existing one.
+func (d SQLServerDialect) UpsertSQL(tableName string, pkColumn string, cols []string) string {
+	return d.UpsertColumnsSQL(tableName, pkColumn, cols, nonKeyColumns(cols, pkColumn))
+}
+
+// UpsertColumnsSQL generates a MERGE statement for SQL Server that inserts the row or overwrites only
+// updateCols of the existing one. With no updateCols an existing row is left untouched.
+func (d SQLServerDialect) UpsertColumnsSQL(tableName string, pkColumn string, cols, updateCols []string) string {
+	placeholders := make([]string, len(cols))
+	sourceCols := make([]string, len(cols))
+	for i, col := range cols {
+		placeholders[i] = d.Placeholder(i + 1)
+		sourceCols[i] = "source." + col
+	}
+
+	sql := fmt.Sprintf("MERGE INTO %s AS target USING (VALUES (%s)) AS source (%s) ON target.%s = source.%s",
+		tableName,
+		strings.Join(placeholders, ", "),
+		strings.Join(cols, ", "),
+		pkColumn, pkColumn,
+	)
+	if len(updateCols) > 0 {
+		updateClauses := make([]string, len(updateCols))
+		for i, col := range updateCols {
+			updateClauses[i] = fmt.Sprintf("target.%s = source.%s", col, col)
+		}
+		sql += " WHEN MATCHED THEN UPDATE SET " + strings.Join(updateClauses, ", ")
+	}
+	// MERGE must be terminated with a semicolon
+	return sql + fmt.Sprintf(" WHEN NOT MATCHED THEN INSERT (%s) VALUES (%s);",
+		strings.Join(cols, ", "),
+		strings.Join(sourceCols, ", "),
+	)
+}
+
+// Collate returns the COLLATE clause for SQL Server (e.g., COLLATE Latin1_General_CI_AS).
+func (d SQLServerDialect) Collate(collation string) string {
+	return "COLLATE " + collation
+}
+
+// SupportsJoinType reports whether SQL Server supports the join kind. All standard joins are supported.
+func (d SQLServerDialect) SupportsJoinType(kind string) bool {
+	switch strings.ToUpper(kind) {
+	case JoinInner, JoinLeft, JoinRight, JoinFull, JoinCross:
+		return true
+	}
+	return false
+}
+
+// PartitionTable returns an error: SQL Server selects partitions with $PARTITION predicates, not in FROM.
+func (d SQLServerDialect) PartitionTable(tableName, partition string) (string, error) {
+	return "", fmt.Errorf("partition selection is not supported by SQL Server: %w", errors.ErrUnsupported)
+}
+
+// NotifySQL is not supported by SQL Server, which has no LISTEN/NOTIFY mechanism.
+func (d SQLServerDialect) NotifySQL() (string, error) {
+	return "", fmt.Errorf("change notifications are not supported by SQL Server: %w", errors.ErrUnsupported)
+}
+
+// LockTimeoutSQL returns the statement that bounds lock waits for the session, in milliseconds.
+func (d SQLServerDialect) LockTimeoutSQL(timeout time.Duration) (string, error) {
+	return fmt.Sprintf("SET LOCK_TIMEOUT %d", max(timeout.Milliseconds(), 1)), nil
+}
+
+// ResetLockTimeoutSQL restores the default of waiting for locks indefinitely, so that the session setting
+// of LockTimeoutSQL does not stay on the pooled connection.
+func (d SQLServerDialect) ResetLockTimeoutSQL() string {
+	return "SET LOCK_TIMEOUT -1"
+}
+
+// LastInsertIDSQL selects the IDENTITY value generated by the INSERT it follows: SQL Server drivers do not
+// implement LastInsertId.
+func (d SQLServerDialect) LastInsertIDSQL() string {
+	return "; SELECT CAST(SCOPE_IDENTITY() AS BIGINT)"
+}
+
+// SavepointSQL returns SAVE TRANSACTION, SQL Server's form of SAVEPOINT.
+func (d SQLServerDialect) SavepointSQL(name string) string {
+	return "SAVE TRANSACTION " + name
+}
+
+// ReleaseSavepointSQL returns no statement: SQL Server savepoints cannot be released and simply end with
+// the transaction.
+func (d SQLServerDialect) ReleaseSavepointSQL(name string) string {
+	return ""
+}
+
+// RollbackToSavepointSQL returns ROLLBACK TRANSACTION to the savepoint.
+func (d SQLServerDialect) RollbackToSavepointSQL(name string) string {
+	return "ROLLBACK TRANSACTION " + name
+}
+
+// LimitWithTiesSQL returns an error: SQL Server only offers WITH TIES on TOP, which cannot follow OFFSET.
+func (d SQLServerDialect) LimitWithTiesSQL(n int) (string, error) {
+	return "", fmt.Errorf("LimitWithTies is not supported by SQL Server: %w", errors.ErrUnsupported)
+}
+
+// SessionSettingSQL returns an error: WithSessionSetting only supports PostgreSQL settings.
+func (d SQLServerDialect) SessionSettingSQL(setting, value string) (string, error) {
+	return "", fmt.Errorf("session settings are not supported by SQL Server: %w", errors.ErrUnsupported)
+}
+
+// JSONContainsSQL returns an error: SQL Server has no JSON containment operator.
+func (d SQLServerDialect) JSONContainsSQL(column, placeholder string) (string, error) {
+	return "", fmt.Errorf("JSON containment is not supported by SQL Server: %w", errors.ErrUnsupported)
+}
+
+// MaxParameters returns the number of parameters SQL Server accepts in a single request.
+func (d SQLServerDialect) MaxParameters() int {
+	return 2100
+}
+
+// sqlServerUniqueRe matches the messages of errors 2627 (unique or primary key constraint) and
+// 2601 (unique index).
+var sqlServerUniqueRe = regexp.MustCompile(
+	`Violation of (?:UNIQUE KEY|PRIMARY KEY) constraint '([^']+)'|Cannot insert duplicate key row in object '[^']*' with unique index '([^']+)'`,
+)
+
+// IsUniqueViolation reports whether err is a unique constraint or unique index violation and returns the
+// constraint or index name.
+func (d SQLServerDialect) IsUniqueViolation(err error) (string, bool) {
+	m := sqlServerUniqueRe.FindStringSubmatch(err.Error())
+	if m == nil {
+		return "", false
+	}
+	return m[1] + m[2], true
+}
+
+// RowLockSQL returns the table hint for the lock mode. Skipping locked rows uses READPAST, which requires
+// REPEATABLEREAD rather than HOLDLOCK for shared locks.
+func (d SQLServerDialect) RowLockSQL(mode LockMode, skipLocked bool) (string, error) {
+	switch {
+	case mode == LockModeUpdate && skipLocked:
+		return "WITH (UPDLOCK, ROWLOCK, READPAST)", nil
+	case mode == LockModeUpdate:
+		return "WITH (UPDLOCK, ROWLOCK)", nil
+	case mode == LockModeShare && skipLocked:
+		return "WITH (REPEATABLEREAD, ROWLOCK, READPAST)", nil
+	case mode == LockModeShare:
+		return "WITH (HOLDLOCK, ROWLOCK)", nil
+	}
+	return "", fmt.Errorf("unknown lock mode %d", mode)
+}
+
+// QuoteIdentifier quotes each segment of name with brackets (users.order -> [users].[order]).
+func (d SQLServerDialect) QuoteIdentifier(name string) string {
+	return quoteSegments(name, "[", "]")
+}
+
+// CurrentTimestampSQL returns the current UTC time. CURRENT_TIMESTAMP would be the server's local time.
+func (d SQLServerDialect) CurrentTimestampSQL() string {
+	return "SYSUTCDATETIME()"
+}
+
+// ColumnType returns the SQL Server column type for values of goType. Strings map to NVARCHAR(255) so that
+// they can be indexed; other unknown types (e.g., maps or structs) map to NVARCHAR(MAX).
+func (d SQLServerDialect) ColumnType(goType reflect.Type) string {
+	t := columnGoType(goType)
+	if t == reflect.TypeFor[time.Time]() {
+		return "DATETIME2"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "BIT"
+	case reflect.Uint8:
+		return "TINYINT"
+	case reflect.Int8, reflect.Int16:
+		return "SMALLINT"
+	case reflect.Uint16, reflect.Int32:
+		return "INT"
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return "BIGINT"
+	case reflect.Float32:
+		return "REAL"
+	case reflect.Float64:
+		return "FLOAT"
+	case reflect.String:
+		return "NVARCHAR(255)"
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "VARBINARY(MAX)"
+		}
+	}
+	return "NVARCHAR(MAX)"
+}
+
+// AutoIncrementSQL returns the IDENTITY property of an auto-incremented primary key.
+func (d SQLServerDialect) AutoIncrementSQL() string {
+	return "IDENTITY(1,1)"
+}
+
+// CreateTableSQL generates a CREATE TABLE guarded by an OBJECT_ID check, since SQL Server has no
+// CREATE TABLE IF NOT EXISTS.
+func (d SQLServerDialect) CreateTableSQL(tableName string, columnDefs []string) string {
+	return fmt.Sprintf("IF OBJECT_ID(N'%s', N'U') IS NULL CREATE TABLE %s (%s)",
+		strings.ReplaceAll(tableName, "'", "''"), tableName, strings.Join(columnDefs, ", "))
+}
+
+// TrueLiteral returns 1, the value of a true BIT: SQL Server has no boolean literals.
+func (d SQLServerDialect) TrueLiteral() string {
+	return "1"
+}
+
+// FalseLiteral returns 0.
+func (d SQLServerDialect) FalseLiteral() string {
+	return "0"
+}
+
+// NullLiteral returns NULL.
+func (d SQLServerDialect) NullLiteral() string {
+	return "NULL"
+}
+
+// SupportsReturning returns false: SQL Server's OUTPUT clause is not used, and generated IDs are selected
+// with SCOPE_IDENTITY() instead.
+func (d SQLServerDialect) SupportsReturning() bool {
+	return false
+}
+
+// InsertReturningSQL returns insertSQL unchanged, since SupportsReturning is false.
+func (d SQLServerDialect) InsertReturningSQL(insertSQL string, returningCols []string) string {
+	return insertSQL
+}
@@ -0,0 +1,169 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SyncInsertMissing inserts the items whose keyColumns values do not exist in the table yet and returns the
+// number of rows inserted. It is meant for reconciling a desired set of rows against a table with a unique
+// key: existing keys are looked up in chunks, and the missing items are inserted with CreateMany. Items that
+// repeat a key within items are only inserted once (the first occurrence wins).
+//
+// Everything runs in a single transaction (the repository's own if it was created with WithTx). Soft-deleted
+// records count as existing, since they still hold their key. Keys are compared by their Go values, so the
+// key columns should map to plain scalar fields such as strings and integers.
+func (r *Repository[T]) SyncInsertMissing(ctx context.Context, items []T, keyColumns []string) (int64, error) {
+	if len(keyColumns) == 0 {
+		return 0, fmt.Errorf("SyncInsertMissing requires at least one key column")
+	}
+	byColumn := make(map[string]fieldInfo, len(r.fields))
+	for _, f := range r.fields {
+		byColumn[f.columnName] = f
+	}
+	keyFields := make([]fieldInfo, len(keyColumns))
+	for i, col := range keyColumns {
+		f, ok := byColumn[col]
+		if !ok {
+			return 0, fmt.Errorf("unknown column '%s' for table %s in SyncInsertMissing", col, r.tableName)
+		}
+		keyFields[i] = f
+	}
+	if len(items) == 0 {
+		return 0, nil
+	}
+
+	if r.tx != nil {
+		return r.insertMissing(ctx, items, keyFields)
+	}
+
+	tx, err := r.beginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	txRepo := *r
+	txRepo.tx = tx
+	inserted, err := txRepo.insertMissing(ctx, items, keyFields)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return inserted, nil
+}
+
+// insertMissing looks up which keys of items already exist and inserts the remaining items.
+func (r *Repository[T]) insertMissing(ctx context.Context, items []T, keyFields []fieldInfo) (int64, error) {
+	chunkSize := rowsPerStatement(r.dialect, len(keyFields))
+	existing := make(map[string]bool, len(items))
+	for start := 0; start < len(items); start += chunkSize {
+		if err := r.collectExistingKeys(ctx, items[start:min(start+chunkSize, len(items))], keyFields, existing); err != nil {
+			return 0, err
+		}
+	}
+
+	var missing []T
+	for i := range items {
+		key := syncKey(reflect.ValueOf(&items[i]).Elem(), keyFields)
+		if existing[key] {
+			continue
+		}
+		existing[key] = true
+		missing = append(missing, items[i])
+	}
+	if len(missing) == 0 {
+		return 0, nil
+	}
+
+	created, err := r.CreateMany(ctx, missing)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(created)), nil
+}
+
+// collectExistingKeys adds the keys of items that are already present in the table to existing.
+func (r *Repository[T]) collectExistingKeys(ctx context.Context, items []T, keyFields []fieldInfo, existing map[string]bool) error {
+	cols := make([]string, len(keyFields))
+	for i, f := range keyFields {
+		cols[i] = r.quote(f.columnName)
+	}
+
+	args := make([]any, 0, len(items)*len(keyFields))
+	conditions := make([]string, len(items))
+	for i := range items {
+		val := reflect.ValueOf(&items[i]).Elem()
+		parts := make([]string, len(keyFields))
+		for j, f := range keyFields {
+			value, err := encodeField(val, f)
+			if err != nil {
+				return err
+			}
+			args = append(args, value)
+			parts[j] = fmt.Sprintf("%s = %s", cols[j], r.dialect.Placeholder(len(args)))
+		}
+		conditions[i] = strings.Join(parts, " AND ")
+	}
+
+	var where string
+	if len(keyFields) == 1 {
+		placeholders := make([]string, len(args))
+		for i := range args {
+			placeholders[i] = r.dialect.Placeholder(i + 1)
+		}
+		where = fmt.Sprintf("%s IN (%s)", cols[0], strings.Join(placeholders, ", "))
+	} else {
+		where = "(" + strings.Join(conditions, ") OR (") + ")"
+	}
+
+	query := r.dialect.SelectSQL(r.quote(r.tableName), cols, "", where, "", "", 0, 0)
+	e, err := r.getExecutor(ctx)
+	if err != nil {
+		return err
+	}
+	qctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	rows, err := e.QueryContext(qctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to look up existing keys: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var instance T
+		val := reflect.ValueOf(&instance).Elem()
+		dest := make([]any, len(keyFields))
+		for i, f := range keyFields {
+			if f.transformer != nil {
+				dest[i] = new(any)
+				continue
+			}
+			dest[i] = val.FieldByIndex(f.index).Addr().Interface()
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		for i, f := range keyFields {
+			if f.transformer != nil {
+				if err := decodeField(val, f, *dest[i].(*any)); err != nil {
+					return err
+				}
+			}
+		}
+		existing[syncKey(val, keyFields)] = true
+	}
+	return rows.Err()
+}
+
+// syncKey renders the key field values of a record as a comparable string.
+func syncKey(val reflect.Value, keyFields []fieldInfo) string {
+	var b strings.Builder
+	for _, f := range keyFields {
+		fmt.Fprintf(&b, "%v\x00", val.FieldByIndex(f.index).Interface())
+	}
+	return b.String()
+}
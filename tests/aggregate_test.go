@@ -0,0 +1,165 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type PostsPerUser struct {
+	UserID int    `db:"user_id"`
+	Total  int64  `db:"total"`
+	Last   string `db:"last_title"`
+}
+
+func TestGroupedAggregate(t *testing.T) {
+	db := setupTestDBWithPosts(t)
+	defer db.Close()
+
+	postRepo, err := crud.NewRepository[Post](db, "posts", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = postRepo.CreateMany(ctx, []Post{
+		{UserID: 1, Title: "a"},
+		{UserID: 1, Title: "b"},
+		{UserID: 2, Title: "c"},
+		{UserID: 3, Title: "d"},
+	})
+	require.NoError(t, err)
+
+	stats, err := crud.GroupedAggregate[Post, PostsPerUser](ctx, postRepo,
+		[]string{"user_id"},
+		[]string{"COUNT(*) AS total", "MAX(title) AS last_title"},
+		postRepo.Where("user_id", "<", 3),
+		postRepo.OrderBy("user_id", crud.SortAsc),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []PostsPerUser{
+		{UserID: 1, Total: 2, Last: "b"},
+		{UserID: 2, Total: 1, Last: "c"},
+	}, stats)
+
+	_, err = crud.GroupedAggregate[Post, PostsPerUser](ctx, postRepo, []string{"author"}, []string{"COUNT(*) AS total"})
+	assert.ErrorContains(t, err, "unknown group column 'author'")
+
+	// Every result column needs a matching tag
+	_, err = crud.GroupedAggregate[Post, PostsPerUser](ctx, postRepo, []string{"user_id"}, []string{"COUNT(*) AS n"})
+	assert.ErrorContains(t, err, "column 'n' has no matching db tag")
+}
+
+type Order struct {
+	ID     int     `db:"id,pk"`
+	UserID int     `db:"user_id"`
+	Amount float64 `db:"amount"`
+}
+
+func setupOrdersRepo(t *testing.T) crud.RepositoryInterface[Order] {
+	db := setupTestDB(t)
+	t.Cleanup(func() { db.Close() })
+	_, err := db.Exec(`CREATE TABLE orders (id INTEGER PRIMARY KEY AUTOINCREMENT, user_id INTEGER, amount REAL)`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Order](db, "orders", crud.SQLiteDialect{})
+	require.NoError(t, err)
+	return repo
+}
+
+func TestSumAvgMinMax(t *testing.T) {
+	repo := setupOrdersRepo(t)
+	ctx := context.Background()
+
+	_, err := repo.CreateMany(ctx, []Order{
+		{UserID: 1, Amount: 10},
+		{UserID: 1, Amount: 20},
+		{UserID: 2, Amount: 60},
+	})
+	require.NoError(t, err)
+
+	sum, err := repo.Sum(ctx, "amount")
+	require.NoError(t, err)
+	assert.Equal(t, 90.0, sum)
+
+	avg, err := repo.Avg(ctx, "amount", repo.Where("user_id", "=", 1))
+	require.NoError(t, err)
+	assert.Equal(t, 15.0, avg)
+
+	minAmount, err := repo.Min(ctx, "amount")
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, minAmount)
+
+	maxAmount, err := repo.Max(ctx, "amount", repo.Where("user_id", "=", 1))
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, maxAmount)
+
+	// No matching rows: the NULL aggregate is reported as 0
+	sum, err = repo.Sum(ctx, "amount", repo.Where("user_id", "=", 99))
+	require.NoError(t, err)
+	assert.Zero(t, sum)
+
+	avg, err = repo.Avg(ctx, "amount", repo.Where("user_id", "=", 99))
+	require.NoError(t, err)
+	assert.Zero(t, avg)
+
+	_, err = repo.Sum(ctx, "price")
+	assert.ErrorContains(t, err, "unknown column 'price'")
+}
+
+func TestMinMaxRejectNonNumericColumns(t *testing.T) {
+	db := setupTestDBWithPosts(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[Post](db, "posts", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = repo.Create(ctx, Post{UserID: 1, Title: "hello"})
+	require.NoError(t, err)
+
+	_, err = repo.Max(ctx, "title")
+	assert.EqualError(t, err, "MAX requires a numeric column, but 'title' is mapped to string; use GroupedAggregate or a raw query")
+	_, err = repo.MinNull(ctx, "title")
+	assert.ErrorContains(t, err, "MIN requires a numeric column")
+
+	maxUser, err := repo.Max(ctx, "user_id")
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), maxUser)
+}
+
+func TestAggregateNull(t *testing.T) {
+	repo := setupOrdersRepo(t)
+	ctx := context.Background()
+
+	// Empty result set: the aggregate is NULL
+	sum, err := repo.SumNull(ctx, "amount")
+	require.NoError(t, err)
+	assert.False(t, sum.Valid)
+
+	maxAmount, err := repo.MaxNull(ctx, "amount")
+	require.NoError(t, err)
+	assert.False(t, maxAmount.Valid)
+
+	_, err = repo.CreateMany(ctx, []Order{
+		{UserID: 1, Amount: 5},
+		{UserID: 1, Amount: -5},
+	})
+	require.NoError(t, err)
+
+	// A genuine zero sum stays distinguishable from "no rows"
+	sum, err = repo.SumNull(ctx, "amount")
+	require.NoError(t, err)
+	assert.Equal(t, sql.NullFloat64{Float64: 0, Valid: true}, sum)
+
+	avg, err := repo.AvgNull(ctx, "amount", repo.Where("user_id", "=", 2))
+	require.NoError(t, err)
+	assert.False(t, avg.Valid)
+
+	minAmount, err := repo.MinNull(ctx, "amount")
+	require.NoError(t, err)
+	assert.Equal(t, sql.NullFloat64{Float64: -5, Valid: true}, minAmount)
+}
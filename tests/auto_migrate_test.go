@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Prototype struct {
+	ID        int64          `db:"id,pk"`
+	Name      string         `db:"name"`
+	Score     float64        `db:"score"`
+	Active    bool           `db:"active"`
+	Payload   []byte         `db:"payload"`
+	Note      sql.NullString `db:"note"`
+	DueAt     *time.Time     `db:"due_at"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+func TestAutoMigrateCreatesTable(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	repo, err := crud.NewRepository[Prototype](db, "prototypes", crud.SQLiteDialect{}, crud.WithQueryRecorder(1))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, repo.AutoMigrate(ctx))
+	assert.Equal(t, "CREATE TABLE IF NOT EXISTS prototypes (id INTEGER PRIMARY KEY, name TEXT NOT NULL, "+
+		"score REAL NOT NULL, active INTEGER NOT NULL, payload BLOB, note TEXT, due_at DATETIME, created_at DATETIME NOT NULL)",
+		repo.LastQueries()[0].SQL)
+	// Running it again leaves the existing table alone
+	require.NoError(t, repo.AutoMigrate(ctx))
+
+	now := time.Now().UTC().Truncate(time.Second)
+	created, err := repo.Create(ctx, Prototype{Name: "first", Score: 1.5, Active: true, CreatedAt: now})
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+
+	got, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "first", got.Name)
+	assert.True(t, got.Active)
+	assert.False(t, got.Note.Valid)
+	assert.Nil(t, got.DueAt)
+	assert.True(t, now.Equal(got.CreatedAt))
+
+	// NOT NULL is enforced for non-nullable fields
+	_, err = db.Exec(`INSERT INTO prototypes (name) VALUES ('incomplete')`)
+	assert.Error(t, err)
+}
+
+func TestAutoMigrateQuotesReservedNames(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[ReservedRow](db, "order", crud.SQLiteDialect{}, crud.WithQueryRecorder(1))
+	require.NoError(t, err)
+	require.NoError(t, repo.AutoMigrate(context.Background()))
+	assert.Equal(t, `CREATE TABLE IF NOT EXISTS "order" (id INTEGER PRIMARY KEY, "select" TEXT NOT NULL, "group" TEXT NOT NULL)`,
+		repo.LastQueries()[0].SQL)
+}
+
+func TestDialectColumnType(t *testing.T) {
+	cases := []struct {
+		goType                             reflect.Type
+		mysql, postgres, sqlite, sqlserver string
+	}{
+		{reflect.TypeFor[int64](), "BIGINT", "bigint", "INTEGER", "BIGINT"},
+		{reflect.TypeFor[*int32](), "INT", "integer", "INTEGER", "INT"},
+		{reflect.TypeFor[uint8](), "TINYINT UNSIGNED", "smallint", "INTEGER", "TINYINT"},
+		{reflect.TypeFor[bool](), "BOOLEAN", "boolean", "INTEGER", "BIT"},
+		{reflect.TypeFor[float64](), "DOUBLE", "double precision", "REAL", "FLOAT"},
+		{reflect.TypeFor[string](), "VARCHAR(255)", "text", "TEXT", "NVARCHAR(255)"},
+		{reflect.TypeFor[[]byte](), "BLOB", "bytea", "BLOB", "VARBINARY(MAX)"},
+		{reflect.TypeFor[time.Time](), "DATETIME(6)", "timestamptz", "DATETIME", "DATETIME2"},
+		{reflect.TypeFor[sql.NullInt64](), "BIGINT", "bigint", "INTEGER", "BIGINT"},
+		{reflect.TypeFor[sql.Null[time.Time]](), "DATETIME(6)", "timestamptz", "DATETIME", "DATETIME2"},
+		{reflect.TypeFor[map[string]any](), "TEXT", "text", "TEXT", "NVARCHAR(MAX)"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.mysql, crud.MySQLDialect{}.ColumnType(c.goType), c.goType.String())
+		assert.Equal(t, c.postgres, crud.PostgresDialect{}.ColumnType(c.goType), c.goType.String())
+		assert.Equal(t, c.sqlite, crud.SQLiteDialect{}.ColumnType(c.goType), c.goType.String())
+		assert.Equal(t, c.sqlserver, crud.SQLServerDialect{}.ColumnType(c.goType), c.goType.String())
+	}
+}
+
+func TestSQLServerCreateTableSQL(t *testing.T) {
+	sql := crud.SQLServerDialect{}.CreateTableSQL("widgets", []string{"id BIGINT IDENTITY(1,1) PRIMARY KEY"})
+	assert.Equal(t, "IF OBJECT_ID(N'widgets', N'U') IS NULL CREATE TABLE widgets (id BIGINT IDENTITY(1,1) PRIMARY KEY)", sql)
+}
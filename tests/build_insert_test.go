@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/dimatock/crud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildInsert(t *testing.T) {
+	// No database connection is needed to build the statement
+	repo, err := crud.NewRepository[User](nil, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	query, args, err := repo.BuildInsert(User{ID: 7, Username: "john", Email: "john@example.com"})
+	require.NoError(t, err)
+
+	// The auto-increment PK is omitted and columns follow struct field order
+	assert.Equal(t, "INSERT INTO users (username, email) VALUES (?, ?)", query)
+	assert.Equal(t, []any{"john", "john@example.com"}, args)
+}
+
+func TestBuildInsert_UserProvidedPK(t *testing.T) {
+	repo, err := crud.NewRepository[UUIDModel](nil, "uuid_models", crud.MySQLDialect{})
+	require.NoError(t, err)
+
+	query, args, err := repo.BuildInsert(UUIDModel{ID: "abc", Data: "payload"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "INSERT INTO uuid_models (id, data) VALUES (?, ?)", query)
+	assert.Equal(t, []any{"abc", "payload"}, args)
+}
+
+func TestBuildInsert_Postgres(t *testing.T) {
+	repo, err := crud.NewRepository[User](nil, "users", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	query, args, err := repo.BuildInsert(User{Username: "john", Email: "john@example.com"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "INSERT INTO users (username, email) VALUES ($1, $2) RETURNING id, username, email", query)
+	assert.Equal(t, []any{"john", "john@example.com"}, args)
+}
@@ -0,0 +1,179 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dialectFeatures groups the capabilities that every built-in dialect implements, if only to report that the
+// feature is unsupported.
+type dialectFeatures interface {
+	crud.BulkInserter
+	crud.Collator
+	crud.JoinTypeChecker
+	crud.Partitioner
+	crud.Notifier
+	crud.LockTimeoutSetter
+	crud.TiesLimiter
+	crud.SessionSetter
+	crud.JSONMatcher
+	crud.ColumnTyper
+	crud.ColumnUpserter
+}
+
+// The built-in dialects implement every optional capability.
+var (
+	_ dialectFeatures = crud.PostgresDialect{}
+	_ dialectFeatures = crud.MySQLDialect{}
+	_ dialectFeatures = crud.SQLiteDialect{}
+	_ dialectFeatures = crud.SQLServerDialect{}
+	_ interface {
+		crud.ParameterLimiter
+		crud.UniqueViolationDetector
+		crud.RowLocker
+		crud.IdentifierQuoter
+		crud.ReservedWordChecker
+		crud.TimestampProvider
+		crud.TableCreator
+		crud.LiteralProvider
+		crud.ReturningInserter
+		crud.OrderLimiter
+		crud.ReturningDeleter
+		crud.WindowCounter
+		crud.ValuesLister
+		crud.CostEstimator
+	} = crud.PostgresDialect{}
+	_ interface {
+		crud.OrderLimiter
+		crud.ReturningInserter
+		crud.ReturningDeleter
+		crud.FirstInsertIDReporter
+		crud.LockTimeoutResetter
+		crud.CostEstimator
+	} = crud.MySQLDialect{}
+	_ interface {
+		crud.OrderLimiter
+		crud.ReturningInserter
+		crud.ReturningDeleter
+	} = crud.SQLiteDialect{}
+	_ interface {
+		crud.ReturningInserter
+		crud.OrderLimiter
+		crud.InsertIDSelector
+		crud.Savepointer
+		crud.LockTimeoutResetter
+		crud.ReservedWordChecker
+	} = crud.SQLServerDialect{}
+	_ crud.ReservedWordChecker = crud.MySQLDialect{}
+	_ crud.ReservedWordChecker = crud.SQLiteDialect{}
+)
+
+// minimalDialect only exposes the methods of the Dialect interface, like a third-party dialect that
+// implements none of the optional capabilities.
+type minimalDialect struct {
+	crud.Dialect
+}
+
+func TestDialectWithoutOptionalCapabilities(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", minimalDialect{crud.SQLiteDialect{}})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	created, err := repo.Create(ctx, User{Username: "minimal", Email: "minimal@example.com"})
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+
+	users, err := repo.CreateMany(ctx, []User{
+		{Username: "bulk1", Email: "bulk1@example.com"},
+		{Username: "bulk2", Email: "bulk2@example.com"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+
+	// The upsert falls back to UpsertSQL
+	created.Email = "upserted@example.com"
+	upserted, err := repo.CreateOrUpdate(ctx, created)
+	require.NoError(t, err)
+	assert.Equal(t, "upserted@example.com", upserted.Email)
+
+	// No unique violation detection: the driver error is returned as it is
+	_, err = repo.Create(ctx, User{Username: "minimal", Email: "other@example.com"})
+	require.Error(t, err)
+	var dup *crud.ErrDuplicate
+	assert.False(t, errors.As(err, &dup))
+
+	// The portable defaults are used for literals, locks and the clock
+	query, _, err := repo.ToSQL(repo.WhereNull("email"), repo.Where("username", true), repo.LockForUpdate())
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT users.id, users.username, users.email FROM users WHERE email IS NULL AND username = TRUE FOR UPDATE", query)
+	query, _, err = repo.ToSQL(repo.WhereColumnOpNow("email", "<"))
+	require.NoError(t, err)
+	assert.Contains(t, query, "email < CURRENT_TIMESTAMP")
+	query, _, err = repo.ToSQL(repo.OrderByCollate("username", "NOCASE", crud.SortAsc), repo.FullJoin("posts", "posts.user_id = users.id"))
+	require.NoError(t, err)
+	assert.Contains(t, query, "FULL OUTER JOIN posts ON posts.user_id = users.id ORDER BY username COLLATE NOCASE ASC")
+
+	// Features without a portable form are reported as unsupported
+	for _, opt := range []crud.Option[User]{
+		repo.LimitWithTies(3),
+		repo.WhereJSONContains("email", map[string]any{"a": 1}),
+		crud.WithPartition[User]("p1"),
+		crud.WithSessionSetting[User]("statement_timeout", "1s"),
+	} {
+		_, _, err = repo.ToSQL(opt)
+		assert.ErrorIs(t, err, errors.ErrUnsupported)
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	_, err = repo.WithTx(tx).List(ctx, crud.WithLockTimeout[User](time.Second))
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+	require.NoError(t, tx.Rollback())
+	assert.ErrorIs(t, repo.AutoMigrate(ctx), errors.ErrUnsupported)
+	_, err = crud.NewRepository[User](db, "users", minimalDialect{crud.PostgresDialect{}}, crud.WithChangeNotify("users"))
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+}
+
+func TestMinimalDialectFallbacks(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	repo, err := crud.NewRepository[User](db, "users", minimalDialect{crud.PostgresDialect{}}, crud.WithQueryRecorder(2))
+	require.NoError(t, err)
+	sqlite, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+	_, err = sqlite.CreateMany(ctx, []User{{Username: "a", Email: "a@example.com"}, {Username: "b", Email: "b@example.com"}})
+	require.NoError(t, err)
+
+	// The capabilities of the wrapped PostgreSQL dialect are hidden: no COUNT(*) OVER(), VALUES list or EXPLAIN
+	_, err = repo.Paginate(ctx, 1, 10)
+	require.NoError(t, err)
+	queries := repo.LastQueries()
+	require.Len(t, queries, 2)
+	assert.NotContains(t, queries[0].SQL, "OVER()")
+	assert.Contains(t, queries[1].SQL, "COUNT(*)")
+
+	users, err := sqlite.List(ctx, sqlite.OrderBy("id", crud.SortAsc))
+	require.NoError(t, err)
+	users[0].Email = "a2@example.com"
+	users[1].Email = "b2@example.com"
+	n, err := repo.UpdateMany(ctx, users)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	for _, q := range repo.LastQueries() {
+		assert.NotContains(t, q.SQL, "VALUES")
+	}
+
+	_, err = repo.EstimateCost(ctx)
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+}
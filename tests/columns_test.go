@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumns(t *testing.T) {
+	db := setupTestDBWithPosts(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithQueryRecorder(1))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	created, err := repo.CreateMany(ctx, []User{
+		{Username: "alice", Email: "alice@example.com"},
+		{Username: "bob", Email: "bob@example.com"},
+	})
+	require.NoError(t, err)
+
+	users, err := repo.List(ctx, repo.Columns("id", "username"), repo.OrderBy("id", crud.SortAsc))
+	require.NoError(t, err)
+	assert.Equal(t, []User{
+		{ID: created[0].ID, Username: "alice"},
+		{ID: created[1].ID, Username: "bob"},
+	}, users)
+	assert.Equal(t, "SELECT users.id, users.username FROM users ORDER BY id ASC", repo.LastQueries()[0].SQL)
+
+	// The column order of the option does not need to follow the struct
+	user, err := repo.GetByID(ctx, created[1].ID, repo.Columns("email", "id"))
+	require.NoError(t, err)
+	assert.Equal(t, User{ID: created[1].ID, Email: "bob@example.com"}, user)
+
+	page, err := repo.Paginate(ctx, 1, 10, repo.Columns("username"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), page.Total)
+	assert.Equal(t, "alice", page.Items[0].Username)
+	assert.Zero(t, page.Items[0].ID)
+
+	// Selected columns are qualified, so they stay unambiguous in joins
+	_, err = db.Exec(`INSERT INTO posts (user_id, title) VALUES (?, 'hello')`, created[0].ID)
+	require.NoError(t, err)
+	users, err = repo.List(ctx, repo.Columns("id", "username"), repo.InnerJoin("posts", "posts.user_id = users.id"))
+	require.NoError(t, err)
+	assert.Equal(t, []User{{ID: created[0].ID, Username: "alice"}}, users)
+
+	_, err = repo.List(ctx, repo.Columns("id", "password"))
+	assert.ErrorContains(t, err, "unknown column 'password'")
+
+	_, err = repo.List(ctx, repo.Columns())
+	assert.Error(t, err)
+}
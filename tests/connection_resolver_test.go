@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tenantKey struct{}
+
+var errUnknownTenant = errors.New("unknown tenant")
+
+func tenantResolver(pools map[string]*sql.DB) func(ctx context.Context) (*sql.DB, error) {
+	return func(ctx context.Context) (*sql.DB, error) {
+		tenant, _ := ctx.Value(tenantKey{}).(string)
+		db, ok := pools[tenant]
+		if !ok {
+			return nil, errUnknownTenant
+		}
+		return db, nil
+	}
+}
+
+func TestConnectionResolver(t *testing.T) {
+	acme := setupTestDB(t)
+	defer acme.Close()
+	globex := setupTestDB(t)
+	defer globex.Close()
+
+	repo, err := crud.NewRepository[User](nil, "users", crud.SQLiteDialect{},
+		crud.WithConnectionResolver(tenantResolver(map[string]*sql.DB{"acme": acme, "globex": globex})))
+	require.NoError(t, err)
+
+	acmeCtx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	globexCtx := context.WithValue(context.Background(), tenantKey{}, "globex")
+
+	created, err := repo.Create(acmeCtx, User{Username: "wile", Email: "wile@acme.example"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, created.ID)
+
+	// SyncInsertMissing runs in a transaction, which must be started on the tenant's pool.
+	inserted, err := repo.SyncInsertMissing(globexCtx, []User{
+		{Username: "hank", Email: "hank@globex.example"},
+		{Username: "homer", Email: "homer@globex.example"},
+	}, []string{"username"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), inserted)
+
+	acmeUsers, err := repo.List(acmeCtx)
+	require.NoError(t, err)
+	require.Len(t, acmeUsers, 1)
+	assert.Equal(t, "wile", acmeUsers[0].Username)
+
+	count, err := repo.Count(globexCtx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	var rows int
+	require.NoError(t, acme.QueryRow(`SELECT COUNT(*) FROM users`).Scan(&rows))
+	assert.Equal(t, 1, rows)
+	require.NoError(t, globex.QueryRow(`SELECT COUNT(*) FROM users`).Scan(&rows))
+	assert.Equal(t, 2, rows)
+}
+
+func TestConnectionResolverErrors(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](nil, "users", crud.SQLiteDialect{},
+		crud.WithConnectionResolver(tenantResolver(map[string]*sql.DB{"acme": db})))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = repo.Create(ctx, User{Username: "nobody", Email: "nobody@example.com"})
+	assert.ErrorIs(t, err, errUnknownTenant)
+	_, err = repo.GetByID(ctx, 1)
+	assert.ErrorIs(t, err, errUnknownTenant)
+	_, err = repo.DeleteWhere(ctx, repo.Where("id", 1))
+	assert.ErrorIs(t, err, errUnknownTenant)
+
+	nilRepo, err := crud.NewRepository[User](nil, "users", crud.SQLiteDialect{},
+		crud.WithConnectionResolver(func(ctx context.Context) (*sql.DB, error) { return nil, nil }))
+	require.NoError(t, err)
+	_, err = nilRepo.List(ctx)
+	assert.ErrorContains(t, err, "connection resolver returned no database connection")
+
+	// A single replica would serve the reads of every tenant
+	_, err = crud.NewRepository[User](nil, "users", crud.SQLiteDialect{},
+		crud.WithConnectionResolver(tenantResolver(map[string]*sql.DB{"acme": db})), crud.WithReadReplica(db))
+	assert.EqualError(t, err, "WithConnectionResolver cannot be combined with WithReadReplica")
+
+	// A repository bound to a transaction does not resolve.
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	defer tx.Rollback()
+	created, err := repo.WithTx(tx).Create(ctx, User{Username: "pinned", Email: "pinned@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "pinned", created.Username)
+}
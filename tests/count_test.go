@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCount(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for i := 1; i <= 5; i++ {
+		_, err := repo.Create(ctx, User{Username: fmt.Sprintf("user%d", i), Email: fmt.Sprintf("user%d@example.com", i)})
+		require.NoError(t, err)
+	}
+
+	filters := [][]crud.Option[User]{
+		{},
+		{repo.Where("username", "user1")},
+		{repo.Where("id", ">", 2)},
+		{repo.WhereIn("username", "user1", "user4", "nobody")},
+		{repo.WhereLike("email", "user%"), repo.Where("id", "<=", 3)},
+		{repo.Where("username", "nobody")},
+	}
+	for i, opts := range filters {
+		users, err := repo.List(ctx, opts...)
+		require.NoError(t, err)
+		count, err := repo.Count(ctx, opts...)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(users)), count, "filter set %d", i)
+	}
+
+	// Limit, offset and ordering are ignored
+	count, err := repo.Count(ctx, repo.Limit(2), repo.Offset(1), repo.OrderBy("id", crud.SortDesc))
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), count)
+
+	// Inside a transaction, uncommitted rows are counted
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	txRepo := repo.WithTx(tx)
+	_, err = txRepo.Create(ctx, User{Username: "user6", Email: "user6@example.com"})
+	require.NoError(t, err)
+	count, err = txRepo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), count)
+	require.NoError(t, tx.Rollback())
+
+	count, err = repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), count)
+}
+
+func TestExists(t *testing.T) {
+	db := setupTestDBWithPosts(t)
+	defer db.Close()
+
+	userRepo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithQueryRecorder(1))
+	require.NoError(t, err)
+	postRepo, err := crud.NewRepository[Post](db, "posts", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	user1, err := userRepo.Create(ctx, User{Username: "user1", Email: "user1@example.com"})
+	require.NoError(t, err)
+	_, err = userRepo.Create(ctx, User{Username: "user2", Email: "user2@example.com"})
+	require.NoError(t, err)
+	_, err = postRepo.Create(ctx, Post{UserID: user1.ID, Title: "Hello"})
+	require.NoError(t, err)
+
+	exists, err := userRepo.Exists(ctx, userRepo.Where("username", "user1"))
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, "SELECT EXISTS(SELECT 1 FROM users WHERE username = ? LIMIT 1)", userRepo.LastQueries()[0].SQL)
+
+	exists, err = userRepo.Exists(ctx, userRepo.Where("username", "nobody"))
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// With a join: only user1 has a post
+	withPost := userRepo.InnerJoin("posts", "posts.user_id = users.id")
+	exists, err = userRepo.Exists(ctx, withPost, userRepo.Where("users.username", "user1"))
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = userRepo.Exists(ctx, withPost, userRepo.Where("users.username", "user2"))
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// Honors transactions
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	txRepo := userRepo.WithTx(tx)
+	_, err = txRepo.Create(ctx, User{Username: "user3", Email: "user3@example.com"})
+	require.NoError(t, err)
+	exists, err = txRepo.Exists(ctx, txRepo.Where("username", "user3"))
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateMany(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithQueryRecorder(1))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = repo.Create(ctx, User{Username: "existing", Email: "existing@example.com"})
+	require.NoError(t, err)
+
+	created, err := repo.CreateMany(ctx, []User{
+		{Username: "a", Email: "a@example.com"},
+		{Username: "b", Email: "b@example.com"},
+		{Username: "c", Email: "c@example.com"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (username, email) VALUES (?, ?), (?, ?), (?, ?)", repo.LastQueries()[0].SQL)
+
+	require.Len(t, created, 3)
+	for _, u := range created {
+		fetched, err := repo.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+		assert.Equal(t, u, fetched)
+	}
+	assert.Equal(t, 2, created[0].ID)
+	assert.Equal(t, 4, created[2].ID)
+
+	empty, err := repo.CreateMany(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestCreateManyChunksLargeBatches(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	// Two columns per row, so this exceeds the placeholder limit of a single statement
+	items := make([]User, 20000)
+	for i := range items {
+		items[i] = User{Username: fmt.Sprintf("user%d", i), Email: fmt.Sprintf("user%d@example.com", i)}
+	}
+
+	ctx := context.Background()
+	created, err := repo.CreateMany(ctx, items)
+	require.NoError(t, err)
+	require.Len(t, created, len(items))
+	assert.Equal(t, 1, created[0].ID)
+	assert.Equal(t, len(items), created[len(items)-1].ID)
+
+	count, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(items)), count)
+
+	last, err := repo.GetByID(ctx, created[len(created)-1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "user19999", last.Username)
+
+	// A failing chunk rolls back the whole batch
+	items = append(items[:0:0], User{Username: "fresh", Email: "fresh@example.com"})
+	for i := 0; i < 20000; i++ {
+		items = append(items, User{Username: fmt.Sprintf("dup%d", i), Email: fmt.Sprintf("dup%d@example.com", i%20000)})
+	}
+	items = append(items, User{Username: "user0", Email: "conflict@example.com"})
+	_, err = repo.CreateMany(ctx, items)
+	require.Error(t, err)
+	exists, err := repo.Exists(ctx, repo.Where("username", "fresh"))
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestCreateManySizesChunksByDialectParameterLimit(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithQueryRecorder(10))
+	require.NoError(t, err)
+
+	// SQLite allows 999 parameters and users inserts two columns, so 499 rows fit in a statement
+	assert.Equal(t, 999, crud.SQLiteDialect{}.MaxParameters())
+	items := make([]User, 1000)
+	for i := range items {
+		items[i] = User{Username: fmt.Sprintf("user%d", i), Email: fmt.Sprintf("user%d@example.com", i)}
+	}
+
+	ctx := context.Background()
+	created, err := repo.CreateMany(ctx, items)
+	require.NoError(t, err)
+	require.Len(t, created, len(items))
+	assert.Equal(t, len(items), created[len(items)-1].ID)
+
+	var argCounts []int
+	for _, q := range repo.LastQueries() {
+		if q.Op == "INSERT" {
+			argCounts = append(argCounts, len(q.Args))
+		}
+	}
+	assert.ElementsMatch(t, []int{998, 998, 4}, argCounts)
+
+	count, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(items)), count)
+}
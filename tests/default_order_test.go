@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDefaultOrderBy(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithDefaultOrderBy("id", crud.SortDesc))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for i := 1; i <= 3; i++ {
+		_, err := repo.Create(ctx, User{Username: fmt.Sprintf("user%d", i), Email: fmt.Sprintf("user%d@example.com", i)})
+		require.NoError(t, err)
+	}
+
+	users, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user3", "user2", "user1"}, usernames(users))
+
+	first, err := repo.First(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "user3", first.Username)
+
+	page, err := repo.Paginate(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user3", "user2"}, usernames(page.Items))
+
+	// An explicit ordering replaces the default
+	sql, _, err := repo.ToSQL(repo.OrderBy("username", crud.SortAsc))
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT users.id, users.username, users.email FROM users ORDER BY username ASC", sql)
+	users, err = repo.List(ctx, repo.OrderBy("username", crud.SortAsc))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user1", "user2", "user3"}, usernames(users))
+
+	_, err = crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithDefaultOrderBy("missing", crud.SortAsc))
+	assert.ErrorContains(t, err, "unknown default order column 'missing'")
+	_, err = crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithDefaultOrderBy("id", "SIDEWAYS"))
+	assert.Error(t, err)
+}
+
+func usernames(users []User) []string {
+	names := make([]string, len(users))
+	for i, u := range users {
+		names[i] = u.Username
+	}
+	return names
+}
@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowQuery counts to a billion with a recursive CTE, which takes far longer than the timeouts below.
+const slowQuery = `WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM c WHERE x < 1000000000)
+	SELECT count(*) AS n FROM c`
+
+type countRow struct {
+	N int64 `db:"n"`
+}
+
+func TestWithDefaultTimeout(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithDefaultTimeout(50*time.Millisecond))
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = crud.RawInto[User, countRow](context.Background(), repo, slowQuery, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	// Fast statements are unaffected, including after the timeout of an earlier one
+	created, err := repo.Create(context.Background(), User{Username: "john", Email: "john@example.com"})
+	require.NoError(t, err)
+	_, err = repo.GetByID(context.Background(), created.ID)
+	require.NoError(t, err)
+}
+
+func TestWithDefaultTimeoutKeepsEarlierDeadline(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithDefaultTimeout(time.Hour))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = crud.RawInto[User, countRow](ctx, repo, slowQuery, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Job struct {
+	ID       int     `db:"id,pk"`
+	Name     string  `db:"name"`
+	Status   string  `db:"status,default:pending"`
+	Priority int     `db:"priority,default:3"`
+	Queue    *string `db:"queue,default:main"`
+}
+
+func TestDefaultTagModifier(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE jobs (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL, status TEXT NOT NULL, priority INTEGER NOT NULL, queue TEXT);`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Job](db, "jobs", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	job, err := repo.Create(ctx, Job{Name: "defaults"})
+	require.NoError(t, err)
+	assert.Equal(t, "pending", job.Status)
+	assert.Equal(t, 3, job.Priority)
+	require.NotNil(t, job.Queue)
+	assert.Equal(t, "main", *job.Queue)
+
+	// Set fields are kept
+	urgent := "urgent"
+	job, err = repo.Create(ctx, Job{Name: "explicit", Status: "running", Priority: 9, Queue: &urgent})
+	require.NoError(t, err)
+	assert.Equal(t, "running", job.Status)
+	assert.Equal(t, 9, job.Priority)
+	assert.Equal(t, "urgent", *job.Queue)
+
+	jobs, err := repo.CreateMany(ctx, []Job{{Name: "bulk"}, {Name: "bulk2"}})
+	require.NoError(t, err)
+	assert.Equal(t, "pending", jobs[0].Status)
+
+	// Pointer defaults are not shared between items
+	require.NotNil(t, jobs[0].Queue)
+	require.NotNil(t, jobs[1].Queue)
+	*jobs[0].Queue = "changed"
+	assert.Equal(t, "main", *jobs[1].Queue)
+	next, err := repo.Create(ctx, Job{Name: "after"})
+	require.NoError(t, err)
+	assert.Equal(t, "main", *next.Queue)
+}
+
+func TestDefaultTagModifierInvalid(t *testing.T) {
+	type badDefault struct {
+		ID    int `db:"id,pk"`
+		Count int `db:"count,default:many"`
+	}
+	db := setupTestDB(t)
+	defer db.Close()
+
+	_, err := crud.NewRepository[badDefault](db, "bad", crud.SQLiteDialect{})
+	assert.ErrorContains(t, err, "invalid default 'many'")
+}
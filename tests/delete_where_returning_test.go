@@ -0,0 +1,155 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteWhereReturning(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	_, _ = repo.Create(ctx, User{Username: "temp-1", Email: "t1@example.com"})
+	_, _ = repo.Create(ctx, User{Username: "temp-2", Email: "t2@example.com"})
+	_, _ = repo.Create(ctx, User{Username: "keeper", Email: "k@example.com"})
+
+	deleted, err := repo.DeleteWhereReturning(ctx, repo.WhereLike("username", "temp-%"))
+	require.NoError(t, err)
+	require.Len(t, deleted, 2)
+	assert.ElementsMatch(t, []string{"temp-1", "temp-2"}, []string{deleted[0].Username, deleted[1].Username})
+
+	remaining, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "keeper", remaining[0].Username)
+}
+
+func TestDeleteWhereReturning_RequiresWhere(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, _ = repo.Create(ctx, User{Username: "user1", Email: "u1@example.com"})
+
+	_, err = repo.DeleteWhereReturning(ctx)
+	require.Error(t, err)
+	assert.Equal(t, "DeleteWhereReturning requires at least one WHERE condition", err.Error())
+
+	// Nothing was deleted
+	users, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+}
+
+func TestDeleteWhereReturning_WithTx(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, _ = repo.Create(ctx, User{Username: "user1", Email: "u1@example.com"})
+
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+
+	deleted, err := repo.WithTx(tx).DeleteWhereReturning(ctx, repo.Where("username", "user1"))
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+
+	// Rolling back restores the row
+	require.NoError(t, tx.Rollback())
+
+	users, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+}
+
+func TestDeleteWhereReturning_RejectsSelectOptions(t *testing.T) {
+	db := setupTestDBWithPosts(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = repo.Create(ctx, User{Username: "user1", Email: "u1@example.com"})
+	require.NoError(t, err)
+
+	where := repo.Where("username", "user1")
+	for _, opt := range []crud.Option[User]{
+		repo.Join("INNER JOIN posts ON posts.user_id = users.id"),
+		repo.OrderBy("id", crud.SortAsc),
+		repo.Limit(1),
+		repo.Columns("id"),
+	} {
+		_, err = repo.DeleteWhereReturning(ctx, where, opt)
+		assert.Error(t, err)
+	}
+
+	// Nothing was deleted
+	users, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+}
+
+func TestDeleteWhereReturning_SoftDelete(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE documents (id INTEGER PRIMARY KEY AUTOINCREMENT, title TEXT NOT NULL, deleted_at DATETIME);`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Document](db, "documents", crud.SQLiteDialect{Returning: true}, crud.WithSoftDelete("deleted_at"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	trashed, err := repo.Create(ctx, Document{Title: "draft-old"})
+	require.NoError(t, err)
+	require.NoError(t, repo.Delete(ctx, trashed.ID))
+	var trashedAt time.Time
+	require.NoError(t, db.QueryRow(`SELECT deleted_at FROM documents WHERE id = ?`, trashed.ID).Scan(&trashedAt))
+
+	draft, err := repo.Create(ctx, Document{Title: "draft-new"})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, Document{Title: "final"})
+	require.NoError(t, err)
+
+	// Already soft-deleted records are neither returned nor stamped again, even with WithTrashed
+	deleted, err := repo.DeleteWhereReturning(ctx, repo.WhereLike("title", "draft-%"), repo.WithTrashed())
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, draft.ID, deleted[0].ID)
+	assert.Nil(t, deleted[0].DeletedAt, "records are returned as they were before the deletion")
+
+	var restampedAt time.Time
+	require.NoError(t, db.QueryRow(`SELECT deleted_at FROM documents WHERE id = ?`, trashed.ID).Scan(&restampedAt))
+	assert.True(t, trashedAt.Equal(restampedAt))
+
+	// The record is marked as deleted, not removed
+	var deletedAt sql.NullTime
+	require.NoError(t, db.QueryRow(`SELECT deleted_at FROM documents WHERE id = ?`, draft.ID).Scan(&deletedAt))
+	assert.True(t, deletedAt.Valid)
+
+	docs, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "final", docs[0].Title)
+}
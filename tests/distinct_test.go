@@ -0,0 +1,61 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistinctWithJoin(t *testing.T) {
+	db := setupTestDBWithPosts(t)
+	defer db.Close()
+
+	userRepo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithQueryRecorder(1))
+	require.NoError(t, err)
+	postRepo, err := crud.NewRepository[Post](db, "posts", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	users, err := userRepo.CreateMany(ctx, []User{
+		{Username: "alice", Email: "alice@example.com"},
+		{Username: "bob", Email: "bob@example.com"},
+		{Username: "carol", Email: "carol@example.com"},
+	})
+	require.NoError(t, err)
+	_, err = postRepo.CreateMany(ctx, []Post{
+		{UserID: users[0].ID, Title: "a1"},
+		{UserID: users[0].ID, Title: "a2"},
+		{UserID: users[0].ID, Title: "a3"},
+		{UserID: users[1].ID, Title: "b1"},
+	})
+	require.NoError(t, err)
+
+	join := userRepo.InnerJoin("posts", "posts.user_id = users.id")
+
+	// Without Distinct, every post yields a row for its author
+	authors, err := userRepo.List(ctx, join)
+	require.NoError(t, err)
+	assert.Len(t, authors, 4)
+
+	authors, err = userRepo.List(ctx, join, userRepo.Distinct(), userRepo.OrderBy("users.id", crud.SortAsc))
+	require.NoError(t, err)
+	assert.Equal(t, []User{users[0], users[1]}, authors)
+	assert.Contains(t, userRepo.LastQueries()[0].SQL, "SELECT DISTINCT users.id, users.username, users.email FROM users INNER JOIN posts")
+
+	names, err := userRepo.List(ctx, join, userRepo.Distinct(), userRepo.Columns("username"), userRepo.OrderBy("username", crud.SortAsc))
+	require.NoError(t, err)
+	assert.Equal(t, []User{{Username: "alice"}, {Username: "bob"}}, names)
+
+	count, err := userRepo.Count(ctx, join, userRepo.Distinct())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	page, err := userRepo.Paginate(ctx, 1, 1, join, userRepo.Distinct(), userRepo.OrderBy("users.id", crud.SortAsc))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), page.Total)
+	assert.Equal(t, []User{users[0]}, page.Items)
+}
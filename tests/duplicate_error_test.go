@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicateErrorNamesConstraint(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	john, err := repo.Create(ctx, User{Username: "john", Email: "john@example.com"})
+	require.NoError(t, err)
+	jane, err := repo.Create(ctx, User{Username: "jane", Email: "jane@example.com"})
+	require.NoError(t, err)
+
+	var dup *crud.ErrDuplicate
+	_, err = repo.Create(ctx, User{Username: "john", Email: "other@example.com"})
+	require.ErrorAs(t, err, &dup)
+	assert.Equal(t, "users.username", dup.Constraint)
+
+	_, err = repo.CreateMany(ctx, []User{{Username: "other", Email: "john@example.com"}})
+	require.ErrorAs(t, err, &dup)
+	assert.Equal(t, "users.email", dup.Constraint)
+
+	jane.Email = john.Email
+	_, err = repo.Update(ctx, jane)
+	require.ErrorAs(t, err, &dup)
+	assert.Equal(t, "users.email", dup.Constraint)
+	assert.ErrorContains(t, err, "UNIQUE constraint failed: users.email")
+}
+
+func TestDialectIsUniqueViolation(t *testing.T) {
+	tests := []struct {
+		name       string
+		dialect    crud.UniqueViolationDetector
+		err        error
+		constraint string
+		ok         bool
+	}{
+		{"postgres message", crud.PostgresDialect{},
+			errors.New(`pq: duplicate key value violates unique constraint "users_email_key"`), "users_email_key", true},
+		{"postgres pgx", crud.PostgresDialect{},
+			sqlStateError{state: "23505", msg: `ERROR: duplicate key value violates unique constraint "users_pkey" (SQLSTATE 23505)`}, "users_pkey", true},
+		{"postgres pq fields", crud.PostgresDialect{}, pqFieldError{'C': "23505", 'n': "users_username_key"}, "users_username_key", true},
+		{"postgres other code", crud.PostgresDialect{}, pqFieldError{'C': "23503", 'n': "posts_user_id_fkey"}, "", false},
+		{"mysql 8", crud.MySQLDialect{},
+			errors.New("Error 1062 (23000): Duplicate entry 'john@example.com' for key 'users.email'"), "email", true},
+		{"mysql 5.7", crud.MySQLDialect{},
+			errors.New("Error 1062: Duplicate entry 'john' for key 'username'"), "username", true},
+		{"mysql other", crud.MySQLDialect{}, errors.New("Error 1452 (23000): Cannot add or update a child row"), "", false},
+		{"sqlite expression index", crud.SQLiteDialect{},
+			errors.New("UNIQUE constraint failed: index 'users_lower_email'"), "users_lower_email", true},
+		{"sqlserver constraint", crud.SQLServerDialect{},
+			errors.New("mssql: Violation of UNIQUE KEY constraint 'UQ_users_email'. Cannot insert duplicate key in object 'dbo.users'."),
+			"UQ_users_email", true},
+		{"sqlserver index", crud.SQLServerDialect{},
+			errors.New("mssql: Cannot insert duplicate key row in object 'dbo.users' with unique index 'IX_users_username'."),
+			"IX_users_username", true},
+		{"wrapped", crud.SQLiteDialect{}, fmt.Errorf("insert: %w", errors.New("UNIQUE constraint failed: users.username")), "users.username", true},
+		{"unrelated", crud.SQLiteDialect{}, errors.New("NOT NULL constraint failed: users.email"), "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			constraint, ok := tt.dialect.IsUniqueViolation(tt.err)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.constraint, constraint)
+		})
+	}
+}
+
+// sqlStateError mimics pgx's *pgconn.PgError.
+type sqlStateError struct {
+	state string
+	msg   string
+}
+
+func (e sqlStateError) Error() string    { return e.msg }
+func (e sqlStateError) SQLState() string { return e.state }
+
+// pqFieldError mimics lib/pq's *pq.Error field accessor.
+type pqFieldError map[byte]string
+
+func (e pqFieldError) Error() string     { return "pq: " + e['C'] }
+func (e pqFieldError) Get(k byte) string { return e[k] }
@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type BaseModel struct {
+	ID        int       `db:"id,pk"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type Tenant struct {
+	BaseModel
+	Name string `db:"name"`
+}
+
+func TestEmbeddedStructFieldsAreMapped(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	_, err := db.Exec(`CREATE TABLE tenants (id INTEGER PRIMARY KEY AUTOINCREMENT, created_at DATETIME NOT NULL, name TEXT NOT NULL)`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Tenant](db, "tenants", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	created, err := repo.Create(ctx, Tenant{BaseModel: BaseModel{CreatedAt: createdAt}, Name: "acme"})
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+
+	got, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, got.ID)
+	assert.Equal(t, "acme", got.Name)
+	assert.True(t, createdAt.Equal(got.CreatedAt))
+
+	sql, _, err := repo.ToSQL()
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT tenants.id, tenants.created_at, tenants.name FROM tenants", sql)
+}
+
+type TenantWithDuplicateColumn struct {
+	BaseModel
+	Created time.Time `db:"created_at"`
+}
+
+type TenantWithBasePointer struct {
+	*BaseModel
+	Name string `db:"name"`
+}
+
+func TestEmbeddedStructFieldErrors(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	_, err := crud.NewRepository[TenantWithDuplicateColumn](db, "tenants", crud.SQLiteDialect{})
+	assert.ErrorContains(t, err, "duplicate column 'created_at'")
+
+	_, err = crud.NewRepository[TenantWithBasePointer](db, "tenants", crud.SQLiteDialect{})
+	assert.ErrorContains(t, err, "embedded field BaseModel must not be a pointer")
+}
@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type TicketStatus string
+
+const (
+	TicketOpen   TicketStatus = "open"
+	TicketClosed TicketStatus = "closed"
+)
+
+type TicketPriority int
+
+type Ticket struct {
+	ID       int             `db:"id,pk"`
+	Status   TicketStatus    `db:"status"`
+	Priority *TicketPriority `db:"priority"`
+}
+
+func init() {
+	crud.RegisterEnum(TicketOpen, TicketClosed)
+	crud.RegisterEnumValidator(func(p TicketPriority) bool { return p >= 1 && p <= 3 })
+}
+
+func TestEnumValidation(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE tickets (id INTEGER PRIMARY KEY AUTOINCREMENT, status TEXT NOT NULL, priority INTEGER);`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Ticket](db, "tickets", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	high := TicketPriority(1)
+	open, err := repo.Create(ctx, Ticket{Status: TicketOpen, Priority: &high})
+	require.NoError(t, err)
+	assert.Equal(t, TicketOpen, open.Status)
+
+	// A NULL pointer enum is valid
+	closed, err := repo.Create(ctx, Ticket{Status: TicketClosed})
+	require.NoError(t, err)
+	assert.Nil(t, closed.Priority)
+
+	// Values written outside the application are rejected on scan
+	_, err = db.Exec(`UPDATE tickets SET status = 'archived' WHERE id = ?`, closed.ID)
+	require.NoError(t, err)
+	_, err = repo.GetByID(ctx, closed.ID)
+	assert.ErrorContains(t, err, "invalid value archived for enum type tests.TicketStatus in column 'status'")
+
+	_, err = db.Exec(`UPDATE tickets SET priority = 9 WHERE id = ?`, open.ID)
+	require.NoError(t, err)
+	_, err = repo.List(ctx, repo.Where("id", open.ID))
+	assert.ErrorContains(t, err, "invalid value 9 for enum type *tests.TicketPriority in column 'priority'")
+}
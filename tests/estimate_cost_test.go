@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateCost_Unsupported(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	_, err = repo.EstimateCost(context.Background(), repo.Where("username", "john"))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrUnsupported))
+}
+
+func TestPostgresEstimateCost(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	cost, err := repo.EstimateCost(context.Background(), repo.Where("username", "john"))
+	require.NoError(t, err)
+	assert.Greater(t, cost, 0.0)
+}
+
+func TestMySQLEstimateCost(t *testing.T) {
+	db := setupMySQLTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.MySQLDialect{})
+	require.NoError(t, err)
+
+	cost, err := repo.EstimateCost(context.Background(), repo.Where("username", "john"))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, cost, 0.0)
+}
@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFluentQuery(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for i := 1; i <= 6; i++ {
+		_, err := repo.Create(ctx, User{Username: fmt.Sprintf("user%d", i), Email: fmt.Sprintf("user%d@example.com", i)})
+		require.NoError(t, err)
+	}
+
+	users, err := repo.Query().
+		Where("username", "!=", "user6").
+		WhereGt("id", 2).
+		OrderBy("id", crud.SortDesc).
+		Limit(2).
+		List(ctx)
+	require.NoError(t, err)
+
+	expected, err := repo.List(ctx,
+		repo.Where("username", "!=", "user6"),
+		repo.Where("id", ">", 2),
+		repo.OrderBy("id", crud.SortDesc),
+		repo.Limit(2),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, expected, users)
+	require.Len(t, users, 2)
+	assert.Equal(t, "user5", users[0].Username)
+	assert.Equal(t, "user4", users[1].Username)
+
+	fluentSQL, fluentArgs, err := repo.Query().WhereIn("id", 1, 2).OrderBy("id", crud.SortAsc).ToSQL()
+	require.NoError(t, err)
+	optionSQL, optionArgs, err := repo.ToSQL(repo.WhereIn("id", 1, 2), repo.OrderBy("id", crud.SortAsc))
+	require.NoError(t, err)
+	assert.Equal(t, optionSQL, fluentSQL)
+	assert.Equal(t, optionArgs, fluentArgs)
+
+	count, err := repo.Query().WhereLte("id", 3).Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	first, err := repo.Query().WhereGte("id", 5).OrderBy("id", crud.SortAsc).First(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "user5", first.Username)
+
+	exists, err := repo.Query().Where("username", "nobody").Exists(ctx)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestFluentQueryIsImmutable(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for i := 1; i <= 4; i++ {
+		_, err := repo.Create(ctx, User{Username: fmt.Sprintf("user%d", i), Email: fmt.Sprintf("user%d@example.com", i)})
+		require.NoError(t, err)
+	}
+
+	base := repo.Query().WhereLike("email", "%@example.com").WhereLt("id", 4)
+	low := base.WhereLt("id", 2)
+	high := base.WhereGt("id", 2)
+
+	lowUsers, err := low.List(ctx)
+	require.NoError(t, err)
+	highUsers, err := high.List(ctx)
+	require.NoError(t, err)
+	baseUsers, err := base.List(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, lowUsers, 1)
+	assert.Equal(t, "user1", lowUsers[0].Username)
+	require.Len(t, highUsers, 1)
+	assert.Equal(t, "user3", highUsers[0].Username)
+	assert.Len(t, baseUsers, 3)
+	assert.Len(t, base.Options(), 2)
+
+	page, err := base.With(repo.OrderBy("id", crud.SortAsc)).Paginate(ctx, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, "user3", page.Items[0].Username)
+}
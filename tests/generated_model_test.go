@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//go:generate go run ../cmd/crudgen -type Widget -output widget_crud_test.go
+
+type WidgetSize struct {
+	Width  int `db:"width"`
+	Height int `db:"height"`
+}
+
+type Widget struct {
+	ID    int        `db:"id,pk"`
+	Name  string     `db:"name"`
+	Color *string    `db:"color"`
+	Size  WidgetSize `db:"size,prefix:size_"`
+}
+
+// StaleWidget implements GeneratedModel with columns that no longer match its tags.
+type StaleWidget struct {
+	ID   int    `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+func (*StaleWidget) CrudColumns() []string    { return []string{"id", "title"} }
+func (m *StaleWidget) CrudScanTargets() []any { return []any{&m.ID, &m.Name} }
+func (m *StaleWidget) CrudValues() []any      { return []any{m.ID, m.Name} }
+
+func TestGeneratedModel(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE widgets (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL, color TEXT, size_width INTEGER, size_height INTEGER);`)
+	require.NoError(t, err)
+
+	var _ crud.GeneratedModel = (*Widget)(nil)
+	repo, err := crud.NewRepository[Widget](db, "widgets", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	red := "red"
+	created, err := repo.Create(ctx, Widget{Name: "gear", Color: &red, Size: WidgetSize{Width: 3, Height: 4}})
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+	require.NotNil(t, created.Color)
+	assert.Equal(t, "red", *created.Color)
+	assert.Equal(t, WidgetSize{Width: 3, Height: 4}, created.Size)
+
+	created.Name = "cog"
+	created.Color = nil
+	_, err = repo.Update(ctx, created)
+	require.NoError(t, err)
+
+	widgets, err := repo.List(ctx, repo.Where("size_width", 3))
+	require.NoError(t, err)
+	require.Len(t, widgets, 1)
+	assert.Equal(t, "cog", widgets[0].Name)
+	assert.Nil(t, widgets[0].Color)
+
+	_, err = crud.NewRepository[StaleWidget](db, "widgets", crud.SQLiteDialect{})
+	assert.ErrorContains(t, err, "out of date")
+}
+
+// GeneratedAlert implements GeneratedModel by hand, like crudgen would, with fields that database/sql
+// cannot bind as they are.
+type GeneratedAlert struct {
+	ID     int     `db:"id,pk"`
+	Labels Labels  `db:"labels"`
+	Extra  *Labels `db:"extra"`
+}
+
+func (*GeneratedAlert) CrudColumns() []string    { return []string{"id", "labels", "extra"} }
+func (m *GeneratedAlert) CrudScanTargets() []any { return []any{&m.ID, &m.Labels, &m.Extra} }
+func (m *GeneratedAlert) CrudValues() []any      { return []any{m.ID, m.Labels, m.Extra} }
+
+func TestGeneratedModelBindsValuers(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec(`CREATE TABLE alerts (id INTEGER PRIMARY KEY AUTOINCREMENT, labels TEXT NOT NULL, extra TEXT)`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[GeneratedAlert](db, "alerts", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	// The pointer-receiver Valuer is called, and the nil *Labels is bound as NULL without calling it
+	ctx := context.Background()
+	created, err := repo.Create(ctx, GeneratedAlert{Labels: Labels{Values: []string{"db", "prod"}}})
+	require.NoError(t, err)
+
+	var labels string
+	var extraIsNull bool
+	require.NoError(t, db.QueryRow(`SELECT labels, extra IS NULL FROM alerts WHERE id = ?`, created.ID).Scan(&labels, &extraIsNull))
+	assert.Equal(t, "db,prod", labels)
+	assert.True(t, extraIsNull)
+
+	created.Extra = &Labels{Values: []string{"x"}}
+	_, err = repo.Update(ctx, created)
+	require.NoError(t, err)
+	got, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.Extra)
+	assert.Equal(t, []string{"x"}, got.Extra.Values)
+}
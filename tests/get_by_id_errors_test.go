@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetByID_NotFound(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	_, err = repo.GetByID(context.Background(), 999)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, crud.ErrNotFound))
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+}
+
+func TestGetByID_TimeoutIsNotNotFound(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	created, err := repo.Create(context.Background(), User{Username: "user1", Email: "u1@example.com"})
+	require.NoError(t, err)
+
+	// A context whose deadline has already passed
+	ctx, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+
+	_, err = repo.GetByID(ctx, created.ID)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.False(t, errors.Is(err, crud.ErrNotFound), "a timeout must not be reported as not found")
+}
+
+func TestGetBy(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithQueryRecorder(1))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	created, err := repo.Create(ctx, User{Username: "lookup", Email: "lookup@example.com"})
+	require.NoError(t, err)
+
+	user, err := repo.GetBy(ctx, "username", "lookup")
+	require.NoError(t, err)
+	assert.Equal(t, created, user)
+	assert.Equal(t, "SELECT users.id, users.username, users.email FROM users WHERE users.username = ? LIMIT 1", repo.LastQueries()[0].SQL)
+
+	_, err = repo.GetBy(ctx, "email", "missing@example.com")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = repo.GetBy(ctx, "username = '' OR 1 = 1 --", "x")
+	assert.ErrorContains(t, err, "unknown column")
+}
@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetByIDsOrdered(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	u1, _ := repo.Create(ctx, User{Username: "user1", Email: "u1@example.com"})
+	u2, _ := repo.Create(ctx, User{Username: "user2", Email: "u2@example.com"})
+	u3, _ := repo.Create(ctx, User{Username: "user3", Email: "u3@example.com"})
+
+	// Mixed key types, a duplicate and a missing id
+	found, missing, err := repo.GetByIDsOrdered(ctx, []any{u3.ID, int64(u1.ID), 999, u3.ID, u2.ID})
+	require.NoError(t, err)
+
+	require.Len(t, found, 3)
+	assert.Equal(t, []string{"user3", "user1", "user2"}, []string{found[0].Username, found[1].Username, found[2].Username})
+	assert.Equal(t, []any{999}, missing)
+}
+
+func TestGetByIDsOrdered_Empty(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	found, missing, err := repo.GetByIDsOrdered(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+	assert.Empty(t, missing)
+}
+
+// PointerKeyUser maps the users table with a pointer primary key.
+type PointerKeyUser struct {
+	ID       *int64 `db:"id,pk"`
+	Username string `db:"username"`
+	Email    string `db:"email"`
+}
+
+func TestGetByIDsOrdered_FloatAndPointerIDs(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	u1, _ := repo.Create(ctx, User{Username: "user1", Email: "u1@example.com"})
+	u2, _ := repo.Create(ctx, User{Username: "user2", Email: "u2@example.com"})
+
+	// Integral floats (e.g. decoded from JSON) match, fractional ones are reported as missing as passed
+	id2 := int64(u2.ID)
+	found, missing, err := repo.GetByIDsOrdered(ctx, []any{float64(u1.ID), float64(u1.ID) + 0.5, &id2, int64(999)})
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, []string{"user1", "user2"}, []string{found[0].Username, found[1].Username})
+	assert.Equal(t, []any{float64(u1.ID) + 0.5, int64(999)}, missing)
+
+	// A pointer primary key is compared by value
+	ptrRepo, err := crud.NewRepository[PointerKeyUser](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+	ptrFound, missing, err := ptrRepo.GetByIDsOrdered(ctx, []any{u2.ID, &id2, float64(u1.ID), 999})
+	require.NoError(t, err)
+	require.Len(t, ptrFound, 2)
+	assert.Equal(t, []string{"user2", "user1"}, []string{ptrFound[0].Username, ptrFound[1].Username})
+	assert.Equal(t, []any{999}, missing)
+}
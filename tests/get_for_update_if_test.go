@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetForUpdateIf_RequiresTransaction(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	created, err := repo.Create(ctx, User{Username: "user1", Email: "u1@example.com"})
+	require.NoError(t, err)
+
+	_, ok, err := repo.GetForUpdateIf(ctx, created.ID, func(User) bool { return true })
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, crud.ErrTxRequired))
+	assert.False(t, ok)
+}
+
+func TestPostgresGetForUpdateIf(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	created, err := repo.Create(ctx, User{Username: "lock-if", Email: "lockif@example.com"})
+	require.NoError(t, err)
+
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	txRepo := repo.WithTx(tx)
+
+	user, ok, err := txRepo.GetForUpdateIf(ctx, created.ID, func(u User) bool { return u.Username == "lock-if" })
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, created.ID, user.ID)
+
+	_, ok, err = txRepo.GetForUpdateIf(ctx, created.ID, func(u User) bool { return u.Username == "someone-else" })
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, tx.Commit())
+}
@@ -0,0 +1,23 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/dimatock/crud"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntsToAnys(t *testing.T) {
+	assert.Equal(t, []any{1, 2, 3}, crud.IntsToAnys([]int{1, 2, 3}))
+	assert.Equal(t, []any{}, crud.IntsToAnys([]int64{}))
+}
+
+func TestMapKeysAndValuesToAnys(t *testing.T) {
+	m := map[int]string{1: "a", 2: "b", 3: "c"}
+
+	assert.ElementsMatch(t, []any{1, 2, 3}, crud.MapKeysToAnys(m))
+	assert.ElementsMatch(t, []any{"a", "b", "c"}, crud.MapValuesToAnys(m))
+
+	assert.Empty(t, crud.MapKeysToAnys(map[string]int{}))
+	assert.Empty(t, crud.MapValuesToAnys(map[string]int(nil)))
+}
@@ -0,0 +1,133 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errEmptyUsername = errors.New("username must not be empty")
+
+// HookedUser maps the users table and implements the lifecycle hooks.
+type HookedUser struct {
+	ID       int    `db:"id,pk"`
+	Username string `db:"username"`
+	Email    string `db:"email"`
+}
+
+func (u *HookedUser) BeforeCreate(ctx context.Context) error {
+	if u.Username == "" {
+		return errEmptyUsername
+	}
+	u.Email = strings.ToLower(u.Email)
+	return nil
+}
+
+func (u *HookedUser) BeforeUpdate(ctx context.Context) error {
+	if u.Username == "" {
+		return errEmptyUsername
+	}
+	return nil
+}
+
+// deletedIDsKey is the context key of the slice collecting the records seen by AfterDelete, so that every
+// test captures its own deletions.
+type deletedIDsKey struct{}
+
+// recordDeleted appends id to the slice stored in ctx with deletedIDsKey, if any.
+func recordDeleted(ctx context.Context, id int) {
+	if ids, ok := ctx.Value(deletedIDsKey{}).(*[]int); ok {
+		*ids = append(*ids, id)
+	}
+}
+
+func (u HookedUser) AfterDelete(ctx context.Context) error {
+	recordDeleted(ctx, u.ID)
+	return nil
+}
+
+func TestLifecycleHooks(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[HookedUser](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// BeforeCreate rejects the item and the insert never happens
+	_, err = repo.Create(ctx, HookedUser{Email: "anon@example.com"})
+	assert.ErrorIs(t, err, errEmptyUsername)
+	_, err = repo.CreateMany(ctx, []HookedUser{{Username: "ok", Email: "ok@example.com"}, {Email: "anon@example.com"}})
+	assert.ErrorIs(t, err, errEmptyUsername)
+	count, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	// BeforeCreate can derive fields
+	created, err := repo.Create(ctx, HookedUser{Username: "alice", Email: "Alice@Example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", created.Email)
+
+	created.Username = ""
+	_, err = repo.Update(ctx, created)
+	assert.ErrorIs(t, err, errEmptyUsername)
+	stored, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", stored.Username)
+
+	var deletedIDs []int
+	require.NoError(t, repo.Delete(context.WithValue(ctx, deletedIDsKey{}, &deletedIDs), created.ID))
+	assert.Equal(t, []int{created.ID}, deletedIDs)
+
+	// Deleting a missing record still reports no rows
+	assert.ErrorIs(t, repo.Delete(ctx, created.ID), crud.ErrNotFound)
+}
+
+// HookedDocument is a soft-deletable record whose BeforeDelete hook records the deletions.
+type HookedDocument struct {
+	ID        int        `db:"id,pk"`
+	Title     string     `db:"title"`
+	DeletedAt *time.Time `db:"deleted_at"`
+}
+
+func (d *HookedDocument) BeforeDelete(ctx context.Context) error {
+	recordDeleted(ctx, d.ID)
+	return nil
+}
+
+func TestDeleteHooksWithSoftDelete(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE documents (id INTEGER PRIMARY KEY AUTOINCREMENT, title TEXT NOT NULL, deleted_at DATETIME);`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[HookedDocument](db, "documents", crud.SQLiteDialect{}, crud.WithSoftDelete("deleted_at"))
+	require.NoError(t, err)
+
+	var deletedIDs []int
+	ctx := context.WithValue(context.Background(), deletedIDsKey{}, &deletedIDs)
+	doc, err := repo.Create(ctx, HookedDocument{Title: "draft"})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Delete(ctx, doc.ID))
+	assert.Equal(t, []int{doc.ID}, deletedIDs)
+
+	// A soft-deleted record is not loaded again, so its hooks do not run on it
+	assert.ErrorIs(t, repo.Delete(ctx, doc.ID), crud.ErrNotFound)
+	assert.Equal(t, []int{doc.ID}, deletedIDs)
+
+	// ForceDelete still reaches it
+	require.NoError(t, repo.ForceDelete(ctx, doc.ID))
+	assert.Equal(t, []int{doc.ID, doc.ID}, deletedIDs)
+}
@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errForbiddenToken = errors.New("forbidden token")
+
+func rejectSemicolonsAndComments(expr string) error {
+	if strings.Contains(expr, ";") || strings.Contains(expr, "--") || strings.Contains(expr, "/*") {
+		return errForbiddenToken
+	}
+	return nil
+}
+
+func TestWithIdentifierValidator(t *testing.T) {
+	db := setupTestDBWithPosts(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{},
+		crud.WithIdentifierValidator(rejectSemicolonsAndComments))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = repo.Create(ctx, User{Username: "alice", Email: "alice@example.com"})
+	require.NoError(t, err)
+
+	// Fragments that pass the validator build as usual
+	users, err := repo.List(ctx,
+		repo.Where("username = ? OR email = ?", "alice", "alice@example.com"),
+		repo.LeftJoin("posts", "posts.user_id = users.id"),
+		repo.OrderBy("users.id", crud.SortAsc),
+	)
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+
+	rejected := []crud.Option[User]{
+		repo.Where("id = ?; DROP TABLE users --", 1),
+		repo.Where("id; DELETE FROM users", 1),
+		repo.WhereIn("id) OR (1 = 1 --", 1),
+		repo.OrderBy("id; DROP TABLE users", crud.SortAsc),
+		repo.Join("JOIN posts ON 1 = 1 /* */"),
+		repo.WhereSubquery("id", "IN", "SELECT user_id FROM posts; DROP TABLE posts"),
+		crud.WhereNot(repo.Where("username -- ", "x")),
+	}
+	for _, opt := range rejected {
+		_, err := repo.List(ctx, opt)
+		assert.ErrorIs(t, err, errForbiddenToken)
+	}
+
+	_, err = crud.GroupedAggregate[User, struct {
+		Username string `db:"username"`
+		Total    int64  `db:"total"`
+	}](ctx, repo, []string{"username"}, []string{"COUNT(*) AS total; --"})
+	assert.ErrorIs(t, err, errForbiddenToken)
+
+	count, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
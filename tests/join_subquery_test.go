@@ -3,6 +3,7 @@ package tests
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"testing"
 
 	"github.com/dimatock/crud"
@@ -86,3 +87,51 @@ func TestListWithSubquery(t *testing.T) {
 	require.Len(t, users, 1)
 	assert.Equal(t, "user1", users[0].Username)
 }
+
+func TestListWithTypedJoins(t *testing.T) {
+	db := setupTestDBWithPosts(t)
+	defer db.Close()
+
+	userRepo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+	postRepo, err := crud.NewRepository[Post](db, "posts", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	user1, err := userRepo.Create(ctx, User{Username: "user1", Email: "user1@example.com"})
+	require.NoError(t, err)
+	_, err = userRepo.Create(ctx, User{Username: "user2", Email: "user2@example.com"})
+	require.NoError(t, err)
+	_, err = postRepo.Create(ctx, Post{UserID: user1.ID, Title: "Post 1"})
+	require.NoError(t, err)
+
+	users, err := userRepo.List(ctx, userRepo.InnerJoin("posts", "posts.user_id = users.id"))
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+
+	// Users without posts are kept by a LEFT JOIN
+	users, err = userRepo.List(ctx, userRepo.LeftJoin("posts", "posts.user_id = users.id"), userRepo.OrderBy("users.id", crud.SortAsc))
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "user2", users[1].Username)
+
+	// RIGHT JOIN is available on SQLite 3.39+
+	posts, err := postRepo.List(ctx, postRepo.RightJoin("users", "posts.user_id = users.id"), postRepo.Where("users.username", "user1"))
+	require.NoError(t, err)
+	assert.Len(t, posts, 1)
+}
+
+func TestTypedJoinUnsupportedByDialect(t *testing.T) {
+	db := setupTestDBWithPosts(t)
+	defer db.Close()
+
+	// MySQL has no FULL OUTER JOIN; the error is raised before any SQL is sent
+	userRepo, err := crud.NewRepository[User](db, "users", crud.MySQLDialect{})
+	require.NoError(t, err)
+
+	_, err = userRepo.List(context.Background(), userRepo.FullJoin("posts", "posts.user_id = users.id"))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+	assert.Equal(t, "FULL JOIN is not supported by crud.MySQLDialect: unsupported operation", err.Error())
+}
@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Prefs struct {
+	Theme  string `json:"theme"`
+	Notify struct {
+		Email bool     `json:"email"`
+		Tags  []string `json:"tags"`
+	} `json:"notify"`
+}
+
+type Profile struct {
+	ID       int                           `db:"id,pk"`
+	Settings crud.JSON[Prefs]              `db:"settings"`
+	Extra    *crud.JSON[map[string]string] `db:"extra"`
+}
+
+func TestJSONColumn(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE profiles (id INTEGER PRIMARY KEY AUTOINCREMENT, settings TEXT NOT NULL, extra TEXT);`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Profile](db, "profiles", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	var prefs Prefs
+	prefs.Theme = "dark"
+	prefs.Notify.Email = true
+	prefs.Notify.Tags = []string{"billing", "security"}
+	extra := crud.NewJSON(map[string]string{"locale": "de"})
+
+	created, err := repo.Create(ctx, Profile{Settings: crud.NewJSON(prefs), Extra: &extra})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, Profile{Settings: crud.NewJSON(Prefs{Theme: "light"})})
+	require.NoError(t, err)
+
+	// Stored as a text document
+	var stored, storedType string
+	err = db.QueryRow(`SELECT settings, typeof(settings) FROM profiles WHERE id = ?`, created.ID).Scan(&stored, &storedType)
+	require.NoError(t, err)
+	assert.Equal(t, "text", storedType)
+	assert.JSONEq(t, `{"theme":"dark","notify":{"email":true,"tags":["billing","security"]}}`, stored)
+
+	fetched, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, prefs, fetched.Settings.Data)
+	require.NotNil(t, fetched.Extra)
+	assert.Equal(t, map[string]string{"locale": "de"}, fetched.Extra.Data)
+
+	// NULL leaves the pointer nil
+	profiles, err := repo.List(ctx, repo.OrderBy("id", crud.SortAsc))
+	require.NoError(t, err)
+	require.Len(t, profiles, 2)
+	assert.Equal(t, "light", profiles[1].Settings.Data.Theme)
+	assert.Nil(t, profiles[1].Extra)
+
+	fetched.Settings.Data.Theme = "solarized"
+	_, err = repo.Update(ctx, fetched)
+	require.NoError(t, err)
+	fetched, err = repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "solarized", fetched.Settings.Data.Theme)
+	assert.Equal(t, []string{"billing", "security"}, fetched.Settings.Data.Notify.Tags)
+}
+
+func TestJSONScan(t *testing.T) {
+	var j crud.JSON[map[string]int]
+	require.NoError(t, j.Scan([]byte(`{"a":1,"b":2}`)))
+	require.NoError(t, j.Scan(`{"c":3}`))
+	assert.Equal(t, map[string]int{"c": 3}, j.Data)
+
+	require.NoError(t, j.Scan(nil))
+	assert.Nil(t, j.Data)
+
+	assert.Error(t, j.Scan(42))
+	assert.Error(t, j.Scan("not json"))
+}
@@ -0,0 +1,49 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type JSONDocument struct {
+	ID   int    `db:"id,pk"`
+	Data string `db:"data"`
+}
+
+func TestWhereJSONContains(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	_, err := db.Exec(`CREATE TABLE json_documents (id INTEGER PRIMARY KEY AUTOINCREMENT, data TEXT)`)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	fragment := map[string]any{"tags": []string{"go"}}
+
+	sqliteRepo, err := crud.NewRepository[JSONDocument](db, "json_documents", crud.SQLiteDialect{})
+	require.NoError(t, err)
+	_, err = sqliteRepo.List(ctx, sqliteRepo.WhereJSONContains("data", fragment))
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+
+	pgRepo, err := crud.NewRepository[JSONDocument](db, "json_documents", crud.PostgresDialect{})
+	require.NoError(t, err)
+	query, args, err := pgRepo.ToSQL(pgRepo.Where("id", ">", 0), pgRepo.WhereJSONContains("data", fragment))
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT json_documents.id, json_documents.data FROM json_documents WHERE id > $1 AND data @> $2::jsonb", query)
+	assert.Equal(t, []any{0, `{"tags":["go"]}`}, args)
+
+	mysqlRepo, err := crud.NewRepository[JSONDocument](db, "json_documents", crud.MySQLDialect{})
+	require.NoError(t, err)
+	query, args, err = mysqlRepo.ToSQL(mysqlRepo.WhereJSONContains("data", fragment))
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT json_documents.id, json_documents.data FROM json_documents WHERE JSON_CONTAINS(data, ?)", query)
+	assert.Equal(t, []any{`{"tags":["go"]}`}, args)
+
+	_, _, err = pgRepo.ToSQL(pgRepo.WhereJSONContains("data", func() {}))
+	assert.ErrorContains(t, err, "failed to marshal")
+}
@@ -0,0 +1,42 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitWithTies(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	// SQLite has no FETCH ... WITH TIES
+	sqliteRepo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+	_, err = sqliteRepo.List(ctx, sqliteRepo.OrderBy("id", crud.SortAsc), sqliteRepo.LimitWithTies(3))
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+
+	// PostgreSQL replaces LIMIT with a FETCH clause placed after OFFSET
+	pgRepo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{})
+	require.NoError(t, err)
+	query, _, err := pgRepo.ToSQL(pgRepo.OrderBy("username", crud.SortAsc), pgRepo.LimitWithTies(3), pgRepo.Offset(2))
+	require.NoError(t, err)
+	assert.Equal(t,
+		"SELECT users.id, users.username, users.email FROM users ORDER BY username ASC OFFSET 2 FETCH FIRST 3 ROWS WITH TIES",
+		query)
+
+	// A later Limit replaces it
+	query, _, err = pgRepo.ToSQL(pgRepo.LimitWithTies(3), pgRepo.Limit(5))
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT users.id, users.username, users.email FROM users LIMIT 5", query)
+
+	_, _, err = pgRepo.ToSQL(pgRepo.LimitWithTies(0))
+	assert.Error(t, err)
+}
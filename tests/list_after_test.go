@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Ranked struct {
+	ID       int            `db:"id,pk"`
+	Name     string         `db:"name"`
+	Score    *int           `db:"score"`
+	Nickname sql.NullString `db:"nickname"`
+}
+
+func TestListAfterRejectsNullableCursor(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE ranked (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL, score INTEGER, nickname TEXT);`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Ranked](db, "ranked", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	score := 10
+	_, err = repo.Create(ctx, Ranked{Name: "a", Score: &score})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, Ranked{Name: "b"})
+	require.NoError(t, err)
+
+	// A NULL score would be skipped by "score > ?" but still sorted, so nullable cursors are rejected
+	_, err = repo.ListAfter(ctx, "score", 5, 10)
+	assert.ErrorContains(t, err, "cursor column 'score' is nullable")
+	_, err = repo.ListAfter(ctx, "nickname", "x", 10)
+	assert.ErrorContains(t, err, "cursor column 'nickname' is nullable")
+
+	_, err = repo.ListAfter(ctx, "missing", 0, 10)
+	assert.ErrorContains(t, err, "unknown cursor column 'missing'")
+	_, err = repo.ListAfter(ctx, "id", 0, 0)
+	assert.Error(t, err)
+
+	// Non-nullable columns work, including with a nil cursor for the first page
+	page, err := repo.ListAfter(ctx, "id", nil, 1)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, "a", page[0].Name)
+
+	page, err = repo.ListAfter(ctx, "id", page[0].ID, 1)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, "b", page[0].Name)
+}
+
+func TestListAfterPagesThroughDataset(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	seed := make([]User, 25)
+	for i := range seed {
+		seed[i] = User{Username: fmt.Sprintf("user%02d", i), Email: fmt.Sprintf("user%02d@example.com", i)}
+	}
+	seed, err = repo.CreateMany(ctx, seed)
+	require.NoError(t, err)
+
+	seen := make(map[int]bool)
+	var cursor any
+	pages := 0
+	for {
+		page, err := repo.ListAfter(ctx, "id", cursor, 10)
+		require.NoError(t, err)
+		if len(page) == 0 {
+			break
+		}
+		pages++
+		for _, u := range page {
+			assert.False(t, seen[u.ID], "user %d returned twice", u.ID)
+			seen[u.ID] = true
+		}
+		cursor = page[len(page)-1].ID
+
+		// Unlike with offsets, deleting a row behind the cursor does not shift the following pages
+		if pages == 1 {
+			_, err := db.Exec(`DELETE FROM users WHERE id = ?`, page[0].ID)
+			require.NoError(t, err)
+		}
+	}
+	assert.Equal(t, 3, pages)
+	assert.Len(t, seen, len(seed))
+	for _, u := range seed {
+		assert.True(t, seen[u.ID], "user %d was skipped", u.ID)
+	}
+
+	// Additional options filter each page
+	filtered, err := repo.ListAfter(ctx, "id", seed[0].ID, 100, repo.WhereLike("username", "user1%"))
+	require.NoError(t, err)
+	assert.Len(t, filtered, 10)
+}
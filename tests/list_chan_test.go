@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupStreamedUsers(t *testing.T, n int) crud.RepositoryInterface[User] {
+	db := setupTestDB(t)
+	t.Cleanup(func() { db.Close() })
+	// Keep the in-memory database on a single connection
+	db.SetMaxOpenConns(1)
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	users := make([]User, n)
+	for i := range users {
+		users[i] = User{Username: fmt.Sprintf("user%02d", i), Email: fmt.Sprintf("user%02d@example.com", i)}
+	}
+	_, err = repo.CreateMany(context.Background(), users)
+	require.NoError(t, err)
+	return repo
+}
+
+func TestListChan(t *testing.T) {
+	repo := setupStreamedUsers(t, 50)
+
+	items, errc, stop := repo.ListChan(context.Background(), repo.Where("id", ">", 10), repo.OrderBy("id", crud.SortAsc))
+	defer stop()
+	var ids []int
+	for item := range items {
+		ids = append(ids, item.ID)
+	}
+	require.NoError(t, <-errc)
+	require.Len(t, ids, 40)
+	assert.Equal(t, 11, ids[0])
+	assert.Equal(t, 50, ids[len(ids)-1])
+}
+
+func TestListChanStopsWhenContextIsCanceled(t *testing.T) {
+	repo := setupStreamedUsers(t, 50)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	items, errc, stop := repo.ListChan(ctx, repo.OrderBy("id", crud.SortAsc))
+	defer stop()
+
+	first := <-items
+	assert.Equal(t, 1, first.ID)
+	cancel()
+
+	// The producer gives up; at most the record it was already sending gets through
+	received := 0
+	for range items {
+		received++
+	}
+	assert.LessOrEqual(t, received, 1)
+	assert.ErrorIs(t, <-errc, context.Canceled)
+
+	// The rows were closed, so the single connection is available again
+	count, err := repo.Count(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(50), count)
+}
+
+func TestListChanStop(t *testing.T) {
+	repo := setupStreamedUsers(t, 50)
+
+	items, errc, stop := repo.ListChan(context.Background(), repo.OrderBy("id", crud.SortAsc))
+	first := <-items
+	assert.Equal(t, 1, first.ID)
+
+	// The consumer walks away without cancelling the context; stop still releases the producer
+	stop()
+	for range items {
+	}
+	assert.ErrorIs(t, <-errc, context.Canceled)
+	stop()
+
+	count, err := repo.Count(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(50), count)
+}
+
+func TestListChanReportsOptionErrors(t *testing.T) {
+	repo := setupStreamedUsers(t, 1)
+
+	items, errc, stop := repo.ListChan(context.Background(), repo.Columns("missing"))
+	defer stop()
+	_, ok := <-items
+	assert.False(t, ok)
+	assert.ErrorContains(t, <-errc, "unknown column 'missing'")
+}
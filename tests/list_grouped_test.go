@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListGroupedBy(t *testing.T) {
+	db := setupTestDBWithPosts(t)
+	defer db.Close()
+
+	postRepo, err := crud.NewRepository[Post](db, "posts", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	_, _ = postRepo.Create(ctx, Post{UserID: 1, Title: "b"})
+	_, _ = postRepo.Create(ctx, Post{UserID: 2, Title: "c"})
+	_, _ = postRepo.Create(ctx, Post{UserID: 1, Title: "a"})
+
+	grouped, err := crud.ListGroupedBy(ctx, postRepo, func(p Post) int { return p.UserID },
+		postRepo.OrderBy("title", crud.SortAsc),
+	)
+	require.NoError(t, err)
+	require.Len(t, grouped, 2)
+
+	require.Len(t, grouped[1], 2)
+	assert.Equal(t, "a", grouped[1][0].Title)
+	assert.Equal(t, "b", grouped[1][1].Title)
+
+	require.Len(t, grouped[2], 1)
+	assert.Equal(t, "c", grouped[2][0].Title)
+
+	// Options are applied before grouping
+	grouped, err = crud.ListGroupedBy(ctx, postRepo, func(p Post) int { return p.UserID }, postRepo.Where("user_id", 2))
+	require.NoError(t, err)
+	assert.Len(t, grouped, 1)
+}
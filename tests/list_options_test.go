@@ -2,6 +2,7 @@ package tests
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 
 	"github.com/dimatock/crud"
@@ -60,6 +61,31 @@ func TestListWithIn_Empty(t *testing.T) {
 	assert.Equal(t, "WhereIn option requires at least one value for column 'username'", err.Error())
 }
 
+func TestListWithNotIn(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	_, _ = repo.Create(ctx, User{Username: "user1", Email: "u1@example.com"})
+	_, _ = repo.Create(ctx, User{Username: "user2", Email: "u2@example.com"})
+	_, _ = repo.Create(ctx, User{Username: "user3", Email: "u3@example.com"})
+	_, _ = repo.Create(ctx, User{Username: "user4", Email: "u4@example.com"})
+
+	users, err := repo.List(ctx, repo.WhereNotIn("username", "user1", "user3"), repo.OrderBy("username", crud.SortAsc))
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "user2", users[0].Username)
+	assert.Equal(t, "user4", users[1].Username)
+
+	_, err = repo.List(ctx, repo.WhereNotIn("username"))
+	require.Error(t, err)
+	assert.Equal(t, "WhereNotIn option requires at least one value for column 'username'", err.Error())
+}
+
 func TestListWithLike(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -103,4 +129,211 @@ func TestListWithOperatorWhere(t *testing.T) {
 	users, err = repo.List(ctx, repo.Where("username", "!=", "user2"))
 	require.NoError(t, err)
 	require.Len(t, users, 2)
-}
\ No newline at end of file
+}
+
+func TestListWithInOrAll(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// Insert some users
+	_, _ = repo.Create(ctx, User{Username: "user1", Email: "u1@example.com"})
+	_, _ = repo.Create(ctx, User{Username: "user2", Email: "u2@example.com"})
+	_, _ = repo.Create(ctx, User{Username: "user3", Email: "u3@example.com"})
+
+	// With values it behaves like WhereIn
+	users, err := repo.List(ctx, repo.WhereInOrAll("username", "user1", "user3"))
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+
+	// With no values the filter is skipped
+	users, err = repo.List(ctx, repo.WhereInOrAll("username"))
+	require.NoError(t, err)
+	assert.Len(t, users, 3)
+}
+
+func TestListWithMatch(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// Insert some users
+	u1, _ := repo.Create(ctx, User{Username: "user1", Email: "u1@example.com"})
+	_, _ = repo.Create(ctx, User{Username: "user2", Email: "u2@example.com"})
+
+	// Only non-zero fields become conditions
+	users, err := repo.List(ctx, repo.WhereMatch(User{Username: "user1"}))
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, u1.ID, users[0].ID)
+
+	// Multiple fields are combined with AND
+	users, err = repo.List(ctx, repo.WhereMatch(User{Username: "user1", Email: "u2@example.com"}))
+	require.NoError(t, err)
+	assert.Len(t, users, 0)
+
+	// A non-zero PK is matched too
+	users, err = repo.List(ctx, repo.WhereMatch(User{ID: u1.ID}))
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "user1", users[0].Username)
+
+	// An all-zero example matches everything
+	users, err = repo.List(ctx, repo.WhereMatch(User{}))
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+}
+
+func TestListWithNot(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// Insert some users
+	_, _ = repo.Create(ctx, User{Username: "user1", Email: "u1@example.com"})
+	_, _ = repo.Create(ctx, User{Username: "user2", Email: "u2@example.com"})
+	_, _ = repo.Create(ctx, User{Username: "user3", Email: "u3@example.com"})
+
+	// NOT (username = ? AND email = ?) only excludes the row matching both conditions
+	users, err := repo.List(ctx, repo.WhereNot(repo.Where("username", "user1"), repo.Where("email", "u1@example.com")))
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+
+	// Combined with a top-level condition and a raw clause inside the group
+	users, err = repo.List(ctx,
+		repo.Where("username", "!=", "user3"),
+		repo.WhereNot(repo.Where("username = ? OR username = ?", "user1", "user3")),
+	)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "user2", users[0].Username)
+
+	// An empty group has no effect
+	users, err = repo.List(ctx, repo.WhereNot())
+	require.NoError(t, err)
+	assert.Len(t, users, 3)
+}
+
+func TestListWithScope(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, _ = repo.Create(ctx, User{Username: "alice", Email: "alice@example.com"})
+	_, _ = repo.Create(ctx, User{Username: "bob", Email: "bob@corp.com"})
+	_, _ = repo.Create(ctx, User{Username: "carol", Email: "carol@example.com"})
+
+	exampleUsers := crud.Scope[User](func() []crud.Option[User] {
+		return []crud.Option[User]{crud.WhereLike[User]("email", "%@example.com")}
+	})
+	newestFirst := crud.Scope[User](func() []crud.Option[User] {
+		return []crud.Option[User]{crud.OrderBy[User]("id", crud.SortDesc)}
+	})
+
+	users, err := repo.List(ctx, repo.ApplyScope(exampleUsers), crud.ApplyScope(newestFirst), repo.Limit(1))
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "carol", users[0].Username)
+
+	users, err = repo.List(ctx, repo.ApplyScope(nil))
+	require.NoError(t, err)
+	assert.Len(t, users, 3)
+}
+
+func TestWhereRejectsNativePlaceholders(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for _, clause := range []string{"id = $1", "username = :name", "id = @p1", "id = ? OR id = $2"} {
+		_, err := repo.List(ctx, repo.Where(clause, 1))
+		assert.ErrorContains(t, err, "always use '?'", clause)
+	}
+
+	_, err = repo.List(ctx, repo.WhereSubquery("id", "IN", "SELECT id FROM users WHERE username = $1", "john"))
+	assert.ErrorContains(t, err, "placeholder '$1'")
+
+	// Casts, times and quoted literals are not placeholders
+	_, err = repo.List(ctx, repo.Where("CAST(id AS TEXT) = ? OR email = '$1' OR username = 'a:b'", "1"))
+	assert.NoError(t, err)
+}
+
+func TestWhereSubqueryPlaceholdersAreRewritten(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{}, crud.WithQueryRecorder(1))
+	require.NoError(t, err)
+
+	_, _ = repo.List(context.Background(),
+		repo.Where("email", "john@example.com"),
+		repo.WhereSubquery("id", "IN", "SELECT id FROM users WHERE username = ?", "john"),
+	)
+	queries := repo.LastQueries()
+	require.Len(t, queries, 1)
+	assert.Contains(t, queries[0].SQL, "email = $1 AND id IN (SELECT id FROM users WHERE username = $2)")
+}
+
+type Contact struct {
+	ID    int     `db:"id,pk"`
+	Name  string  `db:"name"`
+	Phone *string `db:"phone"`
+}
+
+func TestListWithWhereNull(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE contacts (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL, phone TEXT);`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Contact](db, "contacts", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	phone := "555-0100"
+	_, err = repo.Create(ctx, Contact{Name: "with-phone", Phone: &phone})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, Contact{Name: "no-phone-1"})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, Contact{Name: "no-phone-2"})
+	require.NoError(t, err)
+
+	contacts, err := repo.List(ctx, repo.WhereNull("phone"))
+	require.NoError(t, err)
+	require.Len(t, contacts, 2)
+	for _, c := range contacts {
+		assert.Nil(t, c.Phone)
+	}
+
+	contacts, err = repo.List(ctx, repo.WhereNotNull("phone"))
+	require.NoError(t, err)
+	require.Len(t, contacts, 1)
+	assert.Equal(t, "with-phone", contacts[0].Name)
+	assert.Equal(t, "555-0100", *contacts[0].Phone)
+
+	// Combines with placeholder-based options
+	contacts, err = repo.List(ctx, repo.WhereNull("phone"), repo.Where("name", "no-phone-2"))
+	require.NoError(t, err)
+	require.Len(t, contacts, 1)
+	assert.Equal(t, "no-phone-2", contacts[0].Name)
+}
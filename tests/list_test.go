@@ -125,3 +125,215 @@ func TestListWithLimitAndOffset(t *testing.T) {
 	require.Len(t, users, 1)
 	assert.Equal(t, "user2", users[0].Username)
 }
+
+func TestListWithOffsetOnly(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithQueryRecorder(1))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for _, name := range []string{"user1", "user2", "user3"} {
+		_, err = repo.Create(ctx, User{Username: name, Email: name + "@example.com"})
+		require.NoError(t, err)
+	}
+
+	// SQLite only accepts OFFSET after a LIMIT, so an unbounded one is emitted
+	users, err := repo.List(ctx, repo.Offset(1), repo.OrderBy("username", crud.SortAsc))
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "user2", users[0].Username)
+	assert.Equal(t, "user3", users[1].Username)
+	assert.Contains(t, repo.LastQueries()[0].SQL, "LIMIT -1 OFFSET 1")
+
+	mysqlRepo, err := crud.NewRepository[User](db, "users", crud.MySQLDialect{})
+	require.NoError(t, err)
+	query, _, err := mysqlRepo.ToSQL(mysqlRepo.Offset(10))
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT users.id, users.username, users.email FROM users LIMIT 18446744073709551615 OFFSET 10", query)
+
+	pgRepo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{})
+	require.NoError(t, err)
+	query, _, err = pgRepo.ToSQL(pgRepo.Offset(10))
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT users.id, users.username, users.email FROM users OFFSET 10", query)
+
+	// A dialect embedding a built-in one keeps its LIMIT handling in grouped queries too
+	type usernameCount struct {
+		Username string `db:"username"`
+		N        int64  `db:"n"`
+	}
+	wrappedRepo, err := crud.NewRepository[User](db, "users", struct{ crud.SQLiteDialect }{}, crud.WithQueryRecorder(1))
+	require.NoError(t, err)
+	rows, err := crud.GroupedAggregate[User, usernameCount](ctx, wrappedRepo, []string{"username"}, []string{"COUNT(*) AS n"},
+		wrappedRepo.OrderBy("username", crud.SortAsc), wrappedRepo.Offset(1))
+	require.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Contains(t, wrappedRepo.LastQueries()[0].SQL, "ORDER BY username ASC LIMIT -1 OFFSET 1")
+}
+
+func TestListWithPage(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// Insert some users for testing
+	for _, name := range []string{"user1", "user2", "user3", "user4", "user5"} {
+		_, err = repo.Create(ctx, User{Username: name, Email: name + "@example.com"})
+		require.NoError(t, err)
+	}
+
+	// Second page of size 2
+	users, err := repo.List(ctx, repo.WithPage(2, 2), repo.OrderBy("username", crud.SortAsc))
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "user3", users[0].Username)
+	assert.Equal(t, "user4", users[1].Username)
+
+	// Last, partially filled page
+	users, err = repo.List(ctx, repo.WithPage(3, 2), repo.OrderBy("username", crud.SortAsc))
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "user5", users[0].Username)
+
+	// Invalid arguments
+	_, err = repo.List(ctx, repo.WithPage(0, 2))
+	require.Error(t, err)
+	assert.Equal(t, "WithPage option requires page >= 1, got 0", err.Error())
+
+	_, err = repo.List(ctx, repo.WithPage(1, 0))
+	require.Error(t, err)
+	assert.Equal(t, "WithPage option requires size > 0, got 0", err.Error())
+}
+
+func TestListWithSortCollate(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	for _, name := range []string{"c", "B", "a"} {
+		_, err = repo.Create(ctx, User{Username: name, Email: name + "@example.com"})
+		require.NoError(t, err)
+	}
+
+	// Default binary ordering places uppercase letters first
+	users, err := repo.List(ctx, repo.OrderBy("username", crud.SortAsc))
+	require.NoError(t, err)
+	require.Len(t, users, 3)
+	assert.Equal(t, []string{"B", "a", "c"}, []string{users[0].Username, users[1].Username, users[2].Username})
+
+	// Case-insensitive collation
+	users, err = repo.List(ctx, repo.OrderByCollate("username", "NOCASE", crud.SortAsc))
+	require.NoError(t, err)
+	require.Len(t, users, 3)
+	assert.Equal(t, []string{"a", "B", "c"}, []string{users[0].Username, users[1].Username, users[2].Username})
+
+	// Collation names are validated
+	_, err = repo.List(ctx, repo.OrderByCollate("username", "NOCASE; DROP TABLE users", crud.SortAsc))
+	require.Error(t, err)
+	assert.Equal(t, "invalid collation name 'NOCASE; DROP TABLE users' in OrderByCollate", err.Error())
+}
+
+func TestListWithOrderBySpec(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	_, _ = repo.Create(ctx, User{Username: "alice", Email: "b@example.com"})
+	_, _ = repo.Create(ctx, User{Username: "bob", Email: "a@example.com"})
+	_, _ = repo.Create(ctx, User{Username: "alice2", Email: "a2@example.com"})
+
+	allowed := map[string]string{"name": "username", "email": "email"}
+
+	opts, err := crud.OrderBySpec[User]("-email, name", allowed)
+	require.NoError(t, err)
+	require.Len(t, opts, 2)
+
+	users, err := repo.List(ctx, opts...)
+	require.NoError(t, err)
+	require.Len(t, users, 3)
+	assert.Equal(t, "alice", users[0].Username)
+	assert.Equal(t, "bob", users[1].Username)
+	assert.Equal(t, "alice2", users[2].Username)
+
+	// An empty spec produces no options
+	opts, err = crud.OrderBySpec[User]("", allowed)
+	require.NoError(t, err)
+	assert.Empty(t, opts)
+
+	// Fields outside the allow-list are rejected
+	_, err = crud.OrderBySpec[User]("name,-password", allowed)
+	require.Error(t, err)
+	assert.Equal(t, "sorting by 'password' is not allowed", err.Error())
+
+	_, err = crud.OrderBySpec[User]("name,,email", allowed)
+	require.Error(t, err)
+	assert.Equal(t, "empty field in sort specification 'name,,email'", err.Error())
+}
+
+func TestListInto(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = repo.Create(ctx, User{Username: "user1", Email: "user1@example.com"})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, User{Username: "user2", Email: "user2@example.com"})
+	require.NoError(t, err)
+
+	// Records are appended into the pre-allocated backing array
+	buf := make([]User, 0, 8)
+	require.NoError(t, repo.ListInto(ctx, &buf, repo.OrderBy("id", crud.SortAsc)))
+	require.Len(t, buf, 2)
+	assert.Equal(t, 8, cap(buf))
+	assert.Equal(t, "user1", buf[0].Username)
+
+	// Existing elements are kept
+	require.NoError(t, repo.ListInto(ctx, &buf, repo.Where("username", "user2")))
+	require.Len(t, buf, 3)
+	assert.Equal(t, "user2", buf[2].Username)
+
+	// The buffer can be reused by truncating it
+	buf = buf[:0]
+	require.NoError(t, repo.ListInto(ctx, &buf, repo.Where("username", "user1")))
+	require.Len(t, buf, 1)
+	assert.Equal(t, "user1", buf[0].Username)
+
+	assert.Error(t, repo.ListInto(ctx, nil))
+}
+
+func TestFirst(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithQueryRecorder(1))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, _ = repo.Create(ctx, User{Username: "first-a", Email: "a@example.com"})
+	_, _ = repo.Create(ctx, User{Username: "first-b", Email: "b@example.com"})
+
+	user, err := repo.First(ctx, repo.WhereLike("username", "first-%"), repo.OrderBy("id", crud.SortDesc))
+	require.NoError(t, err)
+	assert.Equal(t, "first-b", user.Username)
+	assert.Contains(t, repo.LastQueries()[0].SQL, "LIMIT 1")
+
+	_, err = repo.First(ctx, repo.Where("username", "missing"))
+	assert.ErrorIs(t, err, crud.ErrNotFound)
+}
@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Feature struct {
+	ID      int     `db:"id,pk"`
+	Name    string  `db:"name"`
+	Enabled bool    `db:"enabled"`
+	Note    *string `db:"note"`
+}
+
+func TestDialectLiterals(t *testing.T) {
+	dialects := map[string]crud.LiteralProvider{
+		"mysql":     crud.MySQLDialect{},
+		"postgres":  crud.PostgresDialect{},
+		"sqlite":    crud.SQLiteDialect{},
+		"sqlserver": crud.SQLServerDialect{},
+	}
+	expected := map[string][2]string{
+		"mysql":     {"TRUE", "FALSE"},
+		"postgres":  {"TRUE", "FALSE"},
+		"sqlite":    {"1", "0"},
+		"sqlserver": {"1", "0"},
+	}
+	for name, d := range dialects {
+		assert.Equal(t, expected[name][0], d.TrueLiteral(), name)
+		assert.Equal(t, expected[name][1], d.FalseLiteral(), name)
+		assert.Equal(t, "NULL", d.NullLiteral(), name)
+	}
+}
+
+func TestBooleanWhereUsesDialectLiterals(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqliteRepo, err := crud.NewRepository[Feature](db, "features", crud.SQLiteDialect{})
+	require.NoError(t, err)
+	query, args, err := sqliteRepo.ToSQL(sqliteRepo.Where("enabled", true), sqliteRepo.Where("name", "!=", "beta"))
+	require.NoError(t, err)
+	assert.Contains(t, query, "WHERE enabled = 1 AND name != ?")
+	assert.Equal(t, []any{"beta"}, args)
+
+	pgRepo, err := crud.NewRepository[Feature](db, "features", crud.PostgresDialect{})
+	require.NoError(t, err)
+	query, args, err = pgRepo.ToSQL(pgRepo.Where("enabled", "<>", false), pgRepo.WhereNull("note"))
+	require.NoError(t, err)
+	assert.Contains(t, query, "WHERE enabled <> FALSE AND note IS NULL")
+	assert.Empty(t, args)
+}
+
+func TestBooleanWhereOnSQLite(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE features (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL, enabled BOOLEAN NOT NULL, note TEXT);`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Feature](db, "features", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	note := "rolled out"
+	_, err = repo.Create(ctx, Feature{Name: "search", Enabled: true, Note: &note})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, Feature{Name: "export", Enabled: false})
+	require.NoError(t, err)
+
+	features, err := repo.List(ctx, repo.Where("enabled", true))
+	require.NoError(t, err)
+	require.Len(t, features, 1)
+	assert.Equal(t, "search", features[0].Name)
+
+	features, err = repo.List(ctx, repo.Where("enabled", false), repo.WhereNull("note"))
+	require.NoError(t, err)
+	require.Len(t, features, 1)
+	assert.Equal(t, "export", features[0].Name)
+
+	// The literals keep raw clauses portable across dialects.
+	d := crud.SQLiteDialect{}
+	features, err = repo.List(ctx, repo.Where("enabled = "+d.TrueLiteral()+" AND note IS NOT "+d.NullLiteral()+" AND name != ?", "export"))
+	require.NoError(t, err)
+	require.Len(t, features, 1)
+	assert.Equal(t, "search", features[0].Name)
+}
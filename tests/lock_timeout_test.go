@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithLockTimeout(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	created, err := repo.Create(ctx, User{Username: "user1", Email: "user1@example.com"})
+	require.NoError(t, err)
+
+	// Outside a transaction the option is rejected
+	_, err = repo.GetByID(ctx, created.ID, repo.WithLockTimeout(time.Second))
+	assert.ErrorIs(t, err, crud.ErrTxRequired)
+
+	_, err = repo.List(ctx, repo.WithLockTimeout(0))
+	assert.Error(t, err)
+
+	// On SQLite it is a no-op inside a transaction
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	users, err := repo.WithTx(tx).List(ctx, repo.WithLockTimeout(time.Second))
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+}
+
+func TestWithLockTimeoutMySQLStatement(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.MySQLDialect{}, crud.WithQueryRecorder(1))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	// SQLite cannot run the MySQL statement, but the recorder shows what would be sent
+	_, err = repo.WithTx(tx).GetByID(ctx, 1, repo.Lock("FOR UPDATE"), repo.WithLockTimeout(1500*time.Millisecond))
+	assert.ErrorContains(t, err, "failed to set lock timeout")
+	assert.Equal(t, "SET SESSION innodb_lock_wait_timeout = 2", repo.LastQueries()[0].SQL)
+}
+
+// busyTimeoutDialect maps the lock timeout to SQLite's session-wide busy timeout, which has to be reset.
+type busyTimeoutDialect struct {
+	crud.SQLiteDialect
+}
+
+func (busyTimeoutDialect) LockTimeoutSQL(timeout time.Duration) (string, error) {
+	return fmt.Sprintf("PRAGMA busy_timeout = %d", timeout.Milliseconds()), nil
+}
+
+func (busyTimeoutDialect) ResetLockTimeoutSQL() string {
+	return "PRAGMA busy_timeout = 0"
+}
+
+func TestWithLockTimeoutReset(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", busyTimeoutDialect{}, crud.WithQueryRecorder(3))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	_, err = repo.WithTx(tx).List(ctx, repo.WithLockTimeout(time.Second))
+	require.NoError(t, err)
+	queries := repo.LastQueries()
+	require.Len(t, queries, 3)
+	assert.Equal(t, "PRAGMA busy_timeout = 1000", queries[0].SQL)
+	assert.Contains(t, queries[1].SQL, "SELECT")
+	assert.Equal(t, "PRAGMA busy_timeout = 0", queries[2].SQL)
+
+	assert.Equal(t, "SET SESSION innodb_lock_wait_timeout = DEFAULT", crud.MySQLDialect{}.ResetLockTimeoutSQL())
+}
+
+func TestPostgresWithLockTimeout(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	created, err := repo.Create(ctx, User{Username: "pg-lock-timeout", Email: "locktimeout@example.com"})
+	require.NoError(t, err)
+
+	// The first transaction holds the row lock
+	holder, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer holder.Rollback()
+	_, err = repo.WithTx(holder).GetByID(ctx, created.ID, repo.Lock("FOR UPDATE"))
+	require.NoError(t, err)
+
+	// The second one gives up quickly instead of blocking
+	waiter, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer waiter.Rollback()
+
+	start := time.Now()
+	_, err = repo.WithTx(waiter).GetByID(ctx, created.ID, repo.Lock("FOR UPDATE"), repo.WithLockTimeout(100*time.Millisecond))
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
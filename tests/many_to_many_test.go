@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type M2MUser struct {
+	ID    int     `db:"id,pk"`
+	Name  string  `db:"name"`
+	Roles []*Role `db:"-"`
+}
+
+type Role struct {
+	ID   int    `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+type UserRole struct {
+	ID     int `db:"id,pk"`
+	UserID int `db:"user_id"`
+	RoleID int `db:"role_id"`
+}
+
+func TestEagerLoadingManyToMany(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT);
+		CREATE TABLE roles (id INTEGER PRIMARY KEY, name TEXT);
+		CREATE TABLE user_roles (id INTEGER PRIMARY KEY, user_id INTEGER, role_id INTEGER);
+		INSERT INTO users (id, name) VALUES (1, 'John'), (2, 'Jane'), (3, 'Guest');
+		INSERT INTO roles (id, name) VALUES (10, 'admin'), (20, 'editor'), (30, 'viewer');
+		INSERT INTO user_roles (user_id, role_id) VALUES (1, 10), (1, 20), (2, 20);
+	`)
+	require.NoError(t, err)
+
+	dialect := crud.SQLiteDialect{}
+	userRepo, err := crud.NewRepository[M2MUser](db, "users", dialect)
+	require.NoError(t, err)
+	roleRepo, err := crud.NewRepository[Role](db, "roles", dialect)
+	require.NoError(t, err)
+	userRoleRepo, err := crud.NewRepository[UserRole](db, "user_roles", dialect)
+	require.NoError(t, err)
+
+	mapper := crud.ManyToManyMapper[M2MUser, Role, int]{
+		JoinFetcher: func(ctx context.Context, userIDs []int) ([]crud.JoinPair[int], error) {
+			links, err := userRoleRepo.List(ctx, userRoleRepo.WhereIn("user_id", crud.IntsToAnys(userIDs)...), userRoleRepo.OrderBy("id", crud.SortAsc))
+			if err != nil {
+				return nil, err
+			}
+			pairs := make([]crud.JoinPair[int], len(links))
+			for i, l := range links {
+				pairs[i] = crud.JoinPair[int]{ParentKey: l.UserID, RelatedKey: l.RoleID}
+			}
+			return pairs, nil
+		},
+		Fetcher: func(ctx context.Context, roleIDs []int) ([]Role, error) {
+			return roleRepo.List(ctx, roleRepo.WhereIn("id", crud.IntsToAnys(roleIDs)...))
+		},
+		GetPK:        func(u *M2MUser) int { return u.ID },
+		GetRelatedPK: func(r *Role) int { return r.ID },
+		SetRelated:   func(u *M2MUser, r []*Role) { u.Roles = r },
+	}
+
+	users, err := userRepo.List(context.Background(), userRepo.WithRelation(mapper), userRepo.OrderBy("id", crud.SortAsc))
+	require.NoError(t, err)
+	require.Len(t, users, 3)
+
+	require.Len(t, users[0].Roles, 2)
+	assert.Equal(t, "admin", users[0].Roles[0].Name)
+	assert.Equal(t, "editor", users[0].Roles[1].Name)
+
+	require.Len(t, users[1].Roles, 1)
+	assert.Equal(t, "editor", users[1].Roles[0].Name)
+
+	// No roles yields an empty, non-nil slice
+	assert.NotNil(t, users[2].Roles)
+	assert.Empty(t, users[2].Roles)
+
+	// A partially configured mapper is rejected
+	_, err = userRepo.List(context.Background(), userRepo.WithRelation(crud.ManyToManyMapper[M2MUser, Role, int]{}))
+	assert.ErrorContains(t, err, "ManyToManyMapper is not fully configured")
+}
@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMaxRows(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithMaxRows(2))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for i := 1; i <= 3; i++ {
+		_, err := repo.Create(ctx, User{Username: fmt.Sprintf("user%d", i), Email: fmt.Sprintf("user%d@example.com", i)})
+		require.NoError(t, err)
+	}
+
+	// Exceeding the limit is an error, not a truncation
+	users, err := repo.List(ctx)
+	assert.ErrorIs(t, err, crud.ErrTooManyRows)
+	assert.Nil(t, users)
+
+	var buf []User
+	assert.ErrorIs(t, repo.ListInto(ctx, &buf), crud.ErrTooManyRows)
+
+	// Exactly the limit is fine
+	users, err = repo.List(ctx, repo.Limit(2))
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+
+	users, err = repo.List(ctx, repo.Where("username", "user1"))
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+}
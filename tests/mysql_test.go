@@ -170,3 +170,59 @@ func TestMySQLGetByIDWithLock(t *testing.T) {
 
 	require.NoError(t, tx.Commit())
 }
+
+func TestMySQLDuplicateErrorNamesKey(t *testing.T) {
+	db := setupMySQLTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.MySQLDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = repo.Create(ctx, User{Username: "dup", Email: "dup@example.com"})
+	require.NoError(t, err)
+
+	var dup *crud.ErrDuplicate
+	_, err = repo.Create(ctx, User{Username: "other", Email: "dup@example.com"})
+	require.ErrorAs(t, err, &dup)
+	assert.Equal(t, "email", dup.Constraint)
+}
+
+func TestMySQLUpdateWithResultCountsChangedRows(t *testing.T) {
+	db := setupMySQLTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.MySQLDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	user, err := repo.Create(ctx, User{Username: "result-user", Email: "result@example.com"})
+	require.NoError(t, err)
+
+	user.Email = "changed@example.com"
+	n, err := repo.UpdateWithResult(ctx, user)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	// Without clientFoundRows, MySQL does not count rows whose values did not change
+	n, err = repo.UpdateWithResult(ctx, user)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+}
+
+func TestMySQLCreateWithoutReturning(t *testing.T) {
+	db := setupMySQLTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.MySQLDialect{}, crud.WithQueryRecorder(2))
+	require.NoError(t, err)
+
+	created, err := repo.Create(context.Background(), User{Username: "fallback", Email: "fallback@example.com"})
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+	assert.Equal(t, "fallback", created.Username)
+
+	queries := repo.LastQueries()
+	require.Len(t, queries, 2)
+	assert.NotContains(t, queries[0].SQL, "RETURNING")
+}
@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNestedEagerLoading(t *testing.T) {
+	db := setupRelationsDB(t)
+	defer db.Close()
+
+	dialect := crud.SQLiteDialect{}
+	userRepo, err := crud.NewRepository[RelUser](db, "users", dialect)
+	require.NoError(t, err)
+	postRepo, err := crud.NewRepository[RelPost](db, "posts", dialect)
+	require.NoError(t, err)
+	profileRepo, err := crud.NewRepository[RelProfile](db, "profiles", dialect)
+	require.NoError(t, err)
+
+	// posts -> author -> profile, loaded on the posts fetched for each user
+	authorMapper := crud.ManyToOneMapper[RelPost, RelUser, int]{
+		Fetcher: func(ctx context.Context, ids []int) ([]RelUser, error) {
+			return userRepo.List(ctx, userRepo.WhereIn("id", crud.IntsToAnys(ids)...))
+		},
+		GetFK:      func(p *RelPost) int { return p.UserID },
+		GetPK:      func(u *RelUser) int { return u.ID },
+		SetRelated: func(p *RelPost, u *RelUser) { p.User = u },
+	}.With(crud.HasOneMapper[RelUser, RelProfile, int]{
+		Fetcher: func(ctx context.Context, ids []int) ([]RelProfile, error) {
+			return profileRepo.List(ctx, profileRepo.WhereIn("user_id", crud.IntsToAnys(ids)...))
+		},
+		GetPK:      func(u *RelUser) int { return u.ID },
+		GetFK:      func(p *RelProfile) int { return p.UserID },
+		SetRelated: func(u *RelUser, p *RelProfile) { u.Profile = p },
+	})
+
+	postsMapper := crud.OneToManyMapper[RelUser, RelPost, int]{
+		Fetcher: func(ctx context.Context, ids []int) ([]RelPost, error) {
+			return postRepo.List(ctx, postRepo.WhereIn("user_id", crud.IntsToAnys(ids)...), postRepo.OrderBy("id", crud.SortAsc))
+		},
+		GetPK:      func(u *RelUser) int { return u.ID },
+		GetFK:      func(p *RelPost) int { return p.UserID },
+		SetRelated: func(u *RelUser, p []*RelPost) { u.Posts = p },
+	}.With(authorMapper)
+
+	users, err := userRepo.List(context.Background(), userRepo.WithRelation(postsMapper), userRepo.OrderBy("id", crud.SortAsc))
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+
+	require.Len(t, users[0].Posts, 2)
+	require.NotNil(t, users[0].Posts[0].User)
+	assert.Equal(t, "John Doe", users[0].Posts[0].User.Name)
+	require.NotNil(t, users[0].Posts[0].User.Profile)
+	assert.Equal(t, "Johns Bio", users[0].Posts[0].User.Profile.Bio)
+
+	require.Len(t, users[1].Posts, 1)
+	require.NotNil(t, users[1].Posts[0].User)
+	assert.Equal(t, "Jane Doe", users[1].Posts[0].User.Name)
+	assert.Nil(t, users[1].Posts[0].User.Profile)
+
+	// The top-level parents only get the relation they asked for
+	assert.Nil(t, users[0].Profile)
+}
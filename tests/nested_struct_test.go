@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Address struct {
+	City string `db:"city"`
+	Zip  string `db:"zip"`
+}
+
+type Customer struct {
+	ID      int     `db:"id,pk"`
+	Name    string  `db:"name"`
+	Address Address `db:"address,prefix:address_"`
+	Billing Address `db:"billing,prefix:billing_"`
+}
+
+func TestNestedStructFlattening(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE customers (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT,
+		address_city TEXT,
+		address_zip TEXT,
+		billing_city TEXT,
+		billing_zip TEXT
+	)`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Customer](db, "customers", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	query, _, err := repo.BuildInsert(Customer{})
+	require.NoError(t, err)
+	assert.Equal(t, "INSERT INTO customers (name, address_city, address_zip, billing_city, billing_zip) VALUES (?, ?, ?, ?, ?)", query)
+
+	created, err := repo.Create(ctx, Customer{
+		Name:    "ACME",
+		Address: Address{City: "Berlin", Zip: "10115"},
+		Billing: Address{City: "Munich", Zip: "80331"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Berlin", created.Address.City)
+	assert.Equal(t, "80331", created.Billing.Zip)
+
+	// Nested columns can be updated and filtered on
+	created.Address.City = "Hamburg"
+	_, err = repo.Update(ctx, created)
+	require.NoError(t, err)
+
+	customers, err := repo.List(ctx, repo.Where("address_city", "Hamburg"))
+	require.NoError(t, err)
+	require.Len(t, customers, 1)
+	assert.Equal(t, Address{City: "Hamburg", Zip: "10115"}, customers[0].Address)
+	assert.Equal(t, Address{City: "Munich", Zip: "80331"}, customers[0].Billing)
+}
+
+func TestNestedStructFlattening_Errors(t *testing.T) {
+	type NotAStruct struct {
+		ID   int    `db:"id,pk"`
+		City string `db:"city,prefix:addr_"`
+	}
+	_, err := crud.NewRepository[NotAStruct](nil, "t", crud.SQLiteDialect{})
+	require.Error(t, err)
+	assert.Equal(t, "field City uses the prefix modifier but is not a struct", err.Error())
+
+	type Colliding struct {
+		ID          int     `db:"id,pk"`
+		AddressCity string  `db:"address_city"`
+		Address     Address `db:"address,prefix:address_"`
+	}
+	_, err = crud.NewRepository[Colliding](nil, "t", crud.SQLiteDialect{})
+	require.Error(t, err)
+	assert.Equal(t, "duplicate column 'address_city' in struct Colliding", err.Error())
+}
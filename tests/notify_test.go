@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dimatock/crud"
+	"github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithChangeNotifyRequiresPostgres(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	_, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithChangeNotify("users_changed"))
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+
+	_, err = crud.NewRepository[User](db, "users", crud.MySQLDialect{}, crud.WithChangeNotify("users_changed"))
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+
+	// An empty channel disables notifications
+	_, err = crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithChangeNotify(""))
+	assert.NoError(t, err)
+}
+
+// notifyingDialect publishes notifications with a plain SELECT, so that their payloads can be inspected
+// with a query recorder.
+type notifyingDialect struct {
+	crud.SQLiteDialect
+}
+
+func (notifyingDialect) NotifySQL() (string, error) {
+	return "SELECT ?, ?", nil
+}
+
+func TestChangeNotifySplitsLargePayloads(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", notifyingDialect{},
+		crud.WithChangeNotify("users_changed"), crud.WithQueryRecorder(1000))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	users := make([]User, 3000)
+	for i := range users {
+		users[i] = User{Username: fmt.Sprintf("user%d", i), Email: fmt.Sprintf("user%d@example.com", i)}
+	}
+	created, err := repo.CreateMany(ctx, users)
+	require.NoError(t, err)
+
+	var ids []any
+	notifications := 0
+	for _, q := range repo.LastQueries() {
+		if q.SQL != "SELECT ?, ?" {
+			continue
+		}
+		notifications++
+		payload := q.Args[1].(string)
+		assert.Less(t, len(payload), 8000)
+		var n crud.ChangeNotification
+		require.NoError(t, json.Unmarshal([]byte(payload), &n))
+		assert.Equal(t, "insert", n.Op)
+		ids = append(ids, n.IDs...)
+	}
+	assert.Greater(t, notifications, 1)
+	require.Len(t, ids, len(created))
+	for i, u := range created {
+		assert.Equal(t, float64(u.ID), ids[i])
+	}
+}
+
+func TestPostgresChangeNotify(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	defer db.Close()
+
+	listener := pq.NewListener(os.Getenv("POSTGRES_DSN"), time.Second, time.Minute, nil)
+	defer listener.Close()
+	require.NoError(t, listener.Listen("users_changed"))
+
+	repo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{}, crud.WithChangeNotify("users_changed"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	receive := func() crud.ChangeNotification {
+		select {
+		case n := <-listener.Notify:
+			var payload crud.ChangeNotification
+			require.NoError(t, json.Unmarshal([]byte(n.Extra), &payload))
+			return payload
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for notification")
+			return crud.ChangeNotification{}
+		}
+	}
+
+	created, err := repo.Create(ctx, User{Username: "pg-notify", Email: "notify@example.com"})
+	require.NoError(t, err)
+	n := receive()
+	assert.Equal(t, "users", n.Table)
+	assert.Equal(t, "insert", n.Op)
+	assert.Equal(t, []any{float64(created.ID)}, n.IDs)
+
+	created.Email = "changed@example.com"
+	_, err = repo.Update(ctx, created)
+	require.NoError(t, err)
+	assert.Equal(t, "update", receive().Op)
+
+	require.NoError(t, repo.Delete(ctx, created.ID))
+	assert.Equal(t, "delete", receive().Op)
+}
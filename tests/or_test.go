@@ -0,0 +1,113 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOr(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = repo.CreateMany(ctx, []User{
+		{Username: "user1", Email: "u1@example.com"},
+		{Username: "user2", Email: "u2@example.com"},
+		{Username: "user3", Email: "u3@example.com"},
+		{Username: "user4", Email: "u4@example.org"},
+	})
+	require.NoError(t, err)
+
+	users, err := repo.List(ctx,
+		repo.Or(repo.Where("username", "user1"), repo.Where("username", "user3"), repo.WhereLike("email", "%.org")),
+		repo.Where("id", ">", 1),
+		repo.OrderBy("id", crud.SortAsc),
+	)
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "user3", users[0].Username)
+	assert.Equal(t, "user4", users[1].Username)
+
+	// An empty group adds no condition
+	count, err := repo.Count(ctx, repo.Or())
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+}
+
+func TestOrPlaceholderNumbering(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	query, args, err := repo.ToSQL(
+		repo.Where("id", ">", 1),
+		repo.Or(
+			repo.Where("username", "a"),
+			repo.Where("email = ? OR email = ?", "b@example.com", "c@example.com"),
+			repo.WhereIn("id", 7, 8),
+		),
+		repo.Where("username", "!=", "d"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT users.id, users.username, users.email FROM users "+
+		"WHERE id > $1 AND (username = $2 OR (email = $3 OR email = $4) OR id IN ($5,$6)) AND username != $7", query)
+	assert.Equal(t, []any{1, "a", "b@example.com", "c@example.com", 7, 8, "d"}, args)
+}
+
+func TestNestedAndOrGroups(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	query, args, err := repo.ToSQL(
+		repo.Where("status", "active"),
+		repo.Or(
+			repo.And(
+				repo.Where("a", 1),
+				repo.Or(repo.Where("b", 2), repo.And(repo.Where("c", 3), repo.Where("d", ">", 4))),
+			),
+			repo.Where("e", 5),
+		),
+		repo.Where("f", 6),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT users.id, users.username, users.email FROM users "+
+		"WHERE status = $1 AND ((a = $2 AND (b = $3 OR (c = $4 AND d > $5))) OR e = $6) AND f = $7", query)
+	assert.Equal(t, []any{"active", 1, 2, 3, 4, 5, 6}, args)
+}
+
+func TestAndGroupOnSQLite(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = repo.CreateMany(ctx, []User{
+		{Username: "user1", Email: "u1@example.com"},
+		{Username: "user2", Email: "u2@example.com"},
+		{Username: "user3", Email: "u3@example.org"},
+	})
+	require.NoError(t, err)
+
+	users, err := repo.List(ctx, repo.Or(
+		repo.And(repo.Where("username", "user1"), repo.WhereLike("email", "%.com")),
+		repo.And(repo.Where("username", "user3"), repo.WhereLike("email", "%.com")),
+	))
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "user1", users[0].Username)
+}
@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginate(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for i := 1; i <= 5; i++ {
+		_, err := repo.Create(ctx, User{Username: fmt.Sprintf("user%d", i), Email: fmt.Sprintf("user%d@example.com", i)})
+		require.NoError(t, err)
+	}
+
+	page, err := repo.Paginate(ctx, 2, 2, repo.OrderBy("id", crud.SortAsc))
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), page.Total)
+	assert.Equal(t, 3, page.TotalPages)
+	assert.Equal(t, 2, page.Page)
+	assert.Equal(t, 2, page.PerPage)
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, "user3", page.Items[0].Username)
+	assert.Equal(t, "user4", page.Items[1].Username)
+
+	// The total respects the filters
+	page, err = repo.Paginate(ctx, 1, 10, repo.WhereIn("username", "user1", "user2"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), page.Total)
+	assert.Equal(t, 1, page.TotalPages)
+	assert.Len(t, page.Items, 2)
+
+	// A page past the end is empty but still reports the total
+	page, err = repo.Paginate(ctx, 4, 2)
+	require.NoError(t, err)
+	assert.Empty(t, page.Items)
+	assert.Equal(t, int64(5), page.Total)
+
+	_, err = repo.Paginate(ctx, 0, 2)
+	assert.Error(t, err)
+}
+
+func TestListByQuery(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for i := 1; i <= 5; i++ {
+		_, err := repo.Create(ctx, User{Username: fmt.Sprintf("user%d", i), Email: fmt.Sprintf("user%d@example.com", i)})
+		require.NoError(t, err)
+	}
+
+	page, err := repo.ListByQuery(ctx, crud.ListQuery[User]{
+		Filters: []crud.Option[User]{repo.WhereIn("username", "user1", "user2", "user3")},
+		Sort:    []crud.Option[User]{repo.OrderBy("id", crud.SortDesc)},
+		Page:    1,
+		PerPage: 2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), page.Total)
+	assert.Equal(t, 2, page.TotalPages)
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, "user3", page.Items[0].Username)
+	assert.Equal(t, "user2", page.Items[1].Username)
+
+	// A zero query returns the first page with the default page size
+	page, err = repo.ListByQuery(ctx, crud.ListQuery[User]{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.Page)
+	assert.Equal(t, crud.DefaultPerPage, page.PerPage)
+	assert.Len(t, page.Items, 5)
+
+	_, err = repo.ListByQuery(ctx, crud.ListQuery[User]{Page: -1})
+	assert.Error(t, err)
+}
@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithPartition(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	// SQLite has no partitions
+	sqliteRepo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+	_, err = sqliteRepo.List(ctx, sqliteRepo.WithPartition("p2024"))
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+
+	// MySQL selects the partition after the table name
+	mysqlRepo, err := crud.NewRepository[User](db, "users", crud.MySQLDialect{}, crud.WithQueryRecorder(1))
+	require.NoError(t, err)
+	_, _ = mysqlRepo.List(ctx, mysqlRepo.WithPartition("p2024"), mysqlRepo.Where("username", "john"))
+	queries := mysqlRepo.LastQueries()
+	require.Len(t, queries, 1)
+	assert.Equal(t, "SELECT users.id, users.username, users.email FROM users PARTITION (p2024) WHERE username = ?", queries[0].SQL)
+
+	_, err = mysqlRepo.List(ctx, mysqlRepo.WithPartition("p2024; DROP TABLE users"))
+	assert.Error(t, err)
+
+	// PostgreSQL queries the child table aliased to the parent's name
+	pgRepo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{})
+	require.NoError(t, err)
+	query, _, err := pgRepo.ToSQL(pgRepo.WithPartition("users_2024"))
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT users.id, users.username, users.email FROM users_2024 AS users", query)
+}
+
+func TestWithPartitionPostgresChildTable(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`
+	DROP TABLE IF EXISTS partitioned_users;
+	CREATE TABLE partitioned_users (id INT NOT NULL, username TEXT NOT NULL, email TEXT NOT NULL) PARTITION BY RANGE (id);
+	CREATE TABLE partitioned_users_low PARTITION OF partitioned_users FOR VALUES FROM (1) TO (100);
+	CREATE TABLE partitioned_users_high PARTITION OF partitioned_users FOR VALUES FROM (100) TO (200);
+	INSERT INTO partitioned_users (id, username, email) VALUES (1, 'current', 'current@example.com'), (150, 'archived', 'archived@example.com');
+	`)
+	require.NoError(t, err)
+
+	// The PostgreSQL dialect queries the child table aliased to the parent's name
+	repo, err := crud.NewRepository[User](db, "partitioned_users", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	query, _, err := repo.ToSQL(repo.WithPartition("partitioned_users_high"))
+	require.NoError(t, err)
+	assert.Contains(t, query, "FROM partitioned_users_high AS partitioned_users")
+
+	users, err := repo.List(context.Background(), repo.WithPartition("partitioned_users_high"))
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "archived@example.com", users[0].Email)
+}
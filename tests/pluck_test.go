@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluck(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, _ = repo.Create(ctx, User{Username: "alice", Email: "alice@example.com"})
+	_, _ = repo.Create(ctx, User{Username: "bob", Email: "bob@example.com"})
+
+	names, err := repo.Pluck(ctx, "username", repo.OrderBy("username", crud.SortDesc))
+	require.NoError(t, err)
+	assert.Equal(t, []any{"bob", "alice"}, names)
+
+	// Values have the field's Go type
+	ids, err := repo.Pluck(ctx, "id", repo.Where("username", "alice"))
+	require.NoError(t, err)
+	assert.Equal(t, []any{1}, ids)
+
+	_, err = repo.Pluck(ctx, "password")
+	assert.ErrorContains(t, err, "unknown column 'password'")
+}
+
+func TestWhereInPlucked(t *testing.T) {
+	db := setupTestDBWithPosts(t)
+	defer db.Close()
+
+	userRepo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+	postRepo, err := crud.NewRepository[Post](db, "posts", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	user1, _ := userRepo.Create(ctx, User{Username: "user1", Email: "user1@example.com"})
+	user2, _ := userRepo.Create(ctx, User{Username: "user2", Email: "user2@example.com"})
+	_, _ = userRepo.Create(ctx, User{Username: "user3", Email: "user3@example.com"})
+	_, _ = postRepo.Create(ctx, Post{UserID: user1.ID, Title: "Go"})
+	_, _ = postRepo.Create(ctx, Post{UserID: user2.ID, Title: "Go"})
+	_, _ = postRepo.Create(ctx, Post{UserID: user2.ID, Title: "SQL"})
+
+	authors, err := crud.WhereInPlucked[User](ctx, "id", postRepo, "user_id", postRepo.Where("title", "Go"))
+	require.NoError(t, err)
+	users, err := userRepo.List(ctx, authors, userRepo.OrderBy("id", crud.SortAsc))
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "user1", users[0].Username)
+	assert.Equal(t, "user2", users[1].Username)
+
+	// An empty pluck matches nothing rather than everything
+	none, err := crud.WhereInPlucked[User](ctx, "id", postRepo, "user_id", postRepo.Where("title", "Rust"))
+	require.NoError(t, err)
+	users, err = userRepo.List(ctx, none)
+	require.NoError(t, err)
+	assert.Empty(t, users)
+
+	_, err = crud.WhereInPlucked[User](ctx, "id", postRepo, "missing")
+	assert.Error(t, err)
+}
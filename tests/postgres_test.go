@@ -3,8 +3,10 @@ package tests
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/dimatock/crud"
 	_ "github.com/lib/pq" // PostgreSQL driver
@@ -87,3 +89,308 @@ func TestPostgresGetByIDWithLock(t *testing.T) {
 
 	require.NoError(t, tx.Commit())
 }
+
+func TestPostgresDeleteWhereReturning(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, _ = repo.Create(ctx, User{Username: "pg-temp-1", Email: "t1@example.com"})
+	_, _ = repo.Create(ctx, User{Username: "pg-temp-2", Email: "t2@example.com"})
+	_, _ = repo.Create(ctx, User{Username: "pg-keeper", Email: "k@example.com"})
+
+	// Uses the native DELETE ... RETURNING path
+	deleted, err := repo.DeleteWhereReturning(ctx, repo.WhereLike("username", "pg-temp-%"))
+	require.NoError(t, err)
+	assert.Len(t, deleted, 2)
+
+	remaining, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "pg-keeper", remaining[0].Username)
+}
+
+func TestPostgresPaginate(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, _ = repo.Create(ctx, User{Username: "pg-page-1", Email: "p1@example.com"})
+	_, _ = repo.Create(ctx, User{Username: "pg-page-2", Email: "p2@example.com"})
+	_, _ = repo.Create(ctx, User{Username: "pg-page-3", Email: "p3@example.com"})
+
+	// Uses COUNT(*) OVER() to fetch the total with the page
+	page, err := repo.Paginate(ctx, 1, 2, repo.OrderBy("id", crud.SortAsc))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), page.Total)
+	assert.Equal(t, 2, page.TotalPages)
+	assert.Len(t, page.Items, 2)
+
+	// Falls back to a COUNT query when the page is empty
+	page, err = repo.Paginate(ctx, 3, 2)
+	require.NoError(t, err)
+	assert.Empty(t, page.Items)
+	assert.Equal(t, int64(3), page.Total)
+}
+
+func TestPostgresCreateMany(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	// IDs are populated via RETURNING
+	created, err := repo.CreateMany(ctx, []User{
+		{Username: "pg-many-1", Email: "m1@example.com"},
+		{Username: "pg-many-2", Email: "m2@example.com"},
+	})
+	require.NoError(t, err)
+	require.Len(t, created, 2)
+	assert.NotZero(t, created[0].ID)
+	assert.Equal(t, created[0].ID+1, created[1].ID)
+	assert.Equal(t, "pg-many-2", created[1].Username)
+}
+
+func TestPostgresLimitWithTies(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, _ = repo.Create(ctx, User{Username: "pg-ties-a", Email: "same@example.com"})
+	_, _ = repo.Create(ctx, User{Username: "pg-ties-b", Email: "same@example.org"})
+	_, _ = repo.Create(ctx, User{Username: "pg-ties-c", Email: "other@example.com"})
+
+	// Ordering by a truncated email makes the first two rows tie
+	users, err := repo.List(ctx, repo.Where("username LIKE ?", "pg-ties-%"), repo.OrderBy("LEFT(email, 4)", crud.SortDesc), repo.LimitWithTies(1))
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+}
+
+func TestPostgresUpdateMany(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	created, err := repo.CreateMany(ctx, []User{
+		{Username: "pg-many-1", Email: "pm1@example.com"},
+		{Username: "pg-many-2", Email: "pm2@example.com"},
+	})
+	require.NoError(t, err)
+
+	for i := range created {
+		created[i].Email = "updated-" + created[i].Email
+	}
+	updated, err := repo.UpdateMany(ctx, created)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), updated)
+
+	user, err := repo.GetByID(ctx, created[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "updated-pm2@example.com", user.Email)
+}
+
+func TestPostgresUpdateManySkipsSoftDeleted(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`DROP TABLE IF EXISTS documents; CREATE TABLE documents (id SERIAL PRIMARY KEY, title TEXT NOT NULL, deleted_at TIMESTAMPTZ)`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Document](db, "documents", crud.PostgresDialect{}, crud.WithSoftDelete("deleted_at"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	docs, err := repo.CreateMany(ctx, []Document{{Title: "live"}, {Title: "trash"}})
+	require.NoError(t, err)
+	require.NoError(t, repo.Delete(ctx, docs[1].ID))
+
+	docs[0].Title = "live-2"
+	docs[1].Title = "revived"
+	n, err := repo.UpdateMany(ctx, docs)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	var title string
+	require.NoError(t, db.QueryRow(`SELECT title FROM documents WHERE id = $1`, docs[1].ID).Scan(&title))
+	assert.Equal(t, "trash", title)
+}
+
+func TestPostgresWhereJSONContains(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`DROP TABLE IF EXISTS json_documents; CREATE TABLE json_documents (id SERIAL PRIMARY KEY, data JSONB NOT NULL)`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[JSONDocument](db, "json_documents", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = repo.CreateMany(ctx, []JSONDocument{
+		{Data: `{"tags": ["go", "sql"], "draft": false}`},
+		{Data: `{"tags": ["rust"]}`},
+	})
+	require.NoError(t, err)
+
+	docs, err := repo.List(ctx, repo.WhereJSONContains("data", map[string]any{"tags": []string{"go"}}))
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Contains(t, docs[0].Data, "sql")
+}
+
+func TestPostgresDuplicateErrorNamesConstraint(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = repo.Create(ctx, User{Username: "dup", Email: "dup@example.com"})
+	require.NoError(t, err)
+
+	var dup *crud.ErrDuplicate
+	_, err = repo.Create(ctx, User{Username: "dup", Email: "other@example.com"})
+	require.ErrorAs(t, err, &dup)
+	assert.Equal(t, "users_username_key", dup.Constraint)
+}
+
+func TestPostgresSkipLockedClaimsDisjointRows(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = repo.CreateMany(ctx, []User{
+		{Username: "job1", Email: "job1@example.com"},
+		{Username: "job2", Email: "job2@example.com"},
+	})
+	require.NoError(t, err)
+
+	claim := func(tx *sql.Tx) []User {
+		claimed, err := repo.WithTx(tx).List(ctx,
+			repo.OrderBy("id", crud.SortAsc), repo.Limit(1), repo.LockForUpdate(), repo.SkipLocked())
+		require.NoError(t, err)
+		return claimed
+	}
+
+	tx1, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx1.Rollback()
+	tx2, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx2.Rollback()
+
+	first := claim(tx1)
+	second := claim(tx2)
+	require.Len(t, first, 1)
+	require.Len(t, second, 1)
+	assert.Equal(t, "job1", first[0].Username)
+	assert.Equal(t, "job2", second[0].Username)
+}
+
+func TestPostgresWithSchema(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`DROP SCHEMA IF EXISTS analytics CASCADE; CREATE SCHEMA analytics;
+		CREATE TABLE analytics.users (id SERIAL PRIMARY KEY, username TEXT NOT NULL UNIQUE, email TEXT NOT NULL UNIQUE);`)
+	require.NoError(t, err)
+	defer db.Exec(`DROP SCHEMA analytics CASCADE`)
+
+	repo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{}, crud.WithSchema("analytics"))
+	require.NoError(t, err)
+	public, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	created, err := repo.Create(ctx, User{Username: "alice", Email: "alice@example.com"})
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+	_, err = repo.CreateMany(ctx, []User{{Username: "bob", Email: "bob@example.com"}})
+	require.NoError(t, err)
+
+	created.Email = "alice@analytics.example.com"
+	_, err = repo.Update(ctx, created)
+	require.NoError(t, err)
+
+	upserted, err := repo.CreateOrUpdate(ctx, User{ID: created.ID, Username: "alice2", Email: "alice2@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "alice2", upserted.Username)
+
+	got, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "alice2@example.com", got.Email)
+
+	require.NoError(t, repo.Delete(ctx, created.ID))
+	count, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	count, err = public.Count(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestPostgresAutoMigrate(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`DROP TABLE IF EXISTS prototypes`)
+	require.NoError(t, err)
+	defer db.Exec(`DROP TABLE prototypes`)
+
+	repo, err := crud.NewRepository[Prototype](db, "prototypes", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, repo.AutoMigrate(ctx))
+	require.NoError(t, repo.AutoMigrate(ctx))
+
+	created, err := repo.Create(ctx, Prototype{Name: "first", Payload: []byte("x"), CreatedAt: time.Now()})
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+}
+
+func TestPostgresWhereInLarge(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for i := 1; i <= 5; i++ {
+		_, err := repo.Create(ctx, User{Username: fmt.Sprintf("user%d", i), Email: fmt.Sprintf("user%d@example.com", i)})
+		require.NoError(t, err)
+	}
+
+	ids := make([]any, 20000)
+	for i := range ids {
+		ids[i] = i + 3
+	}
+	users, err := repo.List(ctx, repo.WhereInLarge("id", ids), repo.OrderBy("id", crud.SortAsc))
+	require.NoError(t, err)
+	require.Len(t, users, 3)
+	assert.Equal(t, "user3", users[0].Username)
+
+	count, err := repo.Count(ctx, repo.WhereInLarge("id", ids))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
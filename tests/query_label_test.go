@@ -0,0 +1,21 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dimatock/crud"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryLabel(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, "", crud.QueryLabel(ctx))
+
+	labeled := crud.WithQueryLabel(ctx, "GetUserDashboard")
+	assert.Equal(t, "GetUserDashboard", crud.QueryLabel(labeled))
+
+	// The innermost label wins
+	relabeled := crud.WithQueryLabel(labeled, "ListOrders")
+	assert.Equal(t, "ListOrders", crud.QueryLabel(relabeled))
+}
@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type loggedQuery struct {
+	sql      string
+	args     []any
+	duration time.Duration
+	err      error
+}
+
+type capturingLogger struct {
+	mu      sync.Mutex
+	queries []loggedQuery
+}
+
+func (l *capturingLogger) LogQuery(ctx context.Context, sql string, args []any, duration time.Duration, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.queries = append(l.queries, loggedQuery{sql: sql, args: append([]any(nil), args...), duration: duration, err: err})
+}
+
+func (l *capturingLogger) take() []loggedQuery {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	queries := l.queries
+	l.queries = nil
+	return queries
+}
+
+func TestWithLogger(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	logger := &capturingLogger{}
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithLogger(logger))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = repo.Create(ctx, User{Username: "john", Email: "john@example.com"})
+	require.NoError(t, err)
+
+	queries := logger.take()
+	require.NotEmpty(t, queries)
+	assert.Equal(t, "INSERT INTO users (username, email) VALUES (?, ?)", queries[0].sql)
+	assert.Equal(t, []any{"john", "john@example.com"}, queries[0].args)
+	assert.NoError(t, queries[0].err)
+	assert.Positive(t, queries[0].duration)
+
+	// Failures are logged with their error
+	_, err = repo.Create(ctx, User{Username: "john", Email: "other@example.com"})
+	require.Error(t, err)
+	queries = logger.take()
+	require.Len(t, queries, 1)
+	assert.Error(t, queries[0].err)
+
+	// The transactional repository keeps the logger
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	defer tx.Rollback()
+	_, err = repo.WithTx(tx).List(ctx, repo.Where("username", "john"))
+	require.NoError(t, err)
+	queries = logger.take()
+	require.Len(t, queries, 1)
+	assert.Equal(t, "SELECT users.id, users.username, users.email FROM users WHERE username = ?", queries[0].sql)
+	assert.Equal(t, []any{"john"}, queries[0].args)
+}
@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryRecorder(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithQueryRecorder(2))
+	require.NoError(t, err)
+
+	ctx := crud.WithQueryLabel(context.Background(), "SignUp")
+
+	// Create issues an INSERT followed by a SELECT to load the new row
+	created, err := repo.Create(ctx, User{Username: "john", Email: "john@example.com"})
+	require.NoError(t, err)
+
+	queries := repo.LastQueries()
+	require.Len(t, queries, 2)
+	assert.Equal(t, "INSERT", queries[0].Op)
+	assert.Equal(t, "INSERT INTO users (username, email) VALUES (?, ?)", queries[0].SQL)
+	assert.Equal(t, []any{"john", "john@example.com"}, queries[0].Args)
+	assert.Equal(t, "SignUp", queries[0].Label)
+	assert.Equal(t, "SELECT", queries[1].Op)
+
+	// Only the last N statements are kept, including those run through a transactional copy
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	_, err = repo.WithTx(tx).List(context.Background(), repo.Where("username", "john"))
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	err = repo.Delete(context.Background(), created.ID)
+	require.NoError(t, err)
+
+	queries = repo.LastQueries()
+	require.Len(t, queries, 2)
+	assert.Equal(t, "SELECT users.id, users.username, users.email FROM users WHERE username = ?", queries[0].SQL)
+	assert.Equal(t, []any{"john"}, queries[0].Args)
+	assert.Equal(t, "", queries[0].Label)
+	assert.Equal(t, "DELETE", queries[1].Op)
+}
+
+func TestQueryRecorder_Disabled(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	_, err = repo.List(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, repo.LastQueries())
+}
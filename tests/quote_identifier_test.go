@@ -0,0 +1,161 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ReservedRow maps a table and columns named after SQL keywords.
+type ReservedRow struct {
+	ID     int    `db:"id,pk"`
+	Select string `db:"select"`
+	Group  string `db:"group"`
+}
+
+func setupReservedDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	_, err = db.Exec(`CREATE TABLE "order" (id INTEGER PRIMARY KEY AUTOINCREMENT, "select" TEXT, "group" TEXT)`)
+	require.NoError(t, err)
+	return db
+}
+
+func TestReservedWordIdentifiers(t *testing.T) {
+	db := setupReservedDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	repo, err := crud.NewRepository[ReservedRow](db, "order", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	created, err := repo.Create(ctx, ReservedRow{Select: "a", Group: "g2"})
+	require.NoError(t, err)
+	_, err = repo.CreateMany(ctx, []ReservedRow{{Select: "b", Group: "g1"}, {Select: "c", Group: "g3"}})
+	require.NoError(t, err)
+
+	got, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "a", got.Select)
+
+	got.Group = "g0"
+	_, err = repo.Update(ctx, got)
+	require.NoError(t, err)
+
+	items, err := repo.List(ctx, repo.OrderBy("group", crud.SortAsc))
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, []string{"a", "b", "c"}, []string{items[0].Select, items[1].Select, items[2].Select})
+
+	items, err = repo.List(ctx, repo.Where("select", "b"))
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "g1", items[0].Group)
+
+	count, err := repo.Count(ctx, repo.Where("group", "g3"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	_, err = repo.CreateOrUpdate(ctx, ReservedRow{ID: created.ID, Select: "z", Group: "g9"})
+	require.NoError(t, err)
+	got, err = repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "z", got.Select)
+
+	require.NoError(t, repo.Delete(ctx, created.ID))
+	count, err = repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestReservedWordIdentifiersToSQL(t *testing.T) {
+	db := setupReservedDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[ReservedRow](db, "order", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	sql, _, err := repo.ToSQL(repo.Where("select", "a"), repo.OrderBy("id", crud.SortAsc))
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT "order".id, "order"."select", "order"."group" FROM "order" WHERE "select" = ? ORDER BY id ASC`, sql)
+
+	// Ordinary names are left unquoted
+	users, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+	sql, _, err = users.ToSQL(users.Where("username", "john"))
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT users.id, users.username, users.email FROM users WHERE username = ?", sql)
+}
+
+func TestDialectQuoteIdentifier(t *testing.T) {
+	assert.Equal(t, "`users`.`order`", crud.MySQLDialect{}.QuoteIdentifier("users.order"))
+	assert.Equal(t, `"users"."order"`, crud.PostgresDialect{}.QuoteIdentifier("users.order"))
+	assert.Equal(t, `"users"."order"`, crud.SQLiteDialect{}.QuoteIdentifier("users.order"))
+	assert.Equal(t, "[users].[order]", crud.SQLServerDialect{}.QuoteIdentifier("users.order"))
+	assert.Equal(t, `"we""ird"`, crud.PostgresDialect{}.QuoteIdentifier(`we"ird`))
+}
+
+func TestDialectReservedWords(t *testing.T) {
+	// Words reserved by a single database are only quoted by its dialect
+	assert.True(t, crud.MySQLDialect{}.IsReserved("lock"))
+	assert.True(t, crud.MySQLDialect{}.IsReserved("Partition"))
+	assert.False(t, crud.SQLiteDialect{}.IsReserved("lock"))
+	assert.True(t, crud.PostgresDialect{}.IsReserved("leading"))
+	assert.False(t, crud.SQLServerDialect{}.IsReserved("leading"))
+	assert.True(t, crud.SQLServerDialect{}.IsReserved("top"))
+	for _, d := range []crud.ReservedWordChecker{crud.MySQLDialect{}, crud.PostgresDialect{}, crud.SQLServerDialect{}, crud.SQLiteDialect{}} {
+		assert.True(t, d.IsReserved("order"))
+		assert.False(t, d.IsReserved("username"))
+	}
+}
+
+// LockRow has a column named after a MySQL-only keyword.
+type LockRow struct {
+	ID   int    `db:"id,pk"`
+	Lock string `db:"lock"`
+}
+
+func TestReservedWordsPerDialectToSQL(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	mysqlRepo, err := crud.NewRepository[LockRow](db, "locks", crud.MySQLDialect{})
+	require.NoError(t, err)
+	query, _, err := mysqlRepo.ToSQL(mysqlRepo.Where("lock", "a"))
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT locks.id, locks.`lock` FROM locks WHERE `lock` = ?", query)
+
+	sqliteRepo, err := crud.NewRepository[LockRow](db, "locks", crud.SQLiteDialect{})
+	require.NoError(t, err)
+	query, _, err = sqliteRepo.ToSQL(sqliteRepo.Where("lock", "a"))
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT locks.id, locks.lock FROM locks WHERE lock = ?", query)
+}
+
+// GroupCount counts the rows of each group of ReservedRow.
+type GroupCount struct {
+	Group string `db:"group"`
+	N     int64  `db:"n"`
+}
+
+func TestGroupedAggregateReservedGroupColumn(t *testing.T) {
+	db := setupReservedDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	repo, err := crud.NewRepository[ReservedRow](db, "order", crud.SQLiteDialect{})
+	require.NoError(t, err)
+	_, err = repo.CreateMany(ctx, []ReservedRow{{Select: "a", Group: "g1"}, {Select: "b", Group: "g1"}, {Select: "c", Group: "g2"}})
+	require.NoError(t, err)
+
+	counts, err := crud.GroupedAggregate[ReservedRow, GroupCount](ctx, repo,
+		[]string{"group"}, []string{"COUNT(*) AS n"}, repo.OrderBy("group", crud.SortAsc))
+	require.NoError(t, err)
+	assert.Equal(t, []GroupCount{{Group: "g1", N: 2}, {Group: "g2", N: 1}}, counts)
+}
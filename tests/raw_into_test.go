@@ -0,0 +1,183 @@
+package tests
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type AuthorPostCount struct {
+	Username string `db:"username"`
+	Posts    int    `db:"posts"`
+}
+
+func TestRawInto(t *testing.T) {
+	db := setupTestDBWithPosts(t)
+	defer db.Close()
+
+	userRepo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+	postRepo, err := crud.NewRepository[Post](db, "posts", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	user1, _ := userRepo.Create(ctx, User{Username: "user1", Email: "user1@example.com"})
+	user2, _ := userRepo.Create(ctx, User{Username: "user2", Email: "user2@example.com"})
+	_, err = postRepo.CreateMany(ctx, []Post{{UserID: user1.ID, Title: "a"}, {UserID: user1.ID, Title: "b"}, {UserID: user2.ID, Title: "c"}})
+	require.NoError(t, err)
+
+	query := `SELECT users.username AS username, COUNT(posts.id) AS posts
+		FROM users JOIN posts ON posts.user_id = users.id
+		WHERE users.id >= ? GROUP BY users.username ORDER BY users.username`
+	counts, err := crud.RawInto[User, AuthorPostCount](ctx, userRepo, query, []any{user1.ID})
+	require.NoError(t, err)
+	assert.Equal(t, []AuthorPostCount{{Username: "user1", Posts: 2}, {Username: "user2", Posts: 1}}, counts)
+
+	// Unmapped columns are reported
+	_, err = crud.RawInto[User, AuthorPostCount](ctx, userRepo, "SELECT email FROM users", nil)
+	assert.ErrorContains(t, err, "column 'email' has no matching db tag")
+
+	// Runs inside the repository's transaction
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	txRepo := userRepo.WithTx(tx)
+	_, err = txRepo.Create(ctx, User{Username: "user3", Email: "user3@example.com"})
+	require.NoError(t, err)
+	names, err := crud.RawInto[User, struct {
+		Username string `db:"username"`
+	}](ctx, txRepo, "SELECT username FROM users WHERE username = ?", []any{"user3"})
+	require.NoError(t, err)
+	assert.Len(t, names, 1)
+}
+
+type Membership struct {
+	ID     int    `db:"id,pk"`
+	UserID int    `db:"UserID"`
+	Role   string `db:"Role"`
+}
+
+func TestScanMatchesColumnsCaseInsensitively(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	// Like PostgreSQL with unquoted identifiers, the table stores lower-cased column names
+	_, err := db.Exec(`CREATE TABLE memberships (id INTEGER PRIMARY KEY AUTOINCREMENT, userid INTEGER, role TEXT)`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Membership](db, "memberships", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	created, err := repo.Create(ctx, Membership{UserID: 7, Role: "admin"})
+	require.NoError(t, err)
+
+	got, err := crud.RawInto[Membership, Membership](ctx, repo, `SELECT id, userid, role FROM memberships`, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []Membership{created}, got)
+
+	listed, err := repo.List(ctx, repo.Columns("UserID", "id"))
+	require.NoError(t, err)
+	assert.Equal(t, []Membership{{ID: created.ID, UserID: 7}}, listed)
+
+	// An exact match wins over a case-insensitive one
+	type codes struct {
+		Lower string `db:"code"`
+		Upper string `db:"CODE"`
+	}
+	both, err := crud.RawInto[Membership, codes](ctx, repo, `SELECT 'a' AS CODE, 'b' AS code`, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []codes{{Lower: "b", Upper: "a"}}, both)
+}
+
+type UserWithPostCount struct {
+	User
+	PostCount int `db:"post_count"`
+}
+
+func TestListAsScansEmbeddedEntityAndExtraColumns(t *testing.T) {
+	db := setupTestDBWithPosts(t)
+	defer db.Close()
+
+	userRepo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+	postRepo, err := crud.NewRepository[Post](db, "posts", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	user1, _ := userRepo.Create(ctx, User{Username: "user1", Email: "user1@example.com"})
+	user2, _ := userRepo.Create(ctx, User{Username: "user2", Email: "user2@example.com"})
+	_, err = postRepo.CreateMany(ctx, []Post{{UserID: user1.ID, Title: "a"}, {UserID: user1.ID, Title: "b"}})
+	require.NoError(t, err)
+
+	postCount := []string{"(SELECT COUNT(*) FROM posts WHERE posts.user_id = users.id) AS post_count"}
+	results, err := crud.ListAs[User, UserWithPostCount](ctx, userRepo, postCount, userRepo.OrderBy("id", crud.SortAsc))
+	require.NoError(t, err)
+	assert.Equal(t, []UserWithPostCount{{User: user1, PostCount: 2}, {User: user2, PostCount: 0}}, results)
+
+	results, err = crud.ListAs[User, UserWithPostCount](ctx, userRepo, postCount, userRepo.Where("username", "user2"))
+	require.NoError(t, err)
+	assert.Equal(t, []UserWithPostCount{{User: user2}}, results)
+
+	// The same result type works with a hand-written join
+	query := `SELECT users.id, users.username, users.email, COUNT(posts.id) AS post_count
+		FROM users JOIN posts ON posts.user_id = users.id GROUP BY users.id, users.username, users.email`
+	results, err = crud.RawInto[User, UserWithPostCount](ctx, userRepo, query, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []UserWithPostCount{{User: user1, PostCount: 2}}, results)
+
+	// Extra columns need a matching tag
+	_, err = crud.ListAs[User, User](ctx, userRepo, postCount)
+	assert.ErrorContains(t, err, "column 'post_count' has no matching db tag")
+}
+
+// customUserRepo extends the repository by embedding it, as described in the README.
+type customUserRepo struct {
+	crud.RepositoryInterface[User]
+}
+
+// mockUserRepo stands for an implementation of RepositoryInterface in another package, e.g. a mock.
+type mockUserRepo struct {
+	crud.RepositoryInterface[User]
+}
+
+func TestRepositoryInterfaceIsImplementable(t *testing.T) {
+	typ := reflect.TypeFor[crud.RepositoryInterface[User]]()
+	for i := 0; i < typ.NumMethod(); i++ {
+		assert.True(t, typ.Method(i).IsExported(), "unexported method %s", typ.Method(i).Name)
+	}
+}
+
+func TestRawIntoEmbeddedRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	base, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+	ctx := context.Background()
+	_, err = base.Create(ctx, User{Username: "user1", Email: "user1@example.com"})
+	require.NoError(t, err)
+
+	repo := &customUserRepo{RepositoryInterface: base}
+	names, err := crud.RawInto[User, struct {
+		Username string `db:"username"`
+	}](ctx, repo, "SELECT username FROM users", nil)
+	require.NoError(t, err)
+	assert.Len(t, names, 1)
+
+	counts, err := crud.GroupedAggregate[User, struct {
+		Username string `db:"username"`
+		N        int64  `db:"n"`
+	}](ctx, repo, []string{"username"}, []string{"COUNT(*) AS n"})
+	require.NoError(t, err)
+	assert.Len(t, counts, 1)
+
+	// Implementations not backed by NewRepository are reported
+	_, err = crud.ListAs[User, User](ctx, mockUserRepo{}, nil)
+	assert.ErrorContains(t, err, "does not embed a repository created with NewRepository")
+}
@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadReplicaRouting(t *testing.T) {
+	primary := setupTestDB(t)
+	defer primary.Close()
+	replica := setupTestDB(t)
+	defer replica.Close()
+
+	ctx := context.Background()
+
+	// Seed the replica directly so we can tell which database served a read
+	_, err := replica.Exec("INSERT INTO users (username, email) VALUES ('replica-user', 'r@example.com')")
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[User](primary, "users", crud.SQLiteDialect{}, crud.WithReadReplica(replica))
+	require.NoError(t, err)
+
+	// Writes go to the primary, and the created row is read back from the primary
+	created, err := repo.Create(ctx, User{Username: "primary-user", Email: "p@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "primary-user", created.Username)
+
+	// Reads go to the replica by default
+	users, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "replica-user", users[0].Username)
+
+	// PreferPrimary overrides the routing
+	users, err = repo.List(ctx, repo.PreferPrimary())
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "primary-user", users[0].Username)
+
+	user, err := repo.GetByID(ctx, created.ID, repo.PreferPrimary())
+	require.NoError(t, err)
+	assert.Equal(t, "primary-user", user.Username)
+
+	// Inside a transaction reads use the transaction unless PreferReplica is given
+	tx, err := primary.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	txRepo := repo.WithTx(tx)
+
+	users, err = txRepo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "primary-user", users[0].Username)
+
+	users, err = txRepo.List(ctx, txRepo.PreferReplica())
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "replica-user", users[0].Username)
+}
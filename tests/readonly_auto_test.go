@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Issue struct {
+	ID        int       `db:"id,pk,auto"`
+	Title     string    `db:"title"`
+	Code      string    `db:"code,auto"`
+	CreatedAt time.Time `db:"created_at,readonly"`
+}
+
+type Voucher struct {
+	Code      string    `db:"code,pk"`
+	Amount    int       `db:"amount"`
+	CreatedAt time.Time `db:"created_at,readonly"`
+}
+
+func setupGeneratedColumnsDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	_, err = db.Exec(`
+		CREATE TABLE issues (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			title TEXT NOT NULL,
+			code TEXT NOT NULL DEFAULT 'generated',
+			created_at DATETIME NOT NULL DEFAULT '2024-01-02 03:04:05'
+		);
+		CREATE TABLE vouchers (
+			code TEXT PRIMARY KEY,
+			amount INTEGER NOT NULL,
+			created_at DATETIME NOT NULL DEFAULT '2024-01-02 03:04:05'
+		);`)
+	require.NoError(t, err)
+	return db
+}
+
+var generatedCreatedAt = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestReadonlyAndAutoColumnsAreNotInserted(t *testing.T) {
+	db := setupGeneratedColumnsDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[Issue](db, "issues", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	sql, args, err := repo.BuildInsert(Issue{Title: "a", Code: "ignored", CreatedAt: time.Now()})
+	require.NoError(t, err)
+	assert.Equal(t, "INSERT INTO issues (title) VALUES (?)", sql)
+	assert.Equal(t, []any{"a"}, args)
+
+	ctx := context.Background()
+	created, err := repo.Create(ctx, Issue{Title: "a", Code: "ignored", CreatedAt: time.Now()})
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+	assert.Equal(t, "generated", created.Code)
+	assert.True(t, generatedCreatedAt.Equal(created.CreatedAt))
+
+	// Auto columns can be updated, readonly columns keep their stored value
+	created.Code = "changed"
+	created.CreatedAt = time.Now()
+	_, err = repo.Update(ctx, created)
+	require.NoError(t, err)
+	stored, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "changed", stored.Code)
+	assert.True(t, generatedCreatedAt.Equal(stored.CreatedAt))
+
+	err = repo.UpdateFields(ctx, created.ID, map[string]any{"created_at": time.Now()})
+	assert.ErrorContains(t, err, "cannot update the readonly column 'created_at'")
+}
+
+func TestReadonlyColumnsAreReadBackForProvidedKeys(t *testing.T) {
+	db := setupGeneratedColumnsDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[Voucher](db, "vouchers", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	created, err := repo.Create(ctx, Voucher{Code: "SPRING", Amount: 10})
+	require.NoError(t, err)
+	assert.True(t, generatedCreatedAt.Equal(created.CreatedAt))
+
+	upserted, err := repo.CreateOrUpdate(ctx, Voucher{Code: "SPRING", Amount: 20, CreatedAt: time.Now()})
+	require.NoError(t, err)
+	assert.Equal(t, 20, upserted.Amount)
+	assert.True(t, generatedCreatedAt.Equal(upserted.CreatedAt))
+}
+
+type ReadonlyKey struct {
+	ID int `db:"id,pk,readonly"`
+}
+
+type GeneratedStringKey struct {
+	ID   string `db:"id,pk,auto"`
+	Name string `db:"name"`
+}
+
+func TestGeneratedColumnModifierErrors(t *testing.T) {
+	db := setupGeneratedColumnsDB(t)
+	defer db.Close()
+
+	_, err := crud.NewRepository[ReadonlyKey](db, "issues", crud.SQLiteDialect{})
+	assert.ErrorContains(t, err, "cannot be readonly")
+
+	_, err = crud.NewRepository[GeneratedStringKey](db, "issues", crud.SQLiteDialect{})
+	assert.ErrorContains(t, err, "must be an integer on this dialect")
+
+	// PostgreSQL reads generated keys of any type back with RETURNING
+	repo, err := crud.NewRepository[GeneratedStringKey](db, "issues", crud.PostgresDialect{})
+	require.NoError(t, err)
+	sql, _, err := repo.BuildInsert(GeneratedStringKey{Name: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, "INSERT INTO issues (name) VALUES ($1) RETURNING id, name", sql)
+}
@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowLockOptionsSQL(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	tests := []struct {
+		name    string
+		dialect crud.Dialect
+		opts    []crud.Option[User]
+		want    string
+	}{
+		{"postgres update", crud.PostgresDialect{}, []crud.Option[User]{crud.LockForUpdate[User]()},
+			"SELECT users.id, users.username, users.email FROM users FOR UPDATE"},
+		{"postgres share skip locked", crud.PostgresDialect{}, []crud.Option[User]{crud.SkipLocked[User](), crud.LockForShare[User]()},
+			"SELECT users.id, users.username, users.email FROM users FOR SHARE SKIP LOCKED"},
+		{"mysql update skip locked", crud.MySQLDialect{}, []crud.Option[User]{crud.LockForUpdate[User](), crud.SkipLocked[User]()},
+			"SELECT users.id, users.username, users.email FROM users FOR UPDATE SKIP LOCKED"},
+		{"sqlserver update skip locked", crud.SQLServerDialect{}, []crud.Option[User]{crud.LockForUpdate[User](), crud.SkipLocked[User]()},
+			"SELECT users.id, users.username, users.email FROM users WITH (UPDLOCK, ROWLOCK, READPAST)"},
+		{"sqlite", crud.SQLiteDialect{}, []crud.Option[User]{crud.LockForUpdate[User](), crud.SkipLocked[User]()},
+			"SELECT users.id, users.username, users.email FROM users"},
+		{"skip locked alone", crud.PostgresDialect{}, []crud.Option[User]{crud.SkipLocked[User]()},
+			"SELECT users.id, users.username, users.email FROM users"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, err := crud.NewRepository[User](db, "users", tt.dialect)
+			require.NoError(t, err)
+			sql, _, err := repo.ToSQL(tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, sql)
+		})
+	}
+}
+
+func TestGetForUpdateIfOnSQLite(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	created, err := repo.Create(ctx, User{Username: "user1", Email: "u1@example.com"})
+	require.NoError(t, err)
+
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	// The row lock is a no-op on SQLite, so the typed lock works where Lock("FOR UPDATE") would not
+	item, ok, err := repo.WithTx(tx).GetForUpdateIf(ctx, created.ID, func(u User) bool { return u.Username == "user1" })
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, created, item)
+}
@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSchemaDB attaches a second in-memory database as the analytics schema, with a users table in both
+// schemas so that a statement targeting the wrong one is noticed.
+func setupSchemaDB(t *testing.T) *sql.DB {
+	db := setupTestDB(t)
+	// The attachment is per connection
+	db.SetMaxOpenConns(1)
+	_, err := db.Exec(`ATTACH DATABASE ':memory:' AS analytics`)
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE TABLE analytics.users (id INTEGER PRIMARY KEY AUTOINCREMENT, username TEXT NOT NULL UNIQUE, email TEXT NOT NULL UNIQUE)`)
+	require.NoError(t, err)
+	return db
+}
+
+func TestWithSchemaTargetsQualifiedTable(t *testing.T) {
+	db := setupSchemaDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithSchema("analytics"))
+	require.NoError(t, err)
+	main, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	created, err := repo.Create(ctx, User{Username: "alice", Email: "alice@example.com"})
+	require.NoError(t, err)
+	_, err = repo.CreateMany(ctx, []User{{Username: "bob", Email: "bob@example.com"}})
+	require.NoError(t, err)
+
+	got, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", got.Username)
+
+	got.Email = "alice@analytics.example.com"
+	_, err = repo.Update(ctx, got)
+	require.NoError(t, err)
+
+	_, err = repo.CreateOrUpdate(ctx, User{ID: created.ID, Username: "alice2", Email: "alice2@example.com"})
+	require.NoError(t, err)
+
+	items, err := repo.List(ctx, repo.Where("username", "alice2"))
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "alice2@example.com", items[0].Email)
+
+	require.NoError(t, repo.Delete(ctx, created.ID))
+	count, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// Nothing reached the table in the main schema
+	count, err = main.Count(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	sql, _, err := repo.ToSQL()
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT analytics.users.id, analytics.users.username, analytics.users.email FROM analytics.users", sql)
+}
+
+func TestWithSchemaRejectsQualifiedTableName(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	_, err := crud.NewRepository[User](db, "analytics.users", crud.SQLiteDialect{}, crud.WithSchema("analytics"))
+	assert.ErrorContains(t, err, "already schema-qualified")
+}
+
+func TestWithSchemaQuotesReservedParts(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "order", crud.PostgresDialect{}, crud.WithSchema("analytics"))
+	require.NoError(t, err)
+
+	sql, _, err := repo.ToSQL(repo.Where("id", 1))
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT analytics."order".id, analytics."order".username, analytics."order".email FROM analytics."order" WHERE id = $1`, sql)
+}
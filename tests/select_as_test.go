@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PostWithAuthor is read from posts joined with users; author_id and author_name come from users.
+type PostWithAuthor struct {
+	ID         int    `db:"id,pk"`
+	Title      string `db:"title"`
+	AuthorID   int    `db:"author_id"`
+	AuthorName string `db:"author_name"`
+}
+
+func TestSelectAs(t *testing.T) {
+	db := setupTestDBWithPosts(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	userRepo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+	postRepo, err := crud.NewRepository[Post](db, "posts", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	john, err := userRepo.Create(ctx, User{Username: "john", Email: "john@example.com"})
+	require.NoError(t, err)
+	post, err := postRepo.Create(ctx, Post{UserID: john.ID, Title: "Hello"})
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[PostWithAuthor](db, "posts", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	// Both tables have an id column; the aliases keep them apart
+	opts := []crud.Option[PostWithAuthor]{
+		repo.InnerJoin("users", "users.id = posts.user_id"),
+		repo.SelectAs("users.id", "author_id"),
+		repo.SelectAs("users.username", "author_name"),
+	}
+	sql, _, err := repo.ToSQL(opts...)
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT posts.id, posts.title, users.id AS author_id, users.username AS author_name FROM posts "+
+		"INNER JOIN users ON users.id = posts.user_id", sql)
+
+	items, err := repo.List(ctx, opts...)
+	require.NoError(t, err)
+	assert.Equal(t, []PostWithAuthor{{ID: post.ID, Title: "Hello", AuthorID: john.ID, AuthorName: "john"}}, items)
+
+	// Aliased columns are selected alongside Columns, and GetByID uses them too
+	items, err = repo.List(ctx, append(opts, repo.Columns("id"))...)
+	require.NoError(t, err)
+	assert.Equal(t, []PostWithAuthor{{ID: post.ID, AuthorID: john.ID, AuthorName: "john"}}, items)
+
+	computed, err := repo.GetByID(ctx, post.ID,
+		repo.SelectAs("user_id", "author_id"), repo.SelectAs("upper(title)", "author_name"))
+	require.NoError(t, err)
+	assert.Equal(t, PostWithAuthor{ID: post.ID, Title: "Hello", AuthorID: john.ID, AuthorName: "HELLO"}, computed)
+
+	_, err = repo.List(ctx, repo.SelectAs("users.email", "missing"))
+	assert.ErrorContains(t, err, "unknown column 'missing'")
+}
@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithSessionSetting(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	sqliteRepo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+	_, err = sqliteRepo.List(ctx, sqliteRepo.WithSessionSetting("enable_seqscan", "off"))
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+
+	repo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{}, crud.WithQueryRecorder(2))
+	require.NoError(t, err)
+
+	// Only allow-listed settings and plain values are accepted
+	_, err = repo.List(ctx, repo.WithSessionSetting("search_path", "evil"))
+	assert.ErrorContains(t, err, "does not allow the setting 'search_path'")
+	_, err = repo.List(ctx, repo.WithSessionSetting("enable_seqscan", "off'; DROP TABLE users; --"))
+	assert.ErrorContains(t, err, "invalid value")
+
+	// Outside a transaction the option is rejected, by every read
+	setting := repo.WithSessionSetting("enable_seqscan", "off")
+	_, err = repo.List(ctx, setting)
+	assert.ErrorIs(t, err, crud.ErrTxRequired)
+	_, err = repo.Count(ctx, setting)
+	assert.ErrorIs(t, err, crud.ErrTxRequired)
+	_, err = repo.Exists(ctx, setting)
+	assert.ErrorIs(t, err, crud.ErrTxRequired)
+	_, err = repo.Pluck(ctx, "id", setting)
+	assert.ErrorIs(t, err, crud.ErrTxRequired)
+	_, err = repo.Sum(ctx, "id", setting)
+	assert.ErrorIs(t, err, crud.ErrTxRequired)
+	_, err = crud.GroupedAggregate[User, countRow](ctx, repo, []string{"username"}, []string{"COUNT(*) AS n"}, setting)
+	assert.ErrorIs(t, err, crud.ErrTxRequired)
+
+	// SQLite cannot run the statement, but the recorder shows what would be sent
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	_, err = repo.WithTx(tx).List(ctx, repo.WithSessionSetting("enable_seqscan", "off"))
+	assert.ErrorContains(t, err, "failed to apply session setting")
+	assert.Equal(t, "SET LOCAL enable_seqscan = 'off'", repo.LastQueries()[0].SQL)
+}
+
+func TestPostgresWithSessionSetting(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	_, err = repo.WithTx(tx).List(ctx, repo.WithSessionSetting("enable_seqscan", "off"), repo.Limit(1))
+	require.NoError(t, err)
+
+	var value string
+	require.NoError(t, tx.QueryRowContext(ctx, "SHOW enable_seqscan").Scan(&value))
+	assert.Equal(t, "off", value)
+}
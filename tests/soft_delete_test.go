@@ -0,0 +1,166 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Document struct {
+	ID        int        `db:"id,pk"`
+	Title     string     `db:"title"`
+	DeletedAt *time.Time `db:"deleted_at"`
+}
+
+func TestSoftDelete(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE documents (id INTEGER PRIMARY KEY AUTOINCREMENT, title TEXT NOT NULL, deleted_at DATETIME);`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Document](db, "documents", crud.SQLiteDialect{}, crud.WithSoftDelete("deleted_at"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	keep, err := repo.Create(ctx, Document{Title: "keep"})
+	require.NoError(t, err)
+	trash, err := repo.Create(ctx, Document{Title: "trash"})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Delete(ctx, trash.ID))
+
+	// The row still exists, marked as deleted
+	var deletedAt sql.NullTime
+	require.NoError(t, db.QueryRow(`SELECT deleted_at FROM documents WHERE id = ?`, trash.ID).Scan(&deletedAt))
+	assert.True(t, deletedAt.Valid)
+
+	// Soft-deleted rows disappear from reads
+	docs, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, keep.ID, docs[0].ID)
+
+	_, err = repo.GetByID(ctx, trash.ID)
+	assert.ErrorIs(t, err, crud.ErrNotFound)
+
+	count, err := repo.Count(ctx, repo.Where("title", "trash"))
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	// Raw OR conditions cannot leak deleted rows
+	docs, err = repo.List(ctx, repo.Where("title = ? OR title = ?", "keep", "trash"))
+	require.NoError(t, err)
+	assert.Len(t, docs, 1)
+
+	// ...but reappear with WithTrashed
+	docs, err = repo.List(ctx, repo.WithTrashed(), repo.OrderBy("id", crud.SortAsc))
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.NotNil(t, docs[1].DeletedAt)
+
+	trashed, err := repo.GetByID(ctx, trash.ID, repo.WithTrashed())
+	require.NoError(t, err)
+	assert.Equal(t, "trash", trashed.Title)
+
+	// Deleting twice reports no rows
+	assert.ErrorIs(t, repo.Delete(ctx, trash.ID), sql.ErrNoRows)
+
+	// ForceDelete removes the row physically
+	require.NoError(t, repo.ForceDelete(ctx, trash.ID))
+	docs, err = repo.List(ctx, repo.WithTrashed())
+	require.NoError(t, err)
+	assert.Len(t, docs, 1)
+}
+
+func TestSoftDeleteWhere(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE documents (id INTEGER PRIMARY KEY AUTOINCREMENT, title TEXT NOT NULL, deleted_at DATETIME);`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Document](db, "documents", crud.SQLiteDialect{}, crud.WithSoftDelete("deleted_at"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for _, title := range []string{"draft-1", "draft-2", "final"} {
+		_, err := repo.Create(ctx, Document{Title: title})
+		require.NoError(t, err)
+	}
+
+	deleted, err := repo.DeleteWhere(ctx, repo.WhereLike("title", "draft-%"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	docs, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "final", docs[0].Title)
+
+	// Soft-deleted rows are kept and not deleted twice
+	docs, err = repo.List(ctx, repo.WithTrashed())
+	require.NoError(t, err)
+	assert.Len(t, docs, 3)
+	deleted, err = repo.DeleteWhere(ctx, repo.WhereLike("title", "draft-%"))
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	// UpdateWhere leaves soft-deleted rows alone unless WithTrashed is given
+	updated, err := repo.UpdateWhere(ctx, map[string]any{"title": "renamed"}, repo.Where("title <> ?", ""))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), updated)
+	updated, err = repo.UpdateWhere(ctx, map[string]any{"title": "renamed"}, repo.Where("title <> ?", ""), repo.WithTrashed())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), updated)
+}
+
+func TestSoftDelete_UpdatesSkipTrashed(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE documents (id INTEGER PRIMARY KEY AUTOINCREMENT, title TEXT NOT NULL, deleted_at DATETIME);`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Document](db, "documents", crud.SQLiteDialect{}, crud.WithSoftDelete("deleted_at"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	live, err := repo.Create(ctx, Document{Title: "live"})
+	require.NoError(t, err)
+	trash, err := repo.Create(ctx, Document{Title: "trash"})
+	require.NoError(t, err)
+	require.NoError(t, repo.Delete(ctx, trash.ID))
+
+	trash.Title = "revived"
+	_, err = repo.Update(ctx, trash)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = repo.UpdateFields(ctx, trash.ID, map[string]any{"title": "revived"})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	live.Title = "live-2"
+	n, err := repo.UpdateMany(ctx, []Document{live, trash})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	// The soft-deleted record is unchanged and still deleted
+	var title string
+	var deletedAt sql.NullTime
+	require.NoError(t, db.QueryRow(`SELECT title, deleted_at FROM documents WHERE id = ?`, trash.ID).Scan(&title, &deletedAt))
+	assert.Equal(t, "trash", title)
+	assert.True(t, deletedAt.Valid)
+
+	got, err := repo.GetByID(ctx, live.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "live-2", got.Title)
+}
@@ -0,0 +1,199 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Session struct {
+	Token string `db:"token,pk,auto"`
+	User  string `db:"user"`
+}
+
+func TestSQLiteCreateReloadsGeneratedColumns(t *testing.T) {
+	db := setupGeneratedColumnsDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[Issue](db, "issues", crud.SQLiteDialect{}, crud.WithQueryRecorder(2))
+	require.NoError(t, err)
+
+	created, err := repo.Create(context.Background(), Issue{Title: "reloaded"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, created.ID)
+	assert.Equal(t, "generated", created.Code)
+	assert.True(t, generatedCreatedAt.Equal(created.CreatedAt))
+
+	// Without RETURNING the row is selected again after the INSERT.
+	queries := repo.LastQueries()
+	require.Len(t, queries, 2)
+	assert.Equal(t, "INSERT INTO issues (title) VALUES (?)", queries[0].SQL)
+	assert.Contains(t, queries[1].SQL, "SELECT")
+}
+
+func TestSQLiteCreateWithReturning(t *testing.T) {
+	db := setupGeneratedColumnsDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	dialect := crud.SQLiteDialect{Returning: true}
+	repo, err := crud.NewRepository[Issue](db, "issues", dialect, crud.WithQueryRecorder(2))
+	require.NoError(t, err)
+
+	created, err := repo.Create(ctx, Issue{Title: "returned"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, created.ID)
+	assert.Equal(t, "generated", created.Code)
+	assert.True(t, generatedCreatedAt.Equal(created.CreatedAt))
+
+	queries := repo.LastQueries()
+	require.Len(t, queries, 1)
+	assert.Equal(t, "INSERT INTO issues (title) VALUES (?) RETURNING id, title, code, created_at", queries[0].SQL)
+
+	vouchers, err := crud.NewRepository[Voucher](db, "vouchers", dialect)
+	require.NoError(t, err)
+	voucher, err := vouchers.Create(ctx, Voucher{Code: "WELCOME", Amount: 10})
+	require.NoError(t, err)
+	assert.Equal(t, "WELCOME", voucher.Code)
+	assert.True(t, generatedCreatedAt.Equal(voucher.CreatedAt))
+}
+
+func TestSQLiteGeneratedTextPrimaryKeyRequiresReturning(t *testing.T) {
+	db := setupGeneratedColumnsDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE TABLE sessions (token TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(16)))), user TEXT NOT NULL);`)
+	require.NoError(t, err)
+
+	_, err = crud.NewRepository[Session](db, "sessions", crud.SQLiteDialect{})
+	assert.ErrorContains(t, err, "must be an integer on this dialect")
+
+	repo, err := crud.NewRepository[Session](db, "sessions", crud.SQLiteDialect{Returning: true})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	created, err := repo.Create(ctx, Session{User: "alice"})
+	require.NoError(t, err)
+	assert.Len(t, created.Token, 32)
+
+	fetched, err := repo.GetByID(ctx, created.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", fetched.User)
+}
+
+func TestDialectSupportsReturning(t *testing.T) {
+	assert.True(t, crud.PostgresDialect{}.SupportsReturning())
+	assert.False(t, crud.SQLServerDialect{}.SupportsReturning())
+	assert.False(t, crud.MySQLDialect{}.SupportsReturning())
+	assert.True(t, crud.MySQLDialect{Returning: true}.SupportsReturning())
+	assert.False(t, crud.SQLiteDialect{}.SupportsReturning())
+	assert.True(t, crud.SQLiteDialect{Returning: true}.SupportsReturning())
+
+	assert.Equal(t, "INSERT INTO t (a) VALUES (?) RETURNING id, a",
+		crud.SQLiteDialect{Returning: true}.InsertReturningSQL("INSERT INTO t (a) VALUES (?)", []string{"id", "a"}))
+}
+
+func TestSQLiteCreateManyWithReturning(t *testing.T) {
+	db := setupGeneratedColumnsDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[Issue](db, "issues", crud.SQLiteDialect{Returning: true}, crud.WithQueryRecorder(1))
+	require.NoError(t, err)
+
+	created, err := repo.CreateMany(context.Background(), []Issue{{Title: "first"}, {Title: "second"}})
+	require.NoError(t, err)
+	require.Len(t, created, 2)
+	assert.Equal(t, "first", created[0].Title)
+	assert.Equal(t, 1, created[0].ID)
+	assert.Equal(t, "second", created[1].Title)
+	assert.Equal(t, 2, created[1].ID)
+	for _, issue := range created {
+		assert.Equal(t, "generated", issue.Code)
+		assert.True(t, generatedCreatedAt.Equal(issue.CreatedAt))
+	}
+	assert.Equal(t, "INSERT INTO issues (title) VALUES (?), (?) RETURNING id, title, code, created_at", repo.LastQueries()[0].SQL)
+}
+
+func TestMySQLDialectFallsBackToLastInsertID(t *testing.T) {
+	// SQLite understands the INSERT and SELECT statements of MySQLDialect, which exercises the fallback
+	// without a MySQL server.
+	db := setupGeneratedColumnsDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[Issue](db, "issues", crud.MySQLDialect{}, crud.WithQueryRecorder(2))
+	require.NoError(t, err)
+
+	sql, _, err := repo.BuildInsert(Issue{Title: "fallback"})
+	require.NoError(t, err)
+	assert.Equal(t, "INSERT INTO issues (title) VALUES (?)", sql)
+
+	created, err := repo.Create(context.Background(), Issue{Title: "fallback"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, created.ID)
+	assert.Equal(t, "generated", created.Code)
+
+	queries := repo.LastQueries()
+	require.Len(t, queries, 2)
+	assert.Equal(t, "INSERT INTO issues (title) VALUES (?)", queries[0].SQL)
+	assert.Contains(t, queries[1].SQL, "SELECT")
+
+	mariaDB, err := crud.NewRepository[Issue](db, "issues", crud.MySQLDialect{Returning: true})
+	require.NoError(t, err)
+	sql, _, err = mariaDB.BuildInsert(Issue{Title: "returned"})
+	require.NoError(t, err)
+	assert.Equal(t, "INSERT INTO issues (title) VALUES (?) RETURNING id, title, code, created_at", sql)
+}
+
+func TestSQLiteCreateManyWithReturningKeepsItemOrder(t *testing.T) {
+	db := setupGeneratedColumnsDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[Voucher](db, "vouchers", crud.SQLiteDialect{Returning: true})
+	require.NoError(t, err)
+
+	created, err := repo.CreateMany(context.Background(), []Voucher{{Code: "ZETA", Amount: 1}, {Code: "ALPHA", Amount: 2}, {Code: "MU", Amount: 3}})
+	require.NoError(t, err)
+	require.Len(t, created, 3)
+	assert.Equal(t, []string{"ZETA", "ALPHA", "MU"}, []string{created[0].Code, created[1].Code, created[2].Code})
+	assert.Equal(t, []int{1, 2, 3}, []int{created[0].Amount, created[1].Amount, created[2].Amount})
+	for _, voucher := range created {
+		assert.True(t, generatedCreatedAt.Equal(voucher.CreatedAt))
+	}
+}
+
+func TestSQLiteDeleteWhereReturning(t *testing.T) {
+	db := setupGeneratedColumnsDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	repo, err := crud.NewRepository[Issue](db, "issues", crud.SQLiteDialect{Returning: true}, crud.WithQueryRecorder(2))
+	require.NoError(t, err)
+	_, err = repo.CreateMany(ctx, []Issue{{Title: "old"}, {Title: "new"}})
+	require.NoError(t, err)
+
+	deleted, err := repo.DeleteWhereReturning(ctx, repo.Where("title", "old"))
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, "old", deleted[0].Title)
+	assert.Equal(t, "generated", deleted[0].Code)
+
+	// The rows are read back by the DELETE itself rather than selected beforehand.
+	queries := repo.LastQueries()
+	require.Len(t, queries, 2)
+	assert.Equal(t, "DELETE FROM issues WHERE title = ? RETURNING id, title, code, created_at", queries[1].SQL)
+
+	// Without Returning, the fallback selects the rows first.
+	fallback, err := crud.NewRepository[Issue](db, "issues", crud.SQLiteDialect{}, crud.WithQueryRecorder(2))
+	require.NoError(t, err)
+	deleted, err = fallback.DeleteWhereReturning(ctx, fallback.Where("title", "new"))
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	queries = fallback.LastQueries()
+	require.Len(t, queries, 2)
+	assert.Contains(t, queries[0].SQL, "SELECT")
+	assert.Equal(t, "DELETE FROM issues WHERE title = ?", queries[1].SQL)
+}
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLServerDialectSelectSQL(t *testing.T) {
+	d := crud.SQLServerDialect{}
+	cols := []string{"id", "username"}
+
+	assert.Equal(t, "SELECT id, username FROM users WHERE id = @p1",
+		d.SelectSQL("users", cols, "", "id = "+d.Placeholder(1), "", "", 0, 0))
+
+	// OFFSET ... FETCH requires an ORDER BY
+	assert.Equal(t, "SELECT id, username FROM users ORDER BY (SELECT NULL) OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY",
+		d.SelectSQL("users", cols, "", "", "", "", 10, 0))
+	assert.Equal(t, "SELECT id, username FROM users ORDER BY username ASC OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+		d.SelectSQL("users", cols, "", "", "username ASC", "", 10, 20))
+	assert.Equal(t, "SELECT id, username FROM users ORDER BY id ASC OFFSET 5 ROWS",
+		d.SelectSQL("users", cols, "", "", "id ASC", "", 0, 5))
+
+	// Locks become table hints after the table name
+	assert.Equal(t, "SELECT id, username FROM users WITH (UPDLOCK, ROWLOCK) INNER JOIN posts ON posts.user_id = users.id WHERE id = @p1",
+		d.SelectSQL("users", cols, "INNER JOIN posts ON posts.user_id = users.id", "id = @p1", "", "FOR UPDATE", 0, 0))
+	assert.Equal(t, "SELECT id, username FROM users WITH (UPDLOCK, ROWLOCK, READPAST)",
+		d.SelectSQL("users", cols, "", "", "", "for update skip locked", 0, 0))
+	assert.Equal(t, "SELECT id, username FROM users WITH (XLOCK)",
+		d.SelectSQL("users", cols, "", "", "", "WITH (XLOCK)", 0, 0))
+}
+
+func TestSQLServerDialectInsertAndUpsertSQL(t *testing.T) {
+	d := crud.SQLServerDialect{}
+
+	assert.Equal(t, "INSERT INTO users (username, email) VALUES (@p1, @p2)",
+		d.InsertSQL("users", []string{"username", "email"}, []string{d.Placeholder(1), d.Placeholder(2)}))
+	assert.Equal(t, "INSERT INTO users (username) VALUES (@p1), (@p2)",
+		d.BulkInsertSQL("users", []string{"username"}, [][]string{{"@p1"}, {"@p2"}}))
+
+	assert.Equal(t, "MERGE INTO users AS target USING (VALUES (@p1, @p2, @p3)) AS source (id, username, email) ON target.id = source.id "+
+		"WHEN MATCHED THEN UPDATE SET target.username = source.username, target.email = source.email "+
+		"WHEN NOT MATCHED THEN INSERT (id, username, email) VALUES (source.id, source.username, source.email);",
+		d.UpsertSQL("users", "id", []string{"id", "username", "email"}))
+
+	// Without update columns an existing row is left untouched
+	assert.Equal(t, "MERGE INTO users AS target USING (VALUES (@p1, @p2)) AS source (id, username) ON target.id = source.id "+
+		"WHEN NOT MATCHED THEN INSERT (id, username) VALUES (source.id, source.username);",
+		d.UpsertColumnsSQL("users", "id", []string{"id", "username"}, nil))
+
+	_, err := d.NotifySQL()
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+	_, err = d.LimitWithTiesSQL(3)
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+
+	assert.Equal(t, 2100, d.MaxParameters())
+}
+
+func TestSQLServerDialectThroughRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLServerDialect{})
+	require.NoError(t, err)
+
+	query, args, err := repo.ToSQL(repo.Where("username", "alice"), repo.WithPage(2, 10))
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT users.id, users.username, users.email FROM users WHERE username = @p1 "+
+		"ORDER BY (SELECT NULL) OFFSET 10 ROWS FETCH NEXT 10 ROWS ONLY", query)
+	assert.Equal(t, []any{"alice"}, args)
+
+	// The generated IDENTITY value is selected in the same batch
+	query, args, err = repo.BuildInsert(User{Username: "alice", Email: "alice@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (username, email) VALUES (@p1, @p2); SELECT CAST(SCOPE_IDENTITY() AS BIGINT)", query)
+	assert.Equal(t, []any{"alice", "alice@example.com"}, args)
+}
+
+func TestSQLServerDialectCapabilities(t *testing.T) {
+	d := crud.SQLServerDialect{}
+
+	// Clauses for queries not built by SelectSQL, such as GroupedAggregate
+	assert.Equal(t, " ORDER BY (SELECT NULL) OFFSET 5 ROWS FETCH NEXT 10 ROWS ONLY", d.OrderLimitSQL("", 10, 5))
+	assert.Equal(t, " ORDER BY status ASC", d.OrderLimitSQL("status ASC", 0, 0))
+	assert.Empty(t, d.OrderLimitSQL("", 0, 0))
+
+	assert.Equal(t, "SAVE TRANSACTION sp", d.SavepointSQL("sp"))
+	assert.Empty(t, d.ReleaseSavepointSQL("sp"))
+	assert.Equal(t, "ROLLBACK TRANSACTION sp", d.RollbackToSavepointSQL("sp"))
+
+	assert.Equal(t, "SET LOCK_TIMEOUT -1", d.ResetLockTimeoutSQL())
+}
@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncInsertMissing(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = repo.Create(ctx, User{Username: "alice", Email: "alice@example.com"})
+	require.NoError(t, err)
+
+	inserted, err := repo.SyncInsertMissing(ctx, []User{
+		{Username: "alice", Email: "alice-new@example.com"},
+		{Username: "bob", Email: "bob@example.com"},
+		{Username: "carol", Email: "carol@example.com"},
+		{Username: "bob", Email: "bob-dup@example.com"},
+	}, []string{"username"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), inserted)
+
+	users, err := repo.List(ctx, repo.OrderBy("username", crud.SortAsc))
+	require.NoError(t, err)
+	require.Len(t, users, 3)
+	assert.Equal(t, "alice@example.com", users[0].Email, "existing rows are left untouched")
+	assert.Equal(t, "bob@example.com", users[1].Email, "the first occurrence of a repeated key wins")
+	assert.Equal(t, "carol", users[2].Username)
+
+	// Nothing left to insert
+	inserted, err = repo.SyncInsertMissing(ctx, users, []string{"username"})
+	require.NoError(t, err)
+	assert.Zero(t, inserted)
+
+	_, err = repo.SyncInsertMissing(ctx, users, []string{"nickname"})
+	assert.ErrorContains(t, err, "unknown column 'nickname'")
+
+	_, err = repo.SyncInsertMissing(ctx, users, nil)
+	assert.Error(t, err)
+}
+
+func TestSyncInsertMissingCompositeKey(t *testing.T) {
+	db := setupTestDBWithPosts(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[Post](db, "posts", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = repo.CreateMany(ctx, []Post{
+		{UserID: 1, Title: "a"},
+		{UserID: 2, Title: "a"},
+	})
+	require.NoError(t, err)
+
+	inserted, err := repo.SyncInsertMissing(ctx, []Post{
+		{UserID: 1, Title: "a"},
+		{UserID: 1, Title: "b"},
+		{UserID: 2, Title: "a"},
+		{UserID: 3, Title: "a"},
+	}, []string{"user_id", "title"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), inserted)
+
+	count, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+}
@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Event struct {
+	ID   int       `db:"id,pk"`
+	Name string    `db:"name"`
+	At   time.Time `db:"at"`
+}
+
+func TestWhereTimeBetween(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE events (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL, at DATETIME NOT NULL);`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Event](db, "events", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for name, at := range map[string]time.Time{
+		"before":   time.Date(2024, 3, 9, 22, 59, 59, 0, time.UTC),
+		"start":    time.Date(2024, 3, 9, 23, 0, 0, 0, time.UTC),
+		"inside":   time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC),
+		"boundary": time.Date(2024, 3, 10, 23, 0, 0, 0, time.UTC),
+	} {
+		_, err := repo.Create(ctx, Event{Name: name, At: at})
+		require.NoError(t, err)
+	}
+
+	// March 10th in UTC+1 is [2024-03-09 23:00, 2024-03-10 23:00) in UTC
+	zone := time.FixedZone("UTC+1", 3600)
+	from := time.Date(2024, 3, 10, 0, 0, 0, 0, zone)
+	to := from.AddDate(0, 0, 1)
+
+	events, err := repo.List(ctx, repo.WhereTimeBetween("at", from, to), repo.OrderBy("at", crud.SortAsc))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "start", events[0].Name)
+	assert.Equal(t, "inside", events[1].Name)
+
+	// The exclusive end belongs to the next range
+	events, err = repo.List(ctx, repo.WhereTimeBetween("at", to, to.AddDate(0, 0, 1)))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "boundary", events[0].Name)
+
+	_, err = repo.List(ctx, repo.WhereTimeBetween("at", to, from))
+	assert.Error(t, err)
+}
@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Article struct {
+	ID        int        `db:"id,pk"`
+	Title     string     `db:"title"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+func TestWithTimestamps(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE articles (id INTEGER PRIMARY KEY AUTOINCREMENT, title TEXT NOT NULL, created_at DATETIME NOT NULL, updated_at DATETIME);`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Article](db, "articles", crud.SQLiteDialect{}, crud.WithTimestamps("created_at", "updated_at"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	created, err := repo.Create(ctx, Article{Title: "draft"})
+	require.NoError(t, err)
+	require.False(t, created.CreatedAt.IsZero())
+	require.NotNil(t, created.UpdatedAt)
+	assert.True(t, created.CreatedAt.Equal(*created.UpdatedAt))
+
+	time.Sleep(10 * time.Millisecond)
+
+	created.Title = "published"
+	_, err = repo.Update(ctx, created)
+	require.NoError(t, err)
+
+	stored, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.True(t, stored.CreatedAt.Equal(created.CreatedAt), "created_at must not change on update")
+	require.NotNil(t, stored.UpdatedAt)
+	assert.True(t, stored.UpdatedAt.After(*created.UpdatedAt), "updated_at must advance on update")
+
+	many, err := repo.CreateMany(ctx, []Article{{Title: "a"}, {Title: "b"}})
+	require.NoError(t, err)
+	for _, a := range many {
+		assert.False(t, a.CreatedAt.IsZero())
+		assert.NotNil(t, a.UpdatedAt)
+	}
+}
+
+func TestWithTimestampsRejectsNonTimeField(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	_, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithTimestamps("email", ""))
+	assert.ErrorContains(t, err, "must be a time.Time or *time.Time field")
+}
+
+func TestWithTimestampsRejectsUnknownColumn(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	_, err := crud.NewRepository[Article](db, "articles", crud.SQLiteDialect{}, crud.WithTimestamps("created_at", "modified_at"))
+	assert.EqualError(t, err, "unknown timestamp column 'modified_at'")
+	_, err = crud.NewRepository[Article](db, "articles", crud.SQLiteDialect{}, crud.WithTimestamps("inserted_at", ""))
+	assert.EqualError(t, err, "unknown timestamp column 'inserted_at'")
+
+	// An empty name leaves that timestamp unmanaged
+	_, err = crud.NewRepository[Article](db, "articles", crud.SQLiteDialect{}, crud.WithTimestamps("", "updated_at"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToSQL(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithQueryRecorder(1))
+	require.NoError(t, err)
+
+	sql, args, err := repo.ToSQL(
+		repo.Where("username", "john"),
+		repo.WhereIn("id", 1, 2, 3),
+		repo.OrderBy("id", crud.SortDesc),
+		repo.Limit(10),
+		repo.Offset(20),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT users.id, users.username, users.email FROM users WHERE username = ? AND id IN (?,?,?) ORDER BY id DESC LIMIT 10 OFFSET 20", sql)
+	assert.Equal(t, []any{"john", 1, 2, 3}, args)
+	// Nothing was executed
+	assert.Empty(t, repo.LastQueries())
+
+	sql, args, err = repo.ToSQL()
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT users.id, users.username, users.email FROM users", sql)
+	assert.Empty(t, args)
+
+	_, _, err = repo.ToSQL(repo.Columns("missing"))
+	assert.Error(t, err)
+}
+
+func TestToSQLPostgresPlaceholders(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	sql, args, err := repo.ToSQL(
+		repo.Where("username", "!=", "john"),
+		repo.Where("email", "john@example.com"),
+		repo.OrderBy("username", crud.SortAsc),
+		repo.Limit(5),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT users.id, users.username, users.email FROM users WHERE username != $1 AND email = $2 ORDER BY username ASC LIMIT 5", sql)
+	assert.Equal(t, []any{"john", "john@example.com"}, args)
+}
@@ -3,6 +3,8 @@ package tests
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"path/filepath"
 	"testing"
 
 	"github.com/dimatock/crud"
@@ -71,3 +73,241 @@ func TestTransactionCommit(t *testing.T) {
 
 	assert.Equal(t, newUser.Username, retrievedUser.Username, "Username mismatch after commit")
 }
+
+func TestRunInTransaction(t *testing.T) {
+	db := setupTestDBWithPosts(t)
+	defer db.Close()
+
+	userRepo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+	postRepo, err := crud.NewRepository[Post](db, "posts", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	createWithPosts := func(username string, fail error) error {
+		return crud.RunInTransaction(ctx, db, nil, func(ctx context.Context, b *crud.TxBundle) error {
+			user, err := crud.TxRepo(b, userRepo).Create(ctx, User{Username: username, Email: username + "@example.com"})
+			if err != nil {
+				return err
+			}
+			txPosts := crud.TxRepo(b, postRepo)
+			for _, title := range []string{"first", "second"} {
+				if _, err := txPosts.Create(ctx, Post{UserID: user.ID, Title: title}); err != nil {
+					return err
+				}
+			}
+			return fail
+		})
+	}
+
+	// Both repositories commit together
+	require.NoError(t, createWithPosts("parent", nil))
+	users, err := userRepo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	posts, err := postRepo.List(ctx, postRepo.Where("user_id", users[0].ID))
+	require.NoError(t, err)
+	assert.Len(t, posts, 2)
+
+	// ...and roll back together
+	errAbort := errors.New("abort")
+	assert.ErrorIs(t, createWithPosts("rolled-back", errAbort), errAbort)
+	users, err = userRepo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+	posts, err = postRepo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, posts, 2)
+
+	// A panic also rolls back before propagating
+	assert.Panics(t, func() {
+		_ = crud.RunInTransaction(ctx, db, nil, func(ctx context.Context, b *crud.TxBundle) error {
+			_, _ = crud.TxRepo(b, userRepo).Create(ctx, User{Username: "panicked", Email: "panicked@example.com"})
+			panic("boom")
+		})
+	})
+	count, err := userRepo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestRunInTransactionWithPropagation(t *testing.T) {
+	// A file database, since PropagationRequiresNew needs a second connection to the same data
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "propagation.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec(`CREATE TABLE users (id INTEGER PRIMARY KEY AUTOINCREMENT, username TEXT NOT NULL UNIQUE, email TEXT NOT NULL UNIQUE)`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	create := func(ctx context.Context, b *crud.TxBundle, name string) error {
+		_, err := crud.TxRepo(b, repo).Create(ctx, User{Username: name, Email: name + "@example.com"})
+		return err
+	}
+	errAbort := errors.New("abort")
+
+	err = crud.RunInTransaction(ctx, db, nil, func(ctx context.Context, outer *crud.TxBundle) error {
+		// REQUIRES_NEW commits on its own connection, independently of the outer transaction
+		require.NoError(t, crud.RunInTransactionWith(ctx, db, crud.PropagationRequiresNew, func(ctx context.Context, b *crud.TxBundle) error {
+			assert.NotSame(t, outer.Tx(), b.Tx())
+			return create(ctx, b, "independent")
+		}))
+
+		// REQUIRED joins the outer transaction
+		require.NoError(t, crud.RunInTransactionWith(ctx, db, crud.PropagationRequired, func(ctx context.Context, b *crud.TxBundle) error {
+			assert.Same(t, outer.Tx(), b.Tx())
+			return create(ctx, b, "joined")
+		}))
+
+		// NESTED rolls back to its savepoint only
+		err := crud.RunInTransactionWith(ctx, db, crud.PropagationNested, func(ctx context.Context, b *crud.TxBundle) error {
+			if err := create(ctx, b, "nested"); err != nil {
+				return err
+			}
+			return errAbort
+		})
+		assert.ErrorIs(t, err, errAbort)
+		require.NoError(t, crud.RunInTransactionWith(ctx, db, crud.PropagationNested, func(ctx context.Context, b *crud.TxBundle) error {
+			return create(ctx, b, "kept")
+		}))
+
+		count, err := crud.TxRepo(outer, repo).Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+		return errAbort
+	})
+	assert.ErrorIs(t, err, errAbort)
+
+	// Only the REQUIRES_NEW insert survives the outer rollback
+	users, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "independent", users[0].Username)
+
+	// Outside a transaction every mode starts a new one
+	require.NoError(t, crud.RunInTransactionWith(ctx, db, crud.PropagationNested, func(ctx context.Context, b *crud.TxBundle) error {
+		return create(ctx, b, "standalone")
+	}))
+	count, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+// unreleasedSavepoints mimics SQL Server, whose savepoints cannot be released, with SQLite statements.
+type unreleasedSavepoints struct {
+	crud.SQLiteDialect
+	created *[]string
+}
+
+func (d unreleasedSavepoints) SavepointSQL(name string) string {
+	*d.created = append(*d.created, name)
+	return "SAVEPOINT " + name
+}
+
+func (d unreleasedSavepoints) ReleaseSavepointSQL(name string) string {
+	return ""
+}
+
+func (d unreleasedSavepoints) RollbackToSavepointSQL(name string) string {
+	return "ROLLBACK TO " + name
+}
+
+func TestNestedTransactionWithDialectSavepoints(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	var created []string
+	errAbort := errors.New("abort")
+	require.NoError(t, crud.RunInTransaction(ctx, db, nil, func(ctx context.Context, outer *crud.TxBundle) error {
+		outer.SetDialect(unreleasedSavepoints{created: &created})
+
+		err := crud.RunInTransactionWith(ctx, db, crud.PropagationNested, func(ctx context.Context, b *crud.TxBundle) error {
+			if _, err := crud.TxRepo(b, repo).Create(ctx, User{Username: "nested", Email: "nested@example.com"}); err != nil {
+				return err
+			}
+			return errAbort
+		})
+		assert.ErrorIs(t, err, errAbort)
+		return crud.RunInTransactionWith(ctx, db, crud.PropagationNested, func(ctx context.Context, b *crud.TxBundle) error {
+			_, err := crud.TxRepo(b, repo).Create(ctx, User{Username: "kept", Email: "kept@example.com"})
+			return err
+		})
+	}))
+	assert.Equal(t, []string{"crud_sp_1", "crud_sp_2"}, created)
+
+	users, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "kept", users[0].Username)
+}
+
+func TestNestedTransactionDialectFromTxRepo(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	var created []string
+	repo, err := crud.NewRepository[User](db, "users", unreleasedSavepoints{created: &created})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	nested := func(ctx context.Context, b *crud.TxBundle) error {
+		_, err := crud.TxRepo(b, repo).Create(ctx, User{Username: "nested", Email: "nested@example.com"})
+		return err
+	}
+	require.NoError(t, crud.RunInTransaction(ctx, db, nil, func(ctx context.Context, outer *crud.TxBundle) error {
+		// The bundle does not know the savepoint syntax yet
+		err := crud.RunInTransactionWith(ctx, db, crud.PropagationNested, nested)
+		assert.ErrorContains(t, err, "nested transaction requires the dialect of the transaction")
+
+		// Binding a repository gives the bundle its dialect
+		crud.TxRepo(outer, repo)
+		return crud.RunInTransactionWith(ctx, db, crud.PropagationNested, nested)
+	}))
+	assert.Equal(t, []string{"crud_sp_1"}, created)
+
+	count, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestRunInRollbackTransaction(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	err = crud.RunInRollbackTransaction(ctx, db, repo, func(txRepo crud.RepositoryInterface[User]) error {
+		created, err := txRepo.Create(ctx, User{Username: "temp", Email: "temp@example.com"})
+		require.NoError(t, err)
+		// The record is visible inside the transaction
+		_, err = txRepo.GetByID(ctx, created.ID)
+		return err
+	})
+	require.NoError(t, err)
+
+	count, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	// fn's error is returned, and its changes are discarded too
+	errBoom := errors.New("boom")
+	err = crud.RunInRollbackTransaction(ctx, db, repo, func(txRepo crud.RepositoryInterface[User]) error {
+		_, err := txRepo.Create(ctx, User{Username: "temp", Email: "temp@example.com"})
+		require.NoError(t, err)
+		return errBoom
+	})
+	require.ErrorIs(t, err, errBoom)
+
+	count, err = repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
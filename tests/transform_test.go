@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Patient struct {
+	ID   int    `db:"id,pk"`
+	Name string `db:"name"`
+	SSN  string `db:"ssn,transform:b64"`
+}
+
+func init() {
+	crud.RegisterTransformer("b64", crud.Transformer{
+		Encode: func(value any) (any, error) {
+			return base64.StdEncoding.EncodeToString([]byte(value.(string))), nil
+		},
+		Decode: func(value any) (any, error) {
+			var raw string
+			switch v := value.(type) {
+			case string:
+				raw = v
+			case []byte:
+				raw = string(v)
+			default:
+				return nil, fmt.Errorf("unexpected type %T", value)
+			}
+			decoded, err := base64.StdEncoding.DecodeString(raw)
+			if err != nil {
+				return nil, err
+			}
+			return string(decoded), nil
+		},
+	})
+}
+
+func TestColumnTransformer(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE patients (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL, ssn TEXT NOT NULL);`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Patient](db, "patients", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	created, err := repo.Create(ctx, Patient{Name: "john", SSN: "123-45-6789"})
+	require.NoError(t, err)
+	assert.Equal(t, "123-45-6789", created.SSN)
+
+	// The column is stored encoded; other columns are untouched
+	var rawSSN, rawName string
+	require.NoError(t, db.QueryRow(`SELECT ssn, name FROM patients WHERE id = ?`, created.ID).Scan(&rawSSN, &rawName))
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("123-45-6789")), rawSSN)
+	assert.Equal(t, "john", rawName)
+
+	created.SSN = "987-65-4321"
+	updated, err := repo.Update(ctx, created)
+	require.NoError(t, err)
+	assert.Equal(t, "987-65-4321", updated.SSN)
+
+	// Query by example encodes the value too
+	patients, err := repo.List(ctx, repo.WhereMatch(Patient{SSN: "987-65-4321"}))
+	require.NoError(t, err)
+	require.Len(t, patients, 1)
+	assert.Equal(t, "john", patients[0].Name)
+
+	// Undecodable data surfaces as an error
+	_, err = db.Exec(`UPDATE patients SET ssn = '***' WHERE id = ?`, created.ID)
+	require.NoError(t, err)
+	_, err = repo.GetByID(ctx, created.ID)
+	assert.ErrorContains(t, err, "failed to decode column 'ssn'")
+}
+
+func TestColumnTransformerUnknown(t *testing.T) {
+	type Secret struct {
+		ID    int    `db:"id,pk"`
+		Value string `db:"value,transform:missing"`
+	}
+	_, err := crud.NewRepository[Secret](nil, "secrets", crud.SQLiteDialect{})
+	assert.ErrorContains(t, err, "unknown transformer 'missing'")
+}
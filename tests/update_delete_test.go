@@ -55,6 +55,66 @@ func TestUpdateNonExistentUser(t *testing.T) {
 	assert.ErrorIs(t, err, sql.ErrNoRows)
 }
 
+func TestUpdateAllowNoOp(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithAllowNoOpUpdate())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	missing := User{ID: 999, Username: "ghost", Email: "ghost@example.com"}
+	updated, err := repo.Update(ctx, missing)
+	require.NoError(t, err)
+	assert.Equal(t, missing, updated)
+
+	require.NoError(t, repo.UpdateFields(ctx, 999, map[string]any{"email": "ghost@example.org"}))
+
+	created, err := repo.Create(ctx, User{Username: "john", Email: "john@example.com"})
+	require.NoError(t, err)
+	created.Email = "john@example.org"
+	n, err := repo.UpdateMany(ctx, []User{created, missing})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	// Without the option a missing record is still an error
+	strict, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+	_, err = strict.Update(ctx, missing)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUpdateWithResult(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	user, err := repo.Create(ctx, User{Username: "john", Email: "john@example.com"})
+	require.NoError(t, err)
+
+	user.Email = "new@example.com"
+	n, err := repo.UpdateWithResult(ctx, user)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	stored, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "new@example.com", stored.Email)
+
+	// SQLite counts matched rows, so writing identical values still affects the row
+	n, err = repo.UpdateWithResult(ctx, user)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	// A missing row is reported as zero affected rows rather than an error
+	n, err = repo.UpdateWithResult(ctx, User{ID: 999, Username: "ghost", Email: "ghost@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+}
+
 func TestDeleteUser(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -93,3 +153,118 @@ func TestDeleteNonExistentUser(t *testing.T) {
 	require.Error(t, err, "Expected an error when deleting a non-existent user")
 	assert.ErrorIs(t, err, sql.ErrNoRows)
 }
+
+func TestUpdateFields(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithQueryRecorder(1))
+	require.NoError(t, err, "Failed to create repository")
+
+	ctx := context.Background()
+	user, err := repo.Create(ctx, User{Username: "partial", Email: "partial@example.com"})
+	require.NoError(t, err)
+
+	err = repo.UpdateFields(ctx, user.ID, map[string]any{"email": "changed@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET email = ? WHERE id = ?", repo.LastQueries()[0].SQL)
+
+	// Only the named column changed
+	stored, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "partial", stored.Username)
+	assert.Equal(t, "changed@example.com", stored.Email)
+
+	err = repo.UpdateFields(ctx, 999, map[string]any{"email": "nobody@example.com"})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Column names are validated against the mapped columns
+	err = repo.UpdateFields(ctx, user.ID, map[string]any{"email = 'x', username": "hacked"})
+	assert.ErrorContains(t, err, "unknown column")
+	err = repo.UpdateFields(ctx, user.ID, map[string]any{"id": 5})
+	assert.Error(t, err)
+	err = repo.UpdateFields(ctx, user.ID, map[string]any{})
+	assert.Error(t, err)
+}
+
+func TestUpdateWhere(t *testing.T) {
+	db := setupTestDBWithPosts(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[Post](db, "posts", crud.SQLiteDialect{})
+	require.NoError(t, err, "Failed to create repository")
+
+	ctx := context.Background()
+	for _, post := range []Post{{UserID: 1, Title: "draft: a"}, {UserID: 1, Title: "draft: b"}, {UserID: 2, Title: "draft: c"}} {
+		_, err := repo.Create(ctx, post)
+		require.NoError(t, err)
+	}
+
+	affected, err := repo.UpdateWhere(ctx, map[string]any{"title": "archived"}, repo.WhereLike("title", "draft:%"), repo.Where("user_id", 1))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), affected)
+
+	archived, err := repo.Count(ctx, repo.Where("title", "archived"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), archived)
+
+	// Non-matching rows are untouched
+	untouched, err := repo.List(ctx, repo.Where("user_id", 2))
+	require.NoError(t, err)
+	require.Len(t, untouched, 1)
+	assert.Equal(t, "draft: c", untouched[0].Title)
+
+	affected, err = repo.UpdateWhere(ctx, map[string]any{"title": "x"}, repo.Where("user_id", 99))
+	require.NoError(t, err)
+	assert.Zero(t, affected)
+
+	// A WHERE condition is mandatory
+	_, err = repo.UpdateWhere(ctx, map[string]any{"title": "everything"})
+	assert.ErrorContains(t, err, "requires at least one WHERE condition")
+	_, err = repo.UpdateWhere(ctx, map[string]any{"bogus": 1}, repo.Where("id", 1))
+	assert.ErrorContains(t, err, "unknown column")
+
+	// WHERE placeholders are numbered after the SET values
+	pgRepo, err := crud.NewRepository[Post](db, "posts", crud.PostgresDialect{}, crud.WithQueryRecorder(1))
+	require.NoError(t, err)
+	_, _ = pgRepo.UpdateWhere(ctx, map[string]any{"title": "t", "user_id": 3}, pgRepo.Where("user_id", 2))
+	assert.Equal(t, "UPDATE posts SET title = $1, user_id = $2 WHERE user_id = $3", pgRepo.LastQueries()[0].SQL)
+}
+
+func TestDeleteWhere(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err, "Failed to create repository")
+
+	ctx := context.Background()
+	_, _ = repo.Create(ctx, User{Username: "temp-1", Email: "t1@example.com"})
+	_, _ = repo.Create(ctx, User{Username: "temp-2", Email: "t2@example.com"})
+	_, _ = repo.Create(ctx, User{Username: "keeper", Email: "k@example.com"})
+
+	deleted, err := repo.DeleteWhere(ctx, repo.WhereLike("username", "temp-%"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	remaining, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "keeper", remaining[0].Username)
+
+	// Refuses to run without a WHERE condition
+	_, err = repo.DeleteWhere(ctx)
+	assert.ErrorContains(t, err, "requires at least one WHERE condition")
+
+	// Runs inside the repository's transaction
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	deleted, err = repo.WithTx(tx).DeleteWhere(ctx, repo.Where("username", "keeper"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	require.NoError(t, tx.Rollback())
+
+	count, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateMany(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	created, err := repo.CreateMany(ctx, []User{
+		{Username: "many-1", Email: "m1@example.com"},
+		{Username: "many-2", Email: "m2@example.com"},
+		{Username: "many-3", Email: "m3@example.com"},
+	})
+	require.NoError(t, err)
+
+	created[0].Email = "changed-1@example.com"
+	created[1].Email = "changed-2@example.com"
+	missing := User{ID: 999, Username: "ghost", Email: "ghost@example.com"}
+
+	updated, err := repo.UpdateMany(ctx, []User{created[0], created[1], missing})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), updated)
+
+	users, err := repo.List(ctx, repo.OrderBy("id", crud.SortAsc))
+	require.NoError(t, err)
+	require.Len(t, users, 3)
+	assert.Equal(t, "changed-1@example.com", users[0].Email)
+	assert.Equal(t, "changed-2@example.com", users[1].Email)
+	assert.Equal(t, "m3@example.com", users[2].Email)
+}
+
+type Shipment struct {
+	ID        string    `db:"id,pk,pgtype:uuid"`
+	Weight    float64   `db:"weight"`
+	Count     int32     `db:"count"`
+	Delivered bool      `db:"delivered"`
+	SentAt    time.Time `db:"sent_at"`
+	Carrier   *string   `db:"carrier"`
+}
+
+func TestUpdateManyPostgresTypedValues(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	// The statement is only recorded here; TestPostgresUpdateMany runs it against PostgreSQL
+	repo, err := crud.NewRepository[Shipment](db, "shipments", crud.PostgresDialect{}, crud.WithQueryRecorder(1))
+	require.NoError(t, err)
+
+	_, _ = repo.UpdateMany(context.Background(), []Shipment{{ID: "a"}, {ID: "b"}})
+	queries := repo.LastQueries()
+	require.Len(t, queries, 1)
+	assert.Equal(t,
+		"UPDATE shipments SET weight = v.weight, count = v.count, delivered = v.delivered, sent_at = v.sent_at, carrier = v.carrier "+
+			"FROM (VALUES ($1::uuid, $2::double precision, $3::integer, $4::boolean, $5::timestamptz, $6::text), ($7, $8, $9, $10, $11, $12)) "+
+			"AS v(id, weight, count, delivered, sent_at, carrier) WHERE shipments.id = v.id",
+		queries[0].SQL)
+}
+
+func TestValuesSQL(t *testing.T) {
+	sql := crud.PostgresDialect{}.ValuesSQL("v", []string{"id", "name"}, []string{"int", ""}, [][]string{{"$1", "$2"}, {"$3", "$4"}})
+	assert.Equal(t, "(VALUES ($1::int, $2), ($3, $4)) AS v(id, name)", sql)
+}
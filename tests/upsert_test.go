@@ -2,7 +2,10 @@ package tests
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/dimatock/crud"
 	_ "github.com/go-sql-driver/mysql"
@@ -78,3 +81,63 @@ func TestCreateOrUpdate_MySQL(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, users, 1)
 }
+
+type Setting struct {
+	Key       string    `db:"key,pk"`
+	Value     string    `db:"value"`
+	CreatedAt time.Time `db:"created_at,insertonly"`
+}
+
+func TestCreateOrUpdate_PreservesInsertOnlyColumns(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE settings (key TEXT PRIMARY KEY, value TEXT NOT NULL, created_at DATETIME NOT NULL);`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Setting](db, "settings", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err = repo.CreateOrUpdate(ctx, Setting{Key: "theme", Value: "dark", CreatedAt: created})
+	require.NoError(t, err)
+
+	updated, err := repo.CreateOrUpdate(ctx, Setting{Key: "theme", Value: "light", CreatedAt: created.AddDate(1, 0, 0)})
+	require.NoError(t, err)
+	assert.Equal(t, "light", updated.Value)
+	assert.True(t, updated.CreatedAt.Equal(created), "insertonly column must keep its original value")
+
+	// A dialect without UpsertColumnsSQL can only overwrite every column, so the upsert is refused
+	minimal, err := crud.NewRepository[Setting](db, "settings", minimalDialect{crud.SQLiteDialect{}})
+	require.NoError(t, err)
+	_, err = minimal.CreateOrUpdate(ctx, Setting{Key: "theme", Value: "dark", CreatedAt: created})
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+}
+
+func TestCreateOrUpdate_PreservesTimestampCreatedColumn(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE articles (id INTEGER PRIMARY KEY, title TEXT NOT NULL, created_at DATETIME NOT NULL, updated_at DATETIME);`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Article](db, "articles", crud.SQLiteDialect{}, crud.WithTimestamps("created_at", "updated_at"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	first, err := repo.CreateOrUpdate(ctx, Article{ID: 1, Title: "draft"})
+	require.NoError(t, err)
+	require.False(t, first.CreatedAt.IsZero())
+
+	time.Sleep(10 * time.Millisecond)
+
+	second, err := repo.CreateOrUpdate(ctx, Article{ID: 1, Title: "published"})
+	require.NoError(t, err)
+	assert.Equal(t, "published", second.Title)
+	assert.True(t, second.CreatedAt.Equal(first.CreatedAt), "created_at must not change on upsert")
+	require.NotNil(t, second.UpdatedAt)
+	assert.True(t, second.UpdatedAt.After(*first.UpdatedAt))
+}
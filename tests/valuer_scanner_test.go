@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Severity is stored by name and implements driver.Valuer with a value receiver.
+type Severity int
+
+const (
+	SeverityLow Severity = iota + 1
+	SeverityHigh
+)
+
+var severityNames = map[Severity]string{SeverityLow: "low", SeverityHigh: "high"}
+
+func (s Severity) Value() (driver.Value, error) {
+	name, ok := severityNames[s]
+	if !ok {
+		return nil, fmt.Errorf("invalid severity %d", s)
+	}
+	return name, nil
+}
+
+func (s *Severity) Scan(src any) error {
+	name, _ := src.(string)
+	for sev, n := range severityNames {
+		if n == name {
+			*s = sev
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid severity %v", src)
+}
+
+// Labels is stored as comma-separated text and implements driver.Valuer with a pointer receiver.
+type Labels struct {
+	Values []string
+}
+
+func (l *Labels) Value() (driver.Value, error) {
+	return strings.Join(l.Values, ","), nil
+}
+
+func (l *Labels) Scan(src any) error {
+	text, _ := src.(string)
+	l.Values = strings.Split(text, ",")
+	return nil
+}
+
+type Alert struct {
+	ID       int            `db:"id,pk"`
+	Severity Severity       `db:"severity"`
+	Labels   Labels         `db:"labels"`
+	Previous *Severity      `db:"previous"`
+	Note     sql.NullString `db:"note"`
+}
+
+func TestValuerAndScannerFieldsRoundTrip(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec(`CREATE TABLE alerts (id INTEGER PRIMARY KEY AUTOINCREMENT, severity TEXT NOT NULL, labels TEXT NOT NULL, previous TEXT, note TEXT)`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Alert](db, "alerts", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	created, err := repo.Create(ctx, Alert{Severity: SeverityHigh, Labels: Labels{Values: []string{"db", "prod"}}})
+	require.NoError(t, err)
+
+	var severity, labels string
+	var previousIsNull, noteIsNull bool
+	require.NoError(t, db.QueryRow(`SELECT severity, labels, previous IS NULL, note IS NULL FROM alerts WHERE id = ?`, created.ID).
+		Scan(&severity, &labels, &previousIsNull, &noteIsNull))
+	assert.Equal(t, "high", severity)
+	assert.Equal(t, "db,prod", labels)
+	assert.True(t, previousIsNull, "a nil pointer is stored as NULL")
+	assert.True(t, noteIsNull)
+
+	got, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, SeverityHigh, got.Severity)
+	assert.Equal(t, []string{"db", "prod"}, got.Labels.Values)
+	assert.Nil(t, got.Previous)
+	assert.False(t, got.Note.Valid)
+
+	low := SeverityLow
+	got.Previous = &low
+	got.Labels.Values = []string{"db"}
+	_, err = repo.Update(ctx, got)
+	require.NoError(t, err)
+
+	got, err = repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.Previous)
+	assert.Equal(t, SeverityLow, *got.Previous)
+	assert.Equal(t, []string{"db"}, got.Labels.Values)
+
+	matches, err := repo.List(ctx, repo.WhereMatch(Alert{Labels: Labels{Values: []string{"db"}}}))
+	require.NoError(t, err)
+	assert.Len(t, matches, 1)
+
+	// Value errors are reported
+	_, err = repo.Create(ctx, Alert{Severity: Severity(42)})
+	assert.ErrorContains(t, err, "invalid severity 42")
+}
@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Account struct {
+	ID      int    `db:"id,pk"`
+	Owner   string `db:"owner"`
+	Version int    `db:"version"`
+}
+
+func TestWithVersionColumn(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file:version_test?mode=memory&cache=shared")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE accounts (id INTEGER PRIMARY KEY AUTOINCREMENT, owner TEXT NOT NULL, version INTEGER NOT NULL DEFAULT 0);`)
+	require.NoError(t, err)
+
+	// Two independent repositories, as two processes would have
+	first, err := crud.NewRepository[Account](db, "accounts", crud.SQLiteDialect{}, crud.WithVersionColumn("version"))
+	require.NoError(t, err)
+	second, err := crud.NewRepository[Account](db, "accounts", crud.SQLiteDialect{}, crud.WithVersionColumn("version"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	created, err := first.Create(ctx, Account{Owner: "alice"})
+	require.NoError(t, err)
+
+	a, err := first.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	b, err := second.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+
+	a.Owner = "bob"
+	a, err = first.Update(ctx, a)
+	require.NoError(t, err)
+	assert.Equal(t, 1, a.Version)
+
+	// The second copy still carries version 0
+	b.Owner = "carol"
+	_, err = second.Update(ctx, b)
+	assert.ErrorIs(t, err, crud.ErrStaleObject)
+
+	stored, err := first.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "bob", stored.Owner)
+	assert.Equal(t, 1, stored.Version)
+
+	// After a re-read the update goes through
+	stored.Owner = "carol"
+	stored, err = second.Update(ctx, stored)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.Version)
+
+	// A missing row is not a conflict
+	_, err = first.Update(ctx, Account{ID: 999, Owner: "nobody"})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NotErrorIs(t, err, crud.ErrStaleObject)
+}
+
+func TestWithVersionColumnGuardsOtherWrites(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE accounts (id INTEGER PRIMARY KEY AUTOINCREMENT, owner TEXT NOT NULL, version INTEGER NOT NULL DEFAULT 0);`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Account](db, "accounts", crud.SQLiteDialect{}, crud.WithVersionColumn("version"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	created, err := repo.Create(ctx, Account{Owner: "alice"})
+	require.NoError(t, err)
+	current, err := repo.Update(ctx, created)
+	require.NoError(t, err)
+
+	// Neither can check the stored version
+	_, err = repo.CreateOrUpdate(ctx, Account{ID: created.ID, Owner: "mallory"})
+	assert.EqualError(t, err, "CreateOrUpdate cannot check the version column 'version'; use Create or Update")
+	err = repo.UpdateFields(ctx, created.ID, map[string]any{"owner": "mallory"})
+	assert.EqualError(t, err, "UpdateFields cannot check the version column 'version'; use Update")
+
+	// UpdateMany checks the version of every item
+	created.Owner = "mallory"
+	_, err = repo.UpdateMany(ctx, []Account{created})
+	assert.ErrorIs(t, err, crud.ErrStaleObject)
+
+	stored, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, current, stored)
+}
+
+func TestWithVersionColumnValidation(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	_, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithVersionColumn("username"))
+	assert.ErrorContains(t, err, "must be an integer field")
+
+	_, err = crud.NewRepository[User](db, "users", crud.SQLiteDialect{}, crud.WithVersionColumn("missing"))
+	assert.ErrorContains(t, err, "unknown version column 'missing'")
+}
@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func largeIDSet(n int) []any {
+	ids := make([]any, n)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+	return ids
+}
+
+func TestWhereInLargeToSQL(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	pgRepo, err := crud.NewRepository[User](db, "users", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	query, args, err := pgRepo.ToSQL(pgRepo.Where("username", "!=", "admin"), pgRepo.WhereInLarge("id", largeIDSet(1500)))
+	require.NoError(t, err)
+	assert.Contains(t, query, "WHERE username != $1 AND id IN (SELECT v FROM (VALUES ($2::bigint), ($3), ($4)")
+	assert.Contains(t, query, "($1501)) AS large_in(v))")
+	assert.Len(t, args, 1501)
+
+	// Small sets keep the plain IN list
+	query, args, err = pgRepo.ToSQL(pgRepo.WhereInLarge("id", largeIDSet(3)))
+	require.NoError(t, err)
+	assert.Contains(t, query, "WHERE id IN ($1,$2,$3)")
+	assert.Len(t, args, 3)
+
+	sqliteRepo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+	query, _, err = sqliteRepo.ToSQL(sqliteRepo.WhereInLarge("id", largeIDSet(1500)))
+	require.NoError(t, err)
+	assert.NotContains(t, query, "VALUES")
+	assert.Contains(t, query, "WHERE id IN (?,?,")
+
+	_, _, err = pgRepo.ToSQL(pgRepo.WhereInLarge("id", nil))
+	assert.ErrorContains(t, err, "requires at least one value")
+}
+
+func TestWhereInLargeOnSQLite(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[User](db, "users", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for i := 1; i <= 5; i++ {
+		_, err := repo.Create(ctx, User{Username: fmt.Sprintf("user%d", i), Email: fmt.Sprintf("user%d@example.com", i)})
+		require.NoError(t, err)
+	}
+
+	ids := largeIDSet(3000)[2:]
+	users, err := repo.List(ctx, repo.WhereInLarge("id", ids), repo.OrderBy("id", crud.SortAsc))
+	require.NoError(t, err)
+	require.Len(t, users, 3)
+	assert.Equal(t, "user3", users[0].Username)
+}
+
+func TestWhereInLargeCastsToColumnType(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo, err := crud.NewRepository[Shipment](db, "shipments", crud.PostgresDialect{})
+	require.NoError(t, err)
+
+	ids := make([]any, 1500)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("00000000-0000-0000-0000-%012d", i)
+	}
+	query, _, err := repo.ToSQL(repo.WhereInLarge("id", ids))
+	require.NoError(t, err)
+	assert.Contains(t, query, "WHERE id IN (SELECT v FROM (VALUES ($1::uuid), ($2)")
+
+	// The type comes from the field, not from the first value
+	query, _, err = repo.ToSQL(repo.WhereInLarge("shipments.count", largeIDSet(1500)))
+	require.NoError(t, err)
+	assert.Contains(t, query, "WHERE shipments.count IN (SELECT v FROM (VALUES ($1::integer), ($2)")
+}
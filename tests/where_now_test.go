@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/dimatock/crud"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWhereColumnOpNow(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE events (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL, at DATETIME NOT NULL);`)
+	require.NoError(t, err)
+
+	repo, err := crud.NewRepository[Event](db, "events", crud.SQLiteDialect{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+	_, err = repo.Create(ctx, Event{Name: "expired", At: now.Add(-time.Hour)})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, Event{Name: "active", At: now.Add(time.Hour)})
+	require.NoError(t, err)
+
+	events, err := repo.List(ctx, repo.WhereColumnOpNow("at", ">"))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "active", events[0].Name)
+
+	events, err = repo.List(ctx, repo.WhereColumnOpNow("at", "<="))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "expired", events[0].Name)
+
+	sql, args, err := repo.ToSQL(repo.WhereColumnOpNow("at", ">"))
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT events.id, events.name, events.at FROM events WHERE at > CURRENT_TIMESTAMP", sql)
+	assert.Empty(t, args)
+
+	_, _, err = repo.ToSQL(repo.WhereColumnOpNow("at", "> 0 OR 1 ="))
+	assert.Error(t, err)
+}
+
+func TestCurrentTimestampSQL(t *testing.T) {
+	assert.Equal(t, "CURRENT_TIMESTAMP", crud.PostgresDialect{}.CurrentTimestampSQL())
+	assert.Equal(t, "UTC_TIMESTAMP(6)", crud.MySQLDialect{}.CurrentTimestampSQL())
+	assert.Equal(t, "SYSUTCDATETIME()", crud.SQLServerDialect{}.CurrentTimestampSQL())
+}
@@ -0,0 +1,18 @@
+// Code generated by crudgen; DO NOT EDIT.
+
+package tests
+
+// CrudColumns returns the columns mapped by Widget.
+func (*Widget) CrudColumns() []string {
+	return []string{"id", "name", "color", "size_width", "size_height"}
+}
+
+// CrudScanTargets returns pointers to the mapped fields of m, in CrudColumns order.
+func (m *Widget) CrudScanTargets() []any {
+	return []any{&m.ID, &m.Name, &m.Color, &m.Size.Width, &m.Size.Height}
+}
+
+// CrudValues returns the values of the mapped fields of m, in CrudColumns order.
+func (m *Widget) CrudValues() []any {
+	return []any{m.ID, m.Name, m.Color, m.Size.Width, m.Size.Height}
+}
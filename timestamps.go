@@ -0,0 +1,72 @@
+package crud
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// timestampFields holds the positions in Repository.fields of the columns managed by WithTimestamps.
+type timestampFields struct {
+	created int // -1 if the struct has no such field
+	updated int // -1 if the struct has no such field
+}
+
+// resolveTimestampFields finds the fields for the given timestamp columns and checks their types.
+func resolveTimestampFields(fields []fieldInfo, createdCol, updatedCol string) (timestampFields, error) {
+	ts := timestampFields{created: -1, updated: -1}
+	for i, f := range fields {
+		if f.columnName != createdCol && f.columnName != updatedCol {
+			continue
+		}
+		if f.fieldType != timeType && f.fieldType != reflect.PointerTo(timeType) {
+			return ts, fmt.Errorf("timestamp column '%s' must be a time.Time or *time.Time field, got %s", f.columnName, f.fieldType)
+		}
+		if f.columnName == createdCol {
+			ts.created = i
+		}
+		if f.columnName == updatedCol {
+			ts.updated = i
+		}
+	}
+	if createdCol != "" && ts.created < 0 {
+		return ts, fmt.Errorf("unknown timestamp column '%s'", createdCol)
+	}
+	if updatedCol != "" && ts.updated < 0 {
+		return ts, fmt.Errorf("unknown timestamp column '%s'", updatedCol)
+	}
+	return ts, nil
+}
+
+// isInsertOnly reports whether the field at pos must not be overwritten by an upsert: it is either
+// tagged insertonly or is the created column managed by WithTimestamps.
+func (r *Repository[T]) isInsertOnly(pos int) bool {
+	return r.fields[pos].insertOnly || (r.timestamps != nil && r.timestamps.created == pos)
+}
+
+// stampTimes sets the managed timestamp fields of item to now: the updated column always,
+// and the created column as well when creating is true.
+func (r *Repository[T]) stampTimes(item *T, creating bool) {
+	if r.timestamps == nil {
+		return
+	}
+	now := time.Now().UTC()
+	val := reflect.ValueOf(item).Elem()
+	set := func(pos int) {
+		if pos < 0 {
+			return
+		}
+		field := val.FieldByIndex(r.fields[pos].index)
+		if field.Type() == timeType {
+			field.Set(reflect.ValueOf(now))
+		} else {
+			field.Set(reflect.ValueOf(&now))
+		}
+	}
+	if creating {
+		set(r.timestamps.created)
+	}
+	set(r.timestamps.updated)
+}
@@ -0,0 +1,183 @@
+package crud
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// TxBundle carries one transaction that any number of repositories, of any record types, can be bound
+// to with TxRepo, so that related writes (e.g. a parent and its children) commit or roll back together.
+type TxBundle struct {
+	tx         *sql.Tx
+	dialect    Dialect // Savepoint syntax for nested transactions (see SetDialect); nil until known
+	savepoints int     // Number of savepoints created so far, used to name nested transactions
+}
+
+// NewTxBundle wraps an existing transaction, for callers that manage Begin/Commit themselves.
+func NewTxBundle(tx *sql.Tx) *TxBundle {
+	return &TxBundle{tx: tx}
+}
+
+// SetDialect makes nested transactions (PropagationNested) use the savepoint statements of d, whose syntax
+// differs from the standard SAVEPOINT on some databases, such as SQL Server. Without it, the bundle takes the
+// dialect of the first repository bound with TxRepo; a nested transaction started before either fails.
+func (b *TxBundle) SetDialect(d Dialect) {
+	b.dialect = d
+}
+
+// Tx returns the underlying transaction.
+func (b *TxBundle) Tx() *sql.Tx {
+	return b.tx
+}
+
+// TxRepo returns repo bound to the bundle's transaction, equivalent to repo.WithTx(b.Tx()). The bundle
+// adopts the dialect of the first repository bound this way, unless SetDialect was called.
+// Since Go methods cannot have type parameters, this is a function rather than a TxBundle method.
+func TxRepo[T any](b *TxBundle, repo RepositoryInterface[T]) RepositoryInterface[T] {
+	if b.dialect == nil {
+		if base, err := baseOf(repo); err == nil {
+			b.dialect = base.dialect
+		}
+	}
+	return repo.WithTx(b.tx)
+}
+
+// TxBundleFromContext returns the bundle of the transaction started by RunInTransaction (or
+// RunInTransactionWith) that ctx was derived from, if any.
+func TxBundleFromContext(ctx context.Context) (*TxBundle, bool) {
+	b, ok := ctx.Value(txBundleKey{}).(*TxBundle)
+	return b, ok
+}
+
+type txBundleKey struct{}
+
+// RunInTransaction begins a transaction on db, calls fn with a TxBundle for it and commits if fn returns nil.
+// If fn returns an error or panics, the transaction is rolled back and the error (or panic) is propagated.
+// The repositories used inside fn must be bound with TxRepo and must have been created on db. The context
+// passed to fn carries the bundle (see TxBundleFromContext and RunInTransactionWith).
+func RunInTransaction(ctx context.Context, db *sql.DB, opts *sql.TxOptions, fn func(ctx context.Context, b *TxBundle) error) error {
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	b := NewTxBundle(tx)
+	if err := fn(context.WithValue(ctx, txBundleKey{}, b), b); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	committed = true
+	return nil
+}
+
+// RunInRollbackTransaction begins a transaction on db, calls fn with repo bound to it and rolls the
+// transaction back afterwards, whatever fn returns. It is meant for integration tests that need isolation
+// from each other without recreating the schema; fn's error is returned for assertions. repo must have
+// been created on db.
+func RunInRollbackTransaction[T any](
+	ctx context.Context, db *sql.DB, repo RepositoryInterface[T], fn func(repo RepositoryInterface[T]) error,
+) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	rolledBack := false
+	defer func() {
+		if !rolledBack {
+			_ = tx.Rollback()
+		}
+	}()
+
+	fnErr := fn(repo.WithTx(tx))
+	rolledBack = true
+	if err := tx.Rollback(); err != nil && fnErr == nil {
+		return fmt.Errorf("failed to roll back transaction: %w", err)
+	}
+	return fnErr
+}
+
+// Propagation selects how RunInTransactionWith relates to a transaction already running in the context.
+type Propagation int
+
+const (
+	// PropagationRequired joins the transaction in the context, or begins a new one if there is none.
+	PropagationRequired Propagation = iota
+	// PropagationRequiresNew always begins a new, independent transaction on a separate pooled connection,
+	// which commits or rolls back regardless of the outcome of the surrounding one.
+	PropagationRequiresNew
+	// PropagationNested runs inside a savepoint of the transaction in the context, so that an error only
+	// rolls back fn's own changes; without a surrounding transaction it behaves like PropagationRequired.
+	PropagationNested
+)
+
+// RunInTransactionWith runs fn in a transaction chosen by propagation, using the transaction that
+// RunInTransaction stored in ctx, if any:
+//
+//   - PropagationRequired: fn joins the surrounding transaction; its error is returned to the caller, who decides
+//     whether to roll back. Without a surrounding transaction it runs like RunInTransaction.
+//   - PropagationRequiresNew: fn always runs in a new transaction. database/sql cannot suspend a transaction, so
+//     the new one uses another connection from db's pool; the pool must allow more than one open connection, and
+//     the two transactions can block each other on the same rows.
+//   - PropagationNested: fn runs between the SAVEPOINT and RELEASE SAVEPOINT statements of the bundle's dialect
+//     (see TxBundle.SetDialect); on an error or panic the changes are rolled back to the savepoint and the
+//     surrounding transaction can continue. It fails if the surrounding bundle has no dialect yet.
+func RunInTransactionWith(ctx context.Context, db *sql.DB, propagation Propagation, fn func(ctx context.Context, b *TxBundle) error) error {
+	outer, inTx := TxBundleFromContext(ctx)
+	switch propagation {
+	case PropagationRequired:
+		if inTx {
+			return fn(ctx, outer)
+		}
+		return RunInTransaction(ctx, db, nil, fn)
+	case PropagationRequiresNew:
+		return RunInTransaction(ctx, db, nil, fn)
+	case PropagationNested:
+		if inTx {
+			return outer.runInSavepoint(ctx, fn)
+		}
+		return RunInTransaction(ctx, db, nil, fn)
+	default:
+		return fmt.Errorf("unknown transaction propagation %d", propagation)
+	}
+}
+
+// runInSavepoint calls fn inside a new savepoint of the bundle's transaction, releasing it if fn returns nil
+// and rolling back to it otherwise.
+func (b *TxBundle) runInSavepoint(ctx context.Context, fn func(ctx context.Context, b *TxBundle) error) error {
+	if b.dialect == nil {
+		return fmt.Errorf("nested transaction requires the dialect of the transaction; bind a repository with TxRepo or call SetDialect first")
+	}
+	b.savepoints++
+	create, release, rollback := savepointStatements(b.dialect, fmt.Sprintf("crud_sp_%d", b.savepoints))
+	if _, err := b.tx.ExecContext(ctx, create); err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	released := false
+	defer func() {
+		if !released {
+			_, _ = b.tx.ExecContext(ctx, rollback)
+		}
+	}()
+
+	if err := fn(ctx, b); err != nil {
+		return err
+	}
+	if release != "" {
+		if _, err := b.tx.ExecContext(ctx, release); err != nil {
+			return fmt.Errorf("failed to release savepoint: %w", err)
+		}
+	}
+	released = true
+	return nil
+}
@@ -0,0 +1,118 @@
+package crud
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Transformer converts a column's values on their way to and from the database, e.g. to encrypt PII.
+// Transformers are registered by name with RegisterTransformer and attached to columns with the
+// transform tag modifier, e.g. `db:"ssn,transform:aes"`.
+type Transformer struct {
+	// Encode receives the struct field's value and returns the value bound to the statement.
+	Encode func(value any) (any, error)
+	// Decode receives the value scanned from the database and returns the value stored in the struct field.
+	// The result must be assignable or convertible to the field's type; nil sets the field to its zero value.
+	Decode func(value any) (any, error)
+}
+
+var (
+	transformersMu sync.RWMutex
+	transformers   = map[string]Transformer{}
+)
+
+// RegisterTransformer registers a named transformer for use with the transform tag modifier.
+// Transformers are resolved when a repository is created, so they must be registered before NewRepository
+// is called. Registering a name again replaces the previous transformer for repositories created afterwards.
+func RegisterTransformer(name string, t Transformer) {
+	transformersMu.Lock()
+	defer transformersMu.Unlock()
+	transformers[name] = t
+}
+
+// lookupTransformer returns the transformer registered under name.
+func lookupTransformer(name string) (*Transformer, bool) {
+	transformersMu.RLock()
+	defer transformersMu.RUnlock()
+	t, ok := transformers[name]
+	if !ok {
+		return nil, false
+	}
+	return &t, true
+}
+
+// encodeField returns the value of the field to bind in a statement, applying its transformer if any.
+// Without a transformer the value is prepared for the driver by driverArg.
+func encodeField(val reflect.Value, f fieldInfo) (any, error) {
+	field := val.FieldByIndex(f.index)
+	if f.transformer == nil {
+		return driverArg(field), nil
+	}
+	return encodeValue(field.Interface(), f)
+}
+
+// valuerType is the reflect.Type of driver.Valuer.
+var valuerType = reflect.TypeFor[driver.Valuer]()
+
+// driverArg returns the value of field to hand to database/sql. A nil pointer is bound as NULL without
+// calling any Value method on it, and a field whose Value method has a pointer receiver is passed by
+// address (when it is addressable), since database/sql only recognizes a driver.Valuer in the value
+// it receives. Everything else, including value-receiver Valuers, is passed as is.
+func driverArg(field reflect.Value) any {
+	switch {
+	case field.Kind() == reflect.Pointer && field.IsNil():
+		return nil
+	case field.Type().Implements(valuerType):
+		return field.Interface()
+	case field.CanAddr() && reflect.PointerTo(field.Type()).Implements(valuerType):
+		return field.Addr().Interface()
+	}
+	return field.Interface()
+}
+
+// encodeValue applies the field's transformer, if any, to a value destined for its column.
+func encodeValue(value any, f fieldInfo) (any, error) {
+	if f.transformer == nil || f.transformer.Encode == nil {
+		return value, nil
+	}
+	encoded, err := f.transformer.Encode(value)
+	if err != nil {
+		return nil, fmt.Errorf("transform '%s' failed to encode column '%s': %w", f.transform, f.columnName, err)
+	}
+	return encoded, nil
+}
+
+// decodeField applies the field's transformer to a scanned value and stores the result in the field.
+func decodeField(val reflect.Value, f fieldInfo, raw any) error {
+	return decodeInto(val.FieldByIndex(f.index), f, raw)
+}
+
+// decodeInto applies the field's transformer to a scanned value and stores the result in dst,
+// which must have the field's type.
+func decodeInto(dst reflect.Value, f fieldInfo, raw any) error {
+	decoded := raw
+	if f.transformer.Decode != nil {
+		var err error
+		if decoded, err = f.transformer.Decode(raw); err != nil {
+			return fmt.Errorf("transform '%s' failed to decode column '%s': %w", f.transform, f.columnName, err)
+		}
+	}
+
+	if decoded == nil {
+		dst.SetZero()
+		return nil
+	}
+	dv := reflect.ValueOf(decoded)
+	switch {
+	case dv.Type().AssignableTo(dst.Type()):
+		dst.Set(dv)
+	case dv.Type().ConvertibleTo(dst.Type()):
+		dst.Set(dv.Convert(dst.Type()))
+	default:
+		return fmt.Errorf("transform '%s' decoded column '%s' to %s, which cannot be stored in a field of type %s",
+			f.transform, f.columnName, dv.Type(), dst.Type())
+	}
+	return nil
+}
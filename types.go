@@ -9,3 +9,12 @@ const (
 	// SortDesc specifies descending order.
 	SortDesc SortDirection = "DESC"
 )
+
+// Join kinds accepted by JoinTypeChecker.SupportsJoinType.
+const (
+	JoinInner = "INNER"
+	JoinLeft  = "LEFT"
+	JoinRight = "RIGHT"
+	JoinFull  = "FULL"
+	JoinCross = "CROSS"
+)
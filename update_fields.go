@@ -0,0 +1,159 @@
+package crud
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// UpdateFields updates only the given columns of the record with the given primary key, leaving all other
+// columns untouched. Every key of fields must be a column mapped by T other than the primary key; unknown
+// keys are rejected before any SQL is built, so column names cannot be used for injection. Values are bound
+// as parameters and pass through the column's transformer, if any.
+//
+// The updated column of WithTimestamps is set unless fields already contains it. It is rejected on a
+// repository created with WithVersionColumn, since the stored version cannot be checked; use Update there.
+// It returns sql.ErrNoRows if no record has the given id, or if it is
+// soft-deleted on a WithSoftDelete repository, unless the repository was created with WithAllowNoOpUpdate.
+func (r *Repository[T]) UpdateFields(ctx context.Context, id any, fields map[string]any) error {
+	if r.versionField >= 0 {
+		return fmt.Errorf("UpdateFields cannot check the version column '%s'; use Update", r.config.version)
+	}
+	setClauses, vals, err := r.buildSetClauses(fields)
+	if err != nil {
+		return err
+	}
+
+	vals = append(vals, id)
+	sqlQuery := r.dialect.UpdateSQL(r.quote(r.tableName), strings.Join(setClauses, ", "), r.quote(r.pkColumn), r.dialect.Placeholder(len(vals)))
+	sqlQuery += r.notDeletedSQL()
+
+	e, err := r.getExecutor(ctx)
+	if err != nil {
+		return err
+	}
+	res, err := e.ExecContext(ctx, sqlQuery, vals...)
+	if err != nil {
+		return fmt.Errorf("update failed: %w", duplicateError(r.dialect, err))
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("update successful, but failed to retrieve rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		if r.config.allowNoOp {
+			return nil
+		}
+		return sql.ErrNoRows // No row was updated
+	}
+
+	return r.notifyChange(ctx, "update", id)
+}
+
+// UpdateWhere sets the given columns on every record matching the WHERE conditions of opts and returns the
+// number of affected rows. Column names are validated and values bound as in UpdateFields, including the
+// WithTimestamps handling, and the column of WithVersionColumn is incremented without being checked. At least one WHERE condition is required to avoid
+// accidentally updating the whole table; joins are rejected, and ordering and limits are ignored. Like the
+// reads, it skips soft-deleted rows of a WithSoftDelete repository unless the WithTrashed option is given.
+func (r *Repository[T]) UpdateWhere(ctx context.Context, fields map[string]any, opts ...Option[T]) (int64, error) {
+	setClauses, vals, err := r.buildSetClauses(fields)
+	if err != nil {
+		return 0, err
+	}
+
+	// The SET values come first in the statement, so the WHERE placeholders are numbered after them
+	qb := r.newQueryBuilder()
+	qb.args = vals
+	for _, opt := range opts {
+		if err := opt.apply(qb); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(qb.whereClauses) == 0 {
+		return 0, fmt.Errorf("UpdateWhere requires at least one WHERE condition")
+	}
+	if len(qb.joinClauses) > 0 {
+		return 0, fmt.Errorf("UpdateWhere does not support joins; use a subquery condition instead")
+	}
+	sqlQuery := fmt.Sprintf("UPDATE %s SET %s WHERE %s", r.quote(r.tableName), strings.Join(setClauses, ", "), qb.whereSQL())
+
+	e, err := r.getExecutor(ctx)
+	if err != nil {
+		return 0, err
+	}
+	res, err := e.ExecContext(ctx, sqlQuery, qb.args...)
+	if err != nil {
+		return 0, fmt.Errorf("update failed: %w", duplicateError(r.dialect, err))
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("update successful, but failed to retrieve rows affected: %w", err)
+	}
+
+	if rowsAffected > 0 {
+		if err := r.notifyChange(ctx, "update"); err != nil {
+			return rowsAffected, err
+		}
+	}
+	return rowsAffected, nil
+}
+
+// buildSetClauses validates the columns of fields and returns the SET assignments with their bound values,
+// numbered from the first placeholder. The updated column of WithTimestamps and the WithVersionColumn
+// column are maintained as well.
+func (r *Repository[T]) buildSetClauses(fields map[string]any) ([]string, []any, error) {
+	if len(fields) == 0 {
+		return nil, nil, fmt.Errorf("at least one column to update is required")
+	}
+
+	columns := make([]string, 0, len(fields))
+	for col := range fields {
+		columns = append(columns, col)
+	}
+	slices.Sort(columns) // Deterministic SQL for statement caches and query recorders
+
+	byColumn := make(map[string]fieldInfo, len(r.fields))
+	for _, f := range r.fields {
+		byColumn[f.columnName] = f
+	}
+
+	setClauses := make([]string, 0, len(columns)+2)
+	vals := make([]any, 0, len(columns)+2)
+	for _, col := range columns {
+		f, ok := byColumn[col]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown column '%s' for table %s", col, r.tableName)
+		}
+		if f.isPK {
+			return nil, nil, fmt.Errorf("cannot update the primary key column '%s'", col)
+		}
+		if f.readOnly {
+			return nil, nil, fmt.Errorf("cannot update the readonly column '%s'", col)
+		}
+		value, err := encodeValue(fields[col], f)
+		if err != nil {
+			return nil, nil, err
+		}
+		vals = append(vals, value)
+		setClauses = append(setClauses, fmt.Sprintf("%s = %s", r.quote(col), r.dialect.Placeholder(len(vals))))
+	}
+
+	if r.timestamps != nil && r.timestamps.updated >= 0 {
+		col := r.fields[r.timestamps.updated].columnName
+		if _, set := fields[col]; !set {
+			vals = append(vals, time.Now().UTC())
+			setClauses = append(setClauses, fmt.Sprintf("%s = %s", r.quote(col), r.dialect.Placeholder(len(vals))))
+		}
+	}
+	if r.versionField >= 0 {
+		col := r.quote(r.config.version)
+		setClauses = append(setClauses, fmt.Sprintf("%s = %s + 1", col, col))
+	}
+
+	return setClauses, vals, nil
+}
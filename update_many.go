@@ -0,0 +1,200 @@
+package crud
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// pgTypePattern restricts pgtype modifiers to type names, since they are inserted into the SQL text.
+var pgTypePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_ ]*(\(\d+(\s*,\s*\d+)?\))?(\[\])?$`)
+
+// UpdateMany updates all items by primary key and returns the number of rows updated; items whose record
+// does not exist, or is soft-deleted on a WithSoftDelete repository, are skipped. Every non-primary-key
+// column is written, as with Update; on a repository created with WithVersionColumn, the version of every
+// item is checked and a mismatch returns ErrStaleObject.
+//
+// On PostgreSQL (see ValuesLister) the items are applied with a single statement per chunk, joining a
+// typed VALUES list:
+//
+//	UPDATE t SET col = v.col FROM (VALUES ($1::bigint, $2::text), ...) AS v(id, col) WHERE t.id = v.id
+//
+// The casts are inferred from the Go field types; columns whose database type differs (uuid, jsonb, enums,
+// transformed columns, ...) need a pgtype tag modifier, e.g. `db:"id,pk,pgtype:uuid"`. Other dialects, and
+// repositories created with WithVersionColumn, update the items one by one. When more than one statement is
+// needed they run in a single transaction (the repository's own if it was created with WithTx).
+// BeforeUpdateHook runs for every item before anything is written, and AfterUpdateHook for every item after.
+func (r *Repository[T]) UpdateMany(ctx context.Context, items []T) (int64, error) {
+	if len(items) == 0 {
+		return 0, nil
+	}
+
+	items = append([]T(nil), items...)
+	for i := range items {
+		if err := callHook(&items[i], "BeforeUpdate", func(h BeforeUpdateHook) error { return h.BeforeUpdate(ctx) }); err != nil {
+			return 0, err
+		}
+		r.stampTimes(&items[i], false)
+	}
+
+	lister, bulk := r.dialect.(ValuesLister)
+	bulk = bulk && r.versionField < 0
+	var types []string
+	if bulk {
+		var err error
+		if types, err = r.valuesColumnTypes(lister); err != nil {
+			return 0, err
+		}
+	}
+
+	chunkSize := 1
+	if bulk {
+		chunkSize = rowsPerStatement(r.dialect, len(r.columns))
+	}
+	run := func(txRepo *Repository[T]) (int64, error) {
+		var total int64
+		for start := 0; start < len(items); start += chunkSize {
+			chunk := items[start:min(start+chunkSize, len(items))]
+			n, err := txRepo.updateChunk(ctx, chunk, bulk, types)
+			if err != nil {
+				return total, err
+			}
+			total += n
+		}
+		return total, nil
+	}
+
+	var updated int64
+	var err error
+	if len(items) <= chunkSize || r.tx != nil {
+		updated, err = run(r)
+	} else {
+		tx, txErr := r.beginTx(ctx)
+		if txErr != nil {
+			return 0, txErr
+		}
+		txRepo := *r
+		txRepo.tx = tx
+		if updated, err = run(&txRepo); err != nil {
+			_ = tx.Rollback()
+			return 0, err
+		}
+		if err := tx.Commit(); err != nil {
+			return 0, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+	}
+	if err != nil {
+		return updated, err
+	}
+
+	for i := range items {
+		if err := callHook(&items[i], "AfterUpdate", func(h AfterUpdateHook) error { return h.AfterUpdate(ctx) }); err != nil {
+			return updated, err
+		}
+	}
+	return updated, nil
+}
+
+// updateChunk applies one chunk of UpdateMany, either as a single UPDATE ... FROM (VALUES ...) statement on
+// dialects implementing ValuesLister or as one Update per item.
+func (r *Repository[T]) updateChunk(ctx context.Context, items []T, bulk bool, types []string) (int64, error) {
+	if !bulk {
+		var n int64
+		for _, item := range items {
+			updated, rowsAffected, err := r.updateStatement(ctx, item)
+			if errors.Is(err, sql.ErrNoRows) || (err == nil && rowsAffected == 0) {
+				continue
+			}
+			if err != nil {
+				return n, err
+			}
+			if _, err := r.afterWrite(ctx, "update")(updated, nil); err != nil {
+				return n, err
+			}
+			n++
+		}
+		return n, nil
+	}
+
+	lister := r.dialect.(ValuesLister)
+	rows := make([][]string, len(items))
+	args := make([]any, 0, len(items)*len(r.columns))
+	for i := range items {
+		vals, err := r.fieldValues(&items[i])
+		if err != nil {
+			return 0, err
+		}
+		rows[i] = make([]string, len(vals))
+		for j := range vals {
+			rows[i][j] = r.dialect.Placeholder(len(args) + j + 1)
+		}
+		args = append(args, vals...)
+	}
+
+	cols := quoteIdents(r.dialect, r.columns)
+	pkColumn := r.quote(r.pkColumn)
+	setClauses := make([]string, 0, len(cols)-1)
+	for i, col := range cols {
+		if !r.fields[i].isPK && !r.fields[i].readOnly {
+			setClauses = append(setClauses, fmt.Sprintf("%s = v.%s", col, col))
+		}
+	}
+	sqlQuery := fmt.Sprintf("UPDATE %s SET %s FROM %s WHERE %s = v.%s%s",
+		r.quote(r.tableName),
+		strings.Join(setClauses, ", "),
+		lister.ValuesSQL("v", cols, types, rows),
+		r.qualify(r.pkColumn), pkColumn,
+		r.notDeletedSQL(),
+	)
+
+	e, err := r.getExecutor(ctx)
+	if err != nil {
+		return 0, err
+	}
+	res, err := e.ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return 0, fmt.Errorf("bulk update failed: %w", duplicateError(r.dialect, err))
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("bulk update successful, but failed to retrieve rows affected: %w", err)
+	}
+	if n > 0 {
+		if err := r.notifyChange(ctx, "update", r.pkValues(items)...); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// valuesColumnTypes returns the cast of every column for a typed VALUES list, from the pgtype tag modifier
+// or inferred from the field's Go type by the dialect.
+func (r *Repository[T]) valuesColumnTypes(lister ValuesLister) ([]string, error) {
+	types := make([]string, len(r.fields))
+	for i, f := range r.fields {
+		typ, err := valuesFieldType(lister, f)
+		if err != nil {
+			return nil, err
+		}
+		types[i] = typ
+	}
+	return types, nil
+}
+
+// valuesFieldType returns the cast of the column of f in a typed VALUES list.
+func valuesFieldType(lister ValuesLister, f fieldInfo) (string, error) {
+	if f.pgType != "" {
+		if !pgTypePattern.MatchString(f.pgType) {
+			return "", fmt.Errorf("invalid pgtype '%s' for column '%s'", f.pgType, f.columnName)
+		}
+		return f.pgType, nil
+	}
+	typ, ok := lister.ValuesType(f.fieldType)
+	if !ok || f.transformer != nil {
+		return "", fmt.Errorf("cannot infer the database type of column '%s' (%s); add a pgtype tag modifier", f.columnName, f.fieldType)
+	}
+	return typ, nil
+}
@@ -0,0 +1,58 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// resolveVersionField returns the position in fields of the optimistic locking column, which must be
+// mapped to an integer field.
+func resolveVersionField(fields []fieldInfo, column string) (int, error) {
+	for i, f := range fields {
+		if f.columnName != column {
+			continue
+		}
+		if f.isPK {
+			return -1, fmt.Errorf("version column '%s' cannot be the primary key", column)
+		}
+		if !isIntegerKind(f.fieldType.Kind()) {
+			return -1, fmt.Errorf("version column '%s' must be an integer field, got %s", column, f.fieldType)
+		}
+		return i, nil
+	}
+	return -1, fmt.Errorf("unknown version column '%s'", column)
+}
+
+// isIntegerKind reports whether k is a signed or unsigned integer kind.
+func isIntegerKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// bumpVersion increments the version field of item after a successful update.
+func (r *Repository[T]) bumpVersion(item *T) {
+	field := reflect.ValueOf(item).Elem().FieldByIndex(r.fields[r.versionField].index)
+	if field.CanInt() {
+		field.SetInt(field.Int() + 1)
+	} else {
+		field.SetUint(field.Uint() + 1)
+	}
+}
+
+// staleOrMissing tells apart the two reasons a versioned update can affect no rows: the record is gone
+// (sql.ErrNoRows) or it was modified concurrently (ErrStaleObject).
+func (r *Repository[T]) staleOrMissing(ctx context.Context, pkValue any) error {
+	exists, err := r.Exists(ctx, Where[T](r.pkColumn, pkValue), PreferPrimary[T]())
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: %s %v", ErrStaleObject, r.tableName, pkValue)
+	}
+	return ErrNotFound
+}